@@ -13,6 +13,10 @@ type Cache interface {
 	Set(ctx context.Context, key string, data interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string, data interface{}) error
 	Delete(ctx context.Context, key string) error
+
+	// SetNX sets key only when it isn't set, it reports whether key was
+	// set.
+	SetNX(ctx context.Context, key string, data interface{}, expiration time.Duration) (bool, error)
 }
 
 func NewCache(cfg config.CacheConfiguration) (Cache, error) {
@@ -3,12 +3,14 @@ package mcache
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/go-redis/cache/v8"
 )
 
 type MemoryCache struct {
+	mu    sync.Mutex
 	cache *cache.Cache
 }
 
@@ -48,3 +50,14 @@ func (m *MemoryCache) Get(ctx context.Context, key string, data interface{}) err
 func (m *MemoryCache) Delete(ctx context.Context, key string) error {
 	return m.cache.Delete(ctx, key)
 }
+
+func (m *MemoryCache) SetNX(ctx context.Context, key string, data interface{}, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cache.Exists(ctx, key) {
+		return false, nil
+	}
+
+	return true, m.Set(ctx, key, data, ttl)
+}
@@ -22,3 +22,7 @@ func (n *NoopCache) Get(ctx context.Context, key string, data interface{}) error
 func (n *NoopCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
+
+func (n *NoopCache) SetNX(ctx context.Context, key string, data interface{}, ttl time.Duration) (bool, error) {
+	return true, nil
+}
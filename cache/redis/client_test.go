@@ -59,3 +59,24 @@ func Test_DeleteFromCache(t *testing.T) {
 
 	require.Equal(t, "", item.Name)
 }
+
+func Test_SetNXToCache(t *testing.T) {
+	cache, err := NewRedisCache(getDSN())
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Delete(context.TODO(), key))
+
+	ok, err := cache.SetNX(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = cache.SetNX(context.TODO(), key, &data{Name: "other_name"}, 10*time.Second)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	var item data
+	err = cache.Get(context.TODO(), key, &item)
+
+	require.NoError(t, err)
+	require.Equal(t, "test_name", item.Name)
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 	_ "time/tzdata"
 
@@ -11,6 +13,7 @@ import (
 
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/internal/pkg/apm"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/rdb"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/google/uuid"
@@ -31,7 +34,9 @@ import (
 	"github.com/frain-dev/convoy/queue"
 	"github.com/spf13/cobra"
 
+	"github.com/frain-dev/convoy/datastore/memory"
 	"github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/datastore/postgres"
 )
 
 func main() {
@@ -51,7 +56,7 @@ func main() {
 	}
 
 	app := &app{}
-	db := &mongo.Client{}
+	db := &database{}
 
 	cli := NewCli(app, db)
 	if err := cli.Execute(); err != nil {
@@ -113,19 +118,23 @@ type app struct {
 	sourceRepo        datastore.SourceRepository
 	userRepo          datastore.UserRepository
 	configRepo        datastore.ConfigurationRepository
+	replayJobRepo     datastore.ReplayJobRepository
+	testAttemptRepo   datastore.TestAttemptRepository
+	attemptRepo       datastore.DeliveryAttemptRepository
 	queue             queue.Queuer
 	logger            logger.Logger
 	tracer            tracer.Tracer
 	cache             cache.Cache
 	limiter           limiter.RateLimiter
 	searcher          searcher.Searcher
+	payloads          *claimcheck.Store
 }
 
 func getCtx() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), time.Second*1)
 }
 
-func preRun(app *app, db *mongo.Client) func(cmd *cobra.Command, args []string) error {
+func preRun(app *app, db *database) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		cfgPath, err := cmd.Flags().GetString("config")
 		if err != nil {
@@ -166,13 +175,11 @@ func preRun(app *app, db *mongo.Client) func(cmd *cobra.Command, args []string)
 
 		apm.SetApplication(nRApp)
 
-		database, err := mongo.New(cfg)
+		db.Database, err = newDatabase(cfg)
 		if err != nil {
 			return err
 		}
 
-		*db = *database
-
 		var tr tracer.Tracer
 		var ca cache.Cache
 		var li limiter.RateLimiter
@@ -183,12 +190,17 @@ func preRun(app *app, db *mongo.Client) func(cmd *cobra.Command, args []string)
 			if err != nil {
 				return err
 			}
+			priorities := cfg.Queue.Priorities
 			queueNames := map[string]int{
-				string(convoy.PriorityQueue):    5,
-				string(convoy.EventQueue):       2,
-				string(convoy.CreateEventQueue): 2,
-				string(convoy.ScheduleQueue):    1,
-				string(convoy.DefaultQueue):     1,
+				string(convoy.PriorityQueue):                5,
+				string(convoy.HighPriorityEventQueue):       priorities.High,
+				string(convoy.HighPriorityCreateEventQueue): priorities.High,
+				string(convoy.EventQueue):                   priorities.Normal,
+				string(convoy.CreateEventQueue):             priorities.Normal,
+				string(convoy.LowPriorityEventQueue):        priorities.Low,
+				string(convoy.LowPriorityCreateEventQueue):  priorities.Low,
+				string(convoy.ScheduleQueue):                1,
+				string(convoy.DefaultQueue):                 1,
 			}
 			opts := queue.QueueOptions{
 				Names:             queueNames,
@@ -239,6 +251,9 @@ func preRun(app *app, db *mongo.Client) func(cmd *cobra.Command, args []string)
 		app.orgRepo = db.OrganisationRepo()
 		app.orgMemberRepo = db.OrganisationMemberRepo()
 		app.orgInviteRepo = db.OrganisationInviteRepo()
+		app.replayJobRepo = db.ReplayJobRepo()
+		app.testAttemptRepo = db.TestAttemptRepo()
+		app.attemptRepo = db.DeliveryAttemptRepo()
 
 		app.queue = q
 		app.logger = lo
@@ -246,12 +261,13 @@ func preRun(app *app, db *mongo.Client) func(cmd *cobra.Command, args []string)
 		app.cache = ca
 		app.limiter = li
 		app.searcher = se
+		app.payloads = claimcheck.NewStore(app.configRepo)
 
 		return ensureDefaultUser(context.Background(), app)
 	}
 }
 
-func postRun(app *app, db *mongo.Client) func(cmd *cobra.Command, args []string) error {
+func postRun(app *app, db *database) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		err := db.Disconnect(context.Background())
 		if err == nil {
@@ -269,7 +285,7 @@ func parsePersistentArgs(app *app, cmd *cobra.Command) {
 
 	cmd.PersistentFlags().StringVar(&configFile, "config", "./convoy.json", "Configuration file for convoy")
 	cmd.PersistentFlags().StringVar(&queue, "queue", "", "Queue provider (\"redis\")")
-	cmd.PersistentFlags().StringVar(&dbDsn, "db", "", "Database dsn or path to in-memory file")
+	cmd.PersistentFlags().StringVar(&dbDsn, "db", "", "Database dsn, memory:// or file://<path> for the in-memory database")
 	cmd.PersistentFlags().StringVar(&redisDsn, "redis", "", "Redis dsn")
 
 	cmd.AddCommand(addVersionCommand())
@@ -279,15 +295,68 @@ func parsePersistentArgs(app *app, cmd *cobra.Command) {
 	cmd.AddCommand(addWorkerCommand(app))
 	cmd.AddCommand(addRetryCommand(app))
 	cmd.AddCommand(addSchedulerCommand(app))
-	cmd.AddCommand(addUpgradeCommand(app))
+	cmd.AddCommand(addMigrateCommand())
+	cmd.AddCommand(addSecretsCommand())
 	cmd.AddCommand(addConfigCommand(app))
 }
 
+// database holds the datastore backend opened before a command runs.
+type database struct {
+	datastore.Database
+}
+
+// newDatabase opens the datastore backend of the configured database type.
+func newDatabase(cfg config.Configuration) (datastore.Database, error) {
+	switch cfg.Database.Type {
+	case config.PostgresDatabaseProvider:
+		db, err := postgres.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	case config.InMemoryDatabaseProvider:
+		db, err := memory.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	default:
+		db, err := mongo.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+}
+
+// databaseConfiguration returns the database configuration of a dsn given
+// on the command line. The in-memory datastore is given as memory://, or as
+// file://<path> to write it to a snapshot file.
+func databaseConfiguration(dsn string) (config.DatabaseConfiguration, error) {
+	switch {
+	case strings.HasPrefix(dsn, "mongodb://"), strings.HasPrefix(dsn, "mongodb+srv://"):
+		return config.DatabaseConfiguration{Type: config.MongodbDatabaseProvider, Dsn: dsn}, nil
+	case strings.HasPrefix(dsn, "postgres://"), strings.HasPrefix(dsn, "postgresql://"):
+		return config.DatabaseConfiguration{Type: config.PostgresDatabaseProvider, Dsn: dsn}, nil
+	case dsn == "memory://":
+		return config.DatabaseConfiguration{Type: config.InMemoryDatabaseProvider}, nil
+	case strings.HasPrefix(dsn, "file://"):
+		path := strings.TrimPrefix(dsn, "file://")
+		if util.IsStringEmpty(path) {
+			return config.DatabaseConfiguration{}, errors.New("database snapshot path is empty")
+		}
+
+		return config.DatabaseConfiguration{Type: config.InMemoryDatabaseProvider, Dsn: path}, nil
+	default:
+		return config.DatabaseConfiguration{}, fmt.Errorf("unsupported database dsn %s, use a mongodb://, postgres://, memory:// or file:// dsn", dsn)
+	}
+}
+
 type ConvoyCli struct {
 	cmd *cobra.Command
 }
 
-func NewCli(app *app, db *mongo.Client) ConvoyCli {
+func NewCli(app *app, db *database) ConvoyCli {
 	cmd := &cobra.Command{
 		Use:     "Convoy",
 		Version: convoy.GetVersion(),
@@ -319,9 +388,9 @@ func buildCliConfiguration(cmd *cobra.Command) (*config.Configuration, error) {
 	}
 
 	if !util.IsStringEmpty(dbDsn) {
-		c.Database = config.DatabaseConfiguration{
-			Type: config.MongodbDatabaseProvider,
-			Dsn:  dbDsn,
+		c.Database, err = databaseConfiguration(dbDsn)
+		if err != nil {
+			return nil, err
 		}
 	}
 
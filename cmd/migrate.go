@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/frain-dev/convoy/config"
+	convoyMongo "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
+	"github.com/frain-dev/convoy/internal/pkg/migrate"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// migrationCli holds the database the migrate commands run on.
+type migrationCli struct {
+	db       *convoyMongo.Client
+	migrator *migrate.Migrator
+}
+
+func addMigrateCommand() *cobra.Command {
+	m := &migrationCli{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Run the database migrations",
+		// the migrations run before the other commands can use the
+		// database, so only the database is opened.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadCliConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			if cfg.Database.Type != config.MongodbDatabaseProvider {
+				return fmt.Errorf("%s databases apply their migrations on startup", cfg.Database.Type)
+			}
+
+			cipher, err := encryption.New(cfg.Encryption)
+			if err != nil {
+				return err
+			}
+
+			m.db, err = convoyMongo.New(cfg)
+			if err != nil {
+				return err
+			}
+
+			hostname, err := os.Hostname()
+			if err != nil {
+				return err
+			}
+
+			conn := m.db.Client().(*mongo.Database)
+			owner := fmt.Sprintf("%s:%d", hostname, os.Getpid())
+
+			m.migrator, err = migrate.NewMigrator(convoyMongo.NewMigrationStore(conn), owner, convoyMongo.Migrations(conn, cipher))
+			return err
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return m.db.Disconnect(context.Background())
+		},
+	}
+
+	cmd.AddCommand(migrateUp(m))
+	cmd.AddCommand(migrateDown(m))
+	cmd.AddCommand(migrateStatus(m))
+	cmd.AddCommand(migrateUnlock(m))
+
+	return cmd
+}
+
+func migrateUp(m *migrationCli) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply the pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the migrations applied before a failure are listed too.
+			migrations, err := m.migrator.Up(context.Background(), dryRun)
+			if err == nil || len(migrations) > 0 {
+				printMigrations(migrations, dryRun, "apply", "Applied")
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the pending migrations without applying them")
+	return cmd
+}
+
+func migrateDown(m *migrationCli) *cobra.Command {
+	var dryRun bool
+	var steps int
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Roll back the last applied migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if steps < 1 {
+				return fmt.Errorf("steps must be at least 1")
+			}
+
+			migrations, err := m.migrator.Down(context.Background(), steps, dryRun)
+			if err == nil || len(migrations) > 0 {
+				printMigrations(migrations, dryRun, "roll back", "Rolled back")
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().IntVar(&steps, "steps", 1, "Number of migrations to roll back")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the migrations without rolling them back")
+	return cmd
+}
+
+func migrateStatus(m *migrationCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "List the migrations and whether they were applied",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			statuses, err := m.migrator.Status(context.Background())
+			if err != nil {
+				return err
+			}
+
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Version", "Description", "Applied at"})
+
+			for _, s := range statuses {
+				appliedAt := "pending"
+				if s.Applied {
+					appliedAt = s.AppliedAt.String()
+				}
+
+				table.Append([]string{strconv.Itoa(s.Version), s.Description, appliedAt})
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func migrateUnlock(m *migrationCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Release the migrations lock",
+		Long: "Releases the migrations lock held by an instance that stopped while migrating, " +
+			"check that no instance is still migrating first.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := m.migrator.Unlock(context.Background()); err != nil {
+				return err
+			}
+
+			fmt.Println("Released the migrations lock")
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func printMigrations(migrations []migrate.Migration, dryRun bool, action string, done string) {
+	if len(migrations) == 0 {
+		fmt.Printf("No migrations to %s\n", action)
+		return
+	}
+
+	for _, mg := range migrations {
+		if dryRun {
+			fmt.Printf("Would %s migration %d: %s\n", action, mg.Version, mg.Description)
+			continue
+		}
+
+		fmt.Printf("%s migration %d: %s\n", done, mg.Version, mg.Description)
+	}
+}
+
+// loadCliConfig loads the configuration file overridden with the cli
+// flags, it is used by the commands that don't need the app.
+func loadCliConfig(cmd *cobra.Command) (config.Configuration, error) {
+	cfgPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return config.Configuration{}, err
+	}
+
+	if err = config.LoadConfig(cfgPath); err != nil {
+		return config.Configuration{}, err
+	}
+
+	cliConfig, err := buildCliConfiguration(cmd)
+	if err != nil {
+		return config.Configuration{}, err
+	}
+
+	if err = config.Override(cliConfig); err != nil {
+		return config.Configuration{}, err
+	}
+
+	return config.Get()
+}
@@ -36,8 +36,9 @@ func addSchedulerCommand(a *app) *cobra.Command {
 			s := worker.NewScheduler(a.queue)
 
 			//register tasks
-			s.RegisterTask("30 * * * *", convoy.ScheduleQueue, convoy.MonitorTwitterSources)
+			s.RegisterTask("30 * * * *", convoy.ScheduleQueue, convoy.MonitorSourceHandshakes)
 			s.RegisterTask("55 23 * * *", convoy.ScheduleQueue, convoy.DailyAnalytics)
+			s.RegisterTask("@every 1m", convoy.ScheduleQueue, convoy.PollRestApiSources)
 			s.RegisterTask("@every 24h", convoy.ScheduleQueue, convoy.RetentionPolicies)
 
 			// Start scheduler
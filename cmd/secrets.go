@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/config"
+	convoyMongo "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
+	"github.com/spf13/cobra"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func addSecretsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage the encryption of the secrets stored in the database",
+		// the secrets commands only need the database, they open it
+		// themselves.
+		PersistentPreRun:  func(cmd *cobra.Command, args []string) {},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+	}
+
+	cmd.AddCommand(rotateKey())
+
+	return cmd
+}
+
+func rotateKey() *cobra.Command {
+	var reencrypt bool
+
+	cmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Add a new encryption key and encrypt the stored secrets with it",
+		Long: "Adds a new version of the key to the local key file, which makes it the key new " +
+			"secrets are encrypted with, and encrypts the stored secrets again with it. Secrets " +
+			"stored in plaintext are encrypted too. The previous keys are kept in the key file " +
+			"so secrets can still be decrypted while they are rotated, restart the servers and " +
+			"workers afterwards so they encrypt new secrets with the new key.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadCliConfig(cmd)
+			if err != nil {
+				return err
+			}
+
+			if cfg.Encryption.Type != config.LocalEncryptionProvider {
+				return fmt.Errorf("rotating keys requires the %s encryption provider", config.LocalEncryptionProvider)
+			}
+
+			if cfg.Database.Type != config.MongodbDatabaseProvider {
+				return fmt.Errorf("secrets aren't encrypted in %s databases", cfg.Database.Type)
+			}
+
+			// the key file is created by the first rotation.
+			if !reencrypt {
+				key, err := encryption.GenerateLocalKey(cfg.Encryption.Local.KeyFile)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Added key %s (version %d) to %s\n", key.ID, key.Version, cfg.Encryption.Local.KeyFile)
+			}
+
+			// the cipher is created after the key was added so it
+			// encrypts with the new key.
+			cipher, err := encryption.New(cfg.Encryption)
+			if err != nil {
+				return err
+			}
+
+			db, err := convoyMongo.New(cfg)
+			if err != nil {
+				return err
+			}
+			defer db.Disconnect(context.Background())
+
+			updated, err := convoyMongo.RotateSecrets(context.Background(), db.Client().(*mongo.Database), cipher)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Encrypted the secrets of %d documents with the current key\n", updated)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&reencrypt, "reencrypt", false, "Encrypt the secrets with the current key without adding a new one")
+	return cmd
+}
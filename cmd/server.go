@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -8,6 +9,9 @@ import (
 	"github.com/frain-dev/convoy/analytics"
 	"github.com/frain-dev/convoy/auth/realm_chain"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/changestream"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
 	"github.com/frain-dev/convoy/internal/pkg/server"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
 	route "github.com/frain-dev/convoy/server"
@@ -157,18 +161,21 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 
 	handler := route.NewApplicationHandler(
 		route.Repos{
-			EventRepo:         a.eventRepo,
-			EventDeliveryRepo: a.eventDeliveryRepo,
-			AppRepo:           a.applicationRepo,
-			GroupRepo:         a.groupRepo,
-			ApiKeyRepo:        a.apiKeyRepo,
-			SubRepo:           a.subRepo,
-			SourceRepo:        a.sourceRepo,
-			OrgRepo:           a.orgRepo,
-			OrgMemberRepo:     a.orgMemberRepo,
-			OrgInviteRepo:     a.orgInviteRepo,
-			UserRepo:          a.userRepo,
-			ConfigRepo:        a.configRepo,
+			EventRepo:           a.eventRepo,
+			EventDeliveryRepo:   a.eventDeliveryRepo,
+			AppRepo:             a.applicationRepo,
+			GroupRepo:           a.groupRepo,
+			ApiKeyRepo:          a.apiKeyRepo,
+			SubRepo:             a.subRepo,
+			SourceRepo:          a.sourceRepo,
+			OrgRepo:             a.orgRepo,
+			OrgMemberRepo:       a.orgMemberRepo,
+			OrgInviteRepo:       a.orgInviteRepo,
+			UserRepo:            a.userRepo,
+			ConfigRepo:          a.configRepo,
+			ReplayJobRepo:       a.replayJobRepo,
+			TestAttemptRepo:     a.testAttemptRepo,
+			DeliveryAttemptRepo: a.attemptRepo,
 		}, route.Services{
 			Queue:    a.queue,
 			Logger:   a.logger,
@@ -176,6 +183,7 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 			Cache:    a.cache,
 			Limiter:  a.limiter,
 			Searcher: a.searcher,
+			Payloads: a.payloads,
 		})
 
 	if withWorkers {
@@ -194,10 +202,12 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 		consumer.RegisterHandlers(convoy.EventProcessor, task.ProcessEventDelivery(
 			a.applicationRepo,
 			a.eventDeliveryRepo,
+			a.attemptRepo,
 			a.groupRepo,
 			a.limiter,
 			a.subRepo,
-			a.queue))
+			a.queue,
+			a.payloads))
 
 		consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
 			a.applicationRepo,
@@ -207,7 +217,22 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 			a.cache,
 			a.queue,
 			a.subRepo,
-			a.searcher))
+			a.searcher,
+			a.payloads))
+
+		consumer.RegisterHandlers(convoy.ReplayJobProcessor, task.ProcessReplayJob(
+			a.replayJobRepo,
+			a.eventRepo,
+			a.groupRepo,
+			a.applicationRepo,
+			a.eventDeliveryRepo,
+			a.subRepo,
+			a.queue))
+
+		consumer.RegisterHandlers(convoy.ResumeSubscription, task.ProcessSubscriptionResume(
+			a.eventDeliveryRepo,
+			a.subRepo,
+			a.queue))
 
 		consumer.RegisterHandlers(convoy.RetentionPolicies, task.RententionPolicies(
 			cfg,
@@ -215,14 +240,21 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 			a.groupRepo,
 			a.eventRepo,
 			a.eventDeliveryRepo,
-			a.searcher))
+			a.attemptRepo,
+			a.searcher,
+			a.payloads))
 
-		consumer.RegisterHandlers(convoy.MonitorTwitterSources, task.MonitorTwitterSources(
+		consumer.RegisterHandlers(convoy.MonitorSourceHandshakes, task.MonitorSourceHandshakes(
 			a.sourceRepo,
 			a.subRepo,
 			a.applicationRepo,
 			a.queue))
 
+		consumer.RegisterHandlers(convoy.PollRestApiSources, task.PollRestApiSources(
+			a.sourceRepo,
+			a.eventRepo,
+			a.queue))
+
 		consumer.RegisterHandlers(convoy.DailyAnalytics, analytics.TrackDailyAnalytics(&analytics.Repo{
 			ConfigRepo: a.configRepo,
 			EventRepo:  a.eventRepo,
@@ -237,6 +269,12 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 		//start worker
 		log.Infof("Starting Convoy workers...")
 		consumer.Start()
+
+		// consume pub sub sources.
+		// change streams don't share changes between consumers, so
+		// each source is consumed by the one worker holding its lease.
+		go pubsub.NewSupervisor(a.sourceRepo, a.queue, datastore.PubSubSource, pubsub.NewBroker, nil).Run(context.Background())
+		go pubsub.NewSupervisor(a.sourceRepo, a.queue, datastore.DBChangeStream, changestream.NewBroker, pubsub.NewCacheLease(a.cache)).Run(context.Background())
 	}
 
 	srv.SetHandler(handler.BuildRoutes())
@@ -8,7 +8,10 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/analytics"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/changestream"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
 	"github.com/frain-dev/convoy/worker"
 	"github.com/frain-dev/convoy/worker/task"
@@ -48,10 +51,12 @@ func addWorkerCommand(a *app) *cobra.Command {
 			consumer.RegisterHandlers(convoy.EventProcessor, task.ProcessEventDelivery(
 				a.applicationRepo,
 				a.eventDeliveryRepo,
+				a.attemptRepo,
 				a.groupRepo,
 				a.limiter,
 				a.subRepo,
-				a.queue))
+				a.queue,
+				a.payloads))
 
 			consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
 				a.applicationRepo,
@@ -61,7 +66,22 @@ func addWorkerCommand(a *app) *cobra.Command {
 				a.cache,
 				a.queue,
 				a.subRepo,
-				a.searcher))
+				a.searcher,
+				a.payloads))
+
+			consumer.RegisterHandlers(convoy.ReplayJobProcessor, task.ProcessReplayJob(
+				a.replayJobRepo,
+				a.eventRepo,
+				a.groupRepo,
+				a.applicationRepo,
+				a.eventDeliveryRepo,
+				a.subRepo,
+				a.queue))
+
+			consumer.RegisterHandlers(convoy.ResumeSubscription, task.ProcessSubscriptionResume(
+				a.eventDeliveryRepo,
+				a.subRepo,
+				a.queue))
 
 			consumer.RegisterHandlers(convoy.RetentionPolicies, task.RententionPolicies(
 				cfg,
@@ -69,14 +89,21 @@ func addWorkerCommand(a *app) *cobra.Command {
 				a.groupRepo,
 				a.eventRepo,
 				a.eventDeliveryRepo,
-				a.searcher))
+				a.attemptRepo,
+				a.searcher,
+				a.payloads))
 
-			consumer.RegisterHandlers(convoy.MonitorTwitterSources, task.MonitorTwitterSources(
+			consumer.RegisterHandlers(convoy.MonitorSourceHandshakes, task.MonitorSourceHandshakes(
 				a.sourceRepo,
 				a.subRepo,
 				a.applicationRepo,
 				a.queue))
 
+			consumer.RegisterHandlers(convoy.PollRestApiSources, task.PollRestApiSources(
+				a.sourceRepo,
+				a.eventRepo,
+				a.queue))
+
 			consumer.RegisterHandlers(convoy.DailyAnalytics, analytics.TrackDailyAnalytics(&analytics.Repo{
 				ConfigRepo: a.configRepo,
 				EventRepo:  a.eventRepo,
@@ -92,6 +119,12 @@ func addWorkerCommand(a *app) *cobra.Command {
 			log.Infof("Starting Convoy workers...")
 			consumer.Start()
 
+			// consume pub sub sources.
+			// change streams don't share changes between consumers, so
+			// each source is consumed by the one worker holding its lease.
+			go pubsub.NewSupervisor(a.sourceRepo, a.queue, datastore.PubSubSource, pubsub.NewBroker, nil).Run(ctx)
+			go pubsub.NewSupervisor(a.sourceRepo, a.queue, datastore.DBChangeStream, changestream.NewBroker, pubsub.NewCacheLease(a.cache)).Run(ctx)
+
 			metrics.RegisterQueueMetrics(a.queue)
 
 			router := chi.NewRouter()
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"strings"
@@ -18,6 +19,14 @@ const (
 	MaxResponseSize   = MaxResponseSizeKb * 1024 // in bytes
 	MaxRequestSize    = MaxResponseSize
 
+	// MaxSourceRequestSize bounds the body size sources can accept.
+	MaxSourceRequestSize = 10 * 1024 * 1024 // in bytes
+
+	// DefaultDeduplicationWindow and MaxDeduplicationWindow bound how
+	// long sources drop retries of an ingested request.
+	DefaultDeduplicationWindow = 60 * 60          // in seconds
+	MaxDeduplicationWindow     = 7 * 24 * 60 * 60 // in seconds
+
 	DefaultHost = "localhost:5005"
 )
 
@@ -43,6 +52,11 @@ var DefaultConfiguration = Configuration{
 		Redis: RedisQueueConfiguration{
 			Dsn: "redis://localhost:6378",
 		},
+		Priorities: QueuePriorityConfiguration{
+			High:   6,
+			Normal: 2,
+			Low:    1,
+		},
 	},
 }
 
@@ -61,11 +75,25 @@ type HTTPServerConfiguration struct {
 	SSLKeyFile  string `json:"ssl_key_file" envconfig:"CONVOY_SSL_KEY_FILE"`
 	Port        uint32 `json:"port" envconfig:"PORT"`
 	WorkerPort  uint32 `json:"worker_port" envconfig:"WORKER_PORT"`
+
+	// TrustedProxies are the CIDRs of proxies whose X-Forwarded-For
+	// header is used to find the client IP of ingested requests.
+	TrustedProxies []string `json:"trusted_proxies" envconfig:"CONVOY_TRUSTED_PROXIES"`
 }
 
 type QueueConfiguration struct {
 	Type  QueueProvider           `json:"type" envconfig:"CONVOY_QUEUE_PROVIDER"`
 	Redis RedisQueueConfiguration `json:"redis"`
+
+	// Priorities are the relative weights of the high, normal and low
+	// priority event queues.
+	Priorities QueuePriorityConfiguration `json:"priorities"`
+}
+
+type QueuePriorityConfiguration struct {
+	High   int `json:"high" envconfig:"CONVOY_QUEUE_HIGH_PRIORITY_WEIGHT"`
+	Normal int `json:"normal" envconfig:"CONVOY_QUEUE_NORMAL_PRIORITY_WEIGHT"`
+	Low    int `json:"low" envconfig:"CONVOY_QUEUE_LOW_PRIORITY_WEIGHT"`
 }
 
 type PrometheusConfiguration struct {
@@ -158,6 +186,18 @@ type TypesenseConfiguration struct {
 	ApiKey string `json:"api_key" envconfig:"CONVOY_TYPESENSE_API_KEY"`
 }
 
+// EncryptionConfiguration configures the key provider secrets stored in
+// the database are encrypted with, they are stored in plaintext when no
+// provider is set.
+type EncryptionConfiguration struct {
+	Type  EncryptionProvider           `json:"type" envconfig:"CONVOY_ENCRYPTION_PROVIDER"`
+	Local LocalEncryptionConfiguration `json:"local"`
+}
+
+type LocalEncryptionConfiguration struct {
+	KeyFile string `json:"key_file" envconfig:"CONVOY_ENCRYPTION_KEY_FILE"`
+}
+
 const (
 	envPrefix              string = "convoy"
 	DevelopmentEnvironment string = "development"
@@ -175,6 +215,8 @@ const (
 	RedisLimiterProvider               LimiterProvider         = "redis"
 	MongodbDatabaseProvider            DatabaseProvider        = "mongodb"
 	InMemoryDatabaseProvider           DatabaseProvider        = "in-memory"
+	PostgresDatabaseProvider           DatabaseProvider        = "postgres"
+	LocalEncryptionProvider            EncryptionProvider      = "local"
 )
 
 type AuthProvider string
@@ -187,6 +229,7 @@ type CacheProvider string
 type LimiterProvider string
 type DatabaseProvider string
 type SearchProvider string
+type EncryptionProvider string
 
 func (s SignatureHeaderProvider) String() string {
 	return string(s)
@@ -208,6 +251,7 @@ type Configuration struct {
 	Limiter         LimiterConfiguration    `json:"limiter"`
 	Host            string                  `json:"host" envconfig:"CONVOY_HOST"`
 	Search          SearchConfiguration     `json:"search"`
+	Encryption      EncryptionConfiguration `json:"encryption"`
 }
 
 // Get fetches the application configuration. LoadConfig must have been called
@@ -231,8 +275,21 @@ func Override(newCfg *Configuration) error {
 		return err
 	}
 
+	// the queue given on the command line only sets the type and the dsn,
+	// it keeps the settings of the loaded queue it doesn't set.
+	cfg := *newCfg
+	if cfg.Queue.Type != "" {
+		if cfg.Queue.Redis.Dsn == "" && cfg.Queue.Type == c.Queue.Type {
+			cfg.Queue.Redis.Dsn = c.Queue.Redis.Dsn
+		}
+
+		if cfg.Queue.Priorities == (QueuePriorityConfiguration{}) {
+			cfg.Queue.Priorities = c.Queue.Priorities
+		}
+	}
+
 	ov := reflect.ValueOf(&c).Elem()
-	nv := reflect.ValueOf(newCfg).Elem()
+	nv := reflect.ValueOf(&cfg).Elem()
 
 	for i := 0; i < ov.NumField(); i++ {
 		if !ov.Field(i).CanInterface() {
@@ -249,6 +306,14 @@ func Override(newCfg *Configuration) error {
 		ov.Field(i).Set(reflect.ValueOf(fv))
 	}
 
+	if err = ensureQueueConfig(c.Queue); err != nil {
+		return err
+	}
+
+	if err = ensureEncryptionConfig(c.Encryption, c.Database); err != nil {
+		return err
+	}
+
 	cfgSingleton.Store(&c)
 	return nil
 }
@@ -297,6 +362,19 @@ func ensureSSL(s ServerConfiguration) error {
 	return nil
 }
 
+func ensureTrustedProxies(s ServerConfiguration) error {
+	for _, proxy := range s.HTTP.TrustedProxies {
+		if net.ParseIP(proxy) != nil {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			return fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+	}
+	return nil
+}
+
 func ensureQueueConfig(queueCfg QueueConfiguration) error {
 	switch queueCfg.Type {
 	case RedisQueueProvider:
@@ -307,6 +385,29 @@ func ensureQueueConfig(queueCfg QueueConfiguration) error {
 	default:
 		return fmt.Errorf("unsupported queue type: %s", queueCfg.Type)
 	}
+
+	p := queueCfg.Priorities
+	if p.High <= 0 || p.Normal <= 0 || p.Low <= 0 {
+		return errors.New("queue priority weights must be greater than zero")
+	}
+	return nil
+}
+
+func ensureEncryptionConfig(c EncryptionConfiguration, db DatabaseConfiguration) error {
+	// only the mongodb repositories encrypt the secrets they store.
+	if c.Type != "" && db.Type != MongodbDatabaseProvider {
+		return fmt.Errorf("encryption is not supported for the %s database", db.Type)
+	}
+
+	switch c.Type {
+	case "":
+	case LocalEncryptionProvider:
+		if c.Local.KeyFile == "" {
+			return errors.New("local encryption key file is empty")
+		}
+	default:
+		return fmt.Errorf("unsupported encryption provider: %s", c.Type)
+	}
 	return nil
 }
 
@@ -335,5 +436,13 @@ func validate(c *Configuration) error {
 		return err
 	}
 
+	if err := ensureTrustedProxies(c.Server); err != nil {
+		return err
+	}
+
+	if err := ensureEncryptionConfig(c.Encryption, c.Database); err != nil {
+		return err
+	}
+
 	return nil
 }
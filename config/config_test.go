@@ -109,6 +109,7 @@ func TestLoadConfig(t *testing.T) {
 					Redis: RedisQueueConfiguration{
 						Dsn: "redis://localhost:8379",
 					},
+					Priorities: DefaultConfiguration.Queue.Priorities,
 				},
 				Search: DefaultConfiguration.Search,
 				Server: ServerConfiguration{
@@ -140,6 +141,7 @@ func TestLoadConfig(t *testing.T) {
 					Redis: RedisQueueConfiguration{
 						Dsn: "redis://localhost:8379",
 					},
+					Priorities: DefaultConfiguration.Queue.Priorities,
 				},
 				Server: ServerConfiguration{
 					HTTP: HTTPServerConfiguration{
@@ -171,6 +173,7 @@ func TestLoadConfig(t *testing.T) {
 					Redis: RedisQueueConfiguration{
 						Dsn: "redis://localhost:8379",
 					},
+					Priorities: DefaultConfiguration.Queue.Priorities,
 				},
 				Search: DefaultConfiguration.Search,
 				Server: ServerConfiguration{
@@ -220,6 +223,38 @@ func TestLoadConfig(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "unsupported queue type: abc",
 		},
+		{
+			name: "should_error_for_zero_queue_priority_weight",
+			args: args{
+				path: "./testdata/Config/zero-queue-priority-weight.json",
+			},
+			wantErr:    true,
+			wantErrMsg: "queue priority weights must be greater than zero",
+		},
+		{
+			name: "should_error_for_invalid_trusted_proxy",
+			args: args{
+				path: "./testdata/Config/invalid-trusted-proxy.json",
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid trusted proxy: 10.0.0.0/33",
+		},
+		{
+			name: "should_error_for_empty_encryption_key_file",
+			args: args{
+				path: "./testdata/Config/empty-encryption-key-file.json",
+			},
+			wantErr:    true,
+			wantErrMsg: "local encryption key file is empty",
+		},
+		{
+			name: "should_error_for_encryption_with_postgres",
+			args: args{
+				path: "./testdata/Config/encryption-with-postgres.json",
+			},
+			wantErr:    true,
+			wantErrMsg: "encryption is not supported for the postgres database",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -292,6 +327,21 @@ func TestOverride(t *testing.T) {
 			},
 			configType: "queue",
 		},
+		{
+			name: "should_override_queue_type_and_dsn",
+			args: args{
+				path: "./testdata/Config/valid-convoy.json",
+			},
+			config: &Configuration{
+				Queue: QueueConfiguration{
+					Type: RedisQueueProvider,
+					Redis: RedisQueueConfiguration{
+						Dsn: "redis://localhost:6380",
+					},
+				},
+			},
+			configType: "queue",
+		},
 	}
 
 	for _, tc := range tests {
@@ -313,7 +363,9 @@ func TestOverride(t *testing.T) {
 			case "database":
 				require.Equal(t, c.Database, tc.config.Database)
 			case "queue":
-				require.Equal(t, c.Queue, tc.config.Queue)
+				require.Equal(t, tc.config.Queue.Type, c.Queue.Type)
+				require.Equal(t, tc.config.Queue.Redis, c.Queue.Redis)
+				require.Equal(t, DefaultConfiguration.Queue.Priorities, c.Queue.Priorities)
 			default:
 			}
 		})
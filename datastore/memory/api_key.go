@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type apiKeyRepo struct {
+	table *table
+}
+
+func newApiKeyRepo(c *Client) datastore.APIKeyRepository {
+	return &apiKeyRepo{
+		table: c.tables[APIKeyTable],
+	}
+}
+
+func apiKeyMatcher(fn func(*datastore.APIKey) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.APIKey)) }
+}
+
+func (db *apiKeyRepo) CreateAPIKey(ctx context.Context, apiKey *datastore.APIKey) error {
+	apiKey.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(apiKey.UID) {
+		apiKey.UID = uuid.New().String()
+	}
+
+	return db.table.insert(apiKey)
+}
+
+func (db *apiKeyRepo) UpdateAPIKey(ctx context.Context, apiKey *datastore.APIKey) error {
+	m := apiKeyMatcher(func(a *datastore.APIKey) bool { return a.UID == apiKey.UID })
+
+	return db.table.update(m, false, func(doc interface{}) {
+		*doc.(*datastore.APIKey) = *apiKey
+	})
+}
+
+func (db *apiKeyRepo) FindAPIKeyByID(ctx context.Context, uid string) (*datastore.APIKey, error) {
+	return db.findAPIKey(apiKeyMatcher(func(a *datastore.APIKey) bool { return a.UID == uid }))
+}
+
+func (db *apiKeyRepo) FindAPIKeyByMaskID(ctx context.Context, maskID string) (*datastore.APIKey, error) {
+	return db.findAPIKey(apiKeyMatcher(func(a *datastore.APIKey) bool { return a.MaskID == maskID }))
+}
+
+func (db *apiKeyRepo) FindAPIKeyByHash(ctx context.Context, hash string) (*datastore.APIKey, error) {
+	return db.findAPIKey(apiKeyMatcher(func(a *datastore.APIKey) bool { return a.Hash == hash }))
+}
+
+func (db *apiKeyRepo) findAPIKey(m matcher) (*datastore.APIKey, error) {
+	apiKey := &datastore.APIKey{}
+
+	err := db.table.findOne(m, apiKey)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrAPIKeyNotFound
+	}
+
+	return apiKey, err
+}
+
+func (db *apiKeyRepo) RevokeAPIKeys(ctx context.Context, uids []string) error {
+	m := apiKeyMatcher(func(a *datastore.APIKey) bool { return in(a.UID, uids) })
+
+	return db.table.update(m, true, softDelete)
+}
+
+func (db *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	var apiKeys []datastore.APIKey
+
+	paginationData, err := db.table.paged(all, pageable.Sort, *pageable, &apiKeys)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return apiKeys, paginationData, nil
+}
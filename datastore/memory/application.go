@@ -0,0 +1,236 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type appRepo struct {
+	table  *table
+	events *table
+}
+
+func newApplicationRepo(c *Client) datastore.ApplicationRepository {
+	return &appRepo{
+		table:  c.tables[AppTable],
+		events: c.tables[EventTable],
+	}
+}
+
+func appMatcher(fn func(*datastore.Application) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.Application)) }
+}
+
+func (db *appRepo) CreateApplication(ctx context.Context, app *datastore.Application, groupID string) error {
+	err := db.assertUniqueAppTitle(app, groupID)
+	if err != nil {
+		return err
+	}
+
+	app.ID = primitive.NewObjectID()
+	return db.table.insert(app)
+}
+
+func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	var title *regexp.Regexp
+	if !util.IsStringEmpty(q) {
+		var err error
+		title, err = regexp.Compile("(?i)" + q)
+		if err != nil {
+			return nil, datastore.PaginationData{}, fmt.Errorf("invalid application title query: %v", err)
+		}
+	}
+
+	m := appMatcher(func(a *datastore.Application) bool {
+		if !util.IsStringEmpty(groupID) && a.GroupID != groupID {
+			return false
+		}
+
+		return title == nil || title.MatchString(a.Title)
+	})
+
+	var apps []datastore.Application
+	paginationData, err := db.table.paged(m, -1, pageable, &apps)
+	if err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	db.countEvents(apps)
+
+	return apps, paginationData, nil
+}
+
+func (db *appRepo) assertUniqueAppTitle(app *datastore.Application, groupID string) error {
+	m := appMatcher(func(a *datastore.Application) bool {
+		return a.UID != app.UID && a.Title == app.Title && a.GroupID == groupID
+	})
+
+	if db.table.count(m) != 0 {
+		return datastore.ErrDuplicateAppName
+	}
+
+	return nil
+}
+
+func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	m := appMatcher(func(a *datastore.Application) bool { return a.GroupID == groupID })
+
+	var apps []datastore.Application
+	paginationData, err := db.table.paged(m, -1, pageable, &apps)
+	if err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	db.countEvents(apps)
+
+	return apps, paginationData, nil
+}
+
+func (db *appRepo) CountGroupApplications(ctx context.Context, groupID string) (int64, error) {
+	return db.table.count(appMatcher(func(a *datastore.Application) bool { return a.GroupID == groupID })), nil
+}
+
+func (db *appRepo) SearchApplicationsByGroupId(ctx context.Context, groupId string, searchParams datastore.SearchParams) ([]datastore.Application, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = searchParams.CreatedAtStart
+	}
+
+	m := appMatcher(func(a *datastore.Application) bool {
+		return a.GroupID == groupId && between(a.CreatedAt, start, end)
+	})
+
+	apps := make([]datastore.Application, 0)
+	err := db.table.find(m, 1, 0, 0, &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	db.countEvents(apps)
+
+	return apps, nil
+}
+
+func (db *appRepo) FindApplicationByID(ctx context.Context, id string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	err := db.table.findOne(appMatcher(func(a *datastore.Application) bool { return a.UID == id }), app)
+	if errors.Is(err, errNotFound) {
+		return app, datastore.ErrApplicationNotFound
+	}
+
+	if err != nil {
+		return app, err
+	}
+
+	app.Events = db.countAppEvents(app.UID)
+	return app, nil
+}
+
+// countEvents sets the number of events of each app.
+func (db *appRepo) countEvents(apps []datastore.Application) {
+	for i := range apps {
+		apps[i].Events = db.countAppEvents(apps[i].UID)
+	}
+}
+
+func (db *appRepo) countAppEvents(appID string) int64 {
+	return db.events.count(func(doc interface{}) bool { return doc.(*datastore.Event).AppID == appID })
+}
+
+func (db *appRepo) FindApplicationEndpointByID(ctx context.Context, appID string, endpointID string) (*datastore.Endpoint, error) {
+	app, err := db.FindApplicationByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findEndpoint(&app.Endpoints, endpointID)
+}
+
+func (db *appRepo) FindApplicationByEndpointVerificationToken(ctx context.Context, token string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	m := appMatcher(func(a *datastore.Application) bool {
+		for _, endpoint := range a.Endpoints {
+			if endpoint.VerificationToken == token {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	err := db.table.findOne(m, app)
+	if errors.Is(err, errNotFound) {
+		return app, datastore.ErrEndpointNotFound
+	}
+
+	return app, err
+}
+
+func findEndpoint(endpoints *[]datastore.Endpoint, id string) (*datastore.Endpoint, error) {
+	for _, endpoint := range *endpoints {
+		if endpoint.UID == id && endpoint.DeletedAt == 0 {
+			return &endpoint, nil
+		}
+	}
+	return nil, datastore.ErrEndpointNotFound
+}
+
+func (db *appRepo) UpdateApplication(ctx context.Context, app *datastore.Application, groupID string) error {
+	err := db.assertUniqueAppTitle(app, groupID)
+	if err != nil {
+		return err
+	}
+
+	app.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	return db.table.update(appMatcher(func(a *datastore.Application) bool { return a.UID == app.UID }), false, func(doc interface{}) {
+		a := doc.(*datastore.Application)
+		a.Endpoints = app.Endpoints
+		a.UpdatedAt = app.UpdatedAt
+		a.Title = app.Title
+		a.SupportEmail = app.SupportEmail
+		a.IsDisabled = app.IsDisabled
+	})
+}
+
+func (db *appRepo) CreateApplicationEndpoint(ctx context.Context, groupID string, appID string, endpoint *datastore.Endpoint) error {
+	m := appMatcher(func(a *datastore.Application) bool { return a.UID == appID && isActive(a) })
+
+	return db.table.update(m, false, func(doc interface{}) {
+		a := doc.(*datastore.Application)
+		a.Endpoints = append(a.Endpoints, *endpoint)
+		a.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
+
+func (db *appRepo) DeleteGroupApps(ctx context.Context, groupID string) error {
+	return db.table.update(appMatcher(func(a *datastore.Application) bool { return a.GroupID == groupID }), true, softDelete)
+}
+
+// DeleteApplication soft deletes the app's events and then the app.
+func (db *appRepo) DeleteApplication(ctx context.Context, app *datastore.Application) error {
+	err := db.events.update(func(doc interface{}) bool { return doc.(*datastore.Event).AppID == app.UID }, true, softDelete)
+	if err != nil {
+		log.Errorf("failed to delete messages in %s. Reason: %s", app.UID, err)
+		return err
+	}
+
+	err = db.table.update(appMatcher(func(a *datastore.Application) bool { return a.UID == app.UID }), true, softDelete)
+	if err != nil {
+		log.Errorf("%s an error has occurred while deleting app - %s", app.UID, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UpdateApplication(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := db.GroupRepo()
+	appRepo := db.AppRepo()
+
+	newGroup := &datastore.Group{
+		Name: "Random new group",
+		UID:  uuid.NewString(),
+	}
+
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), newGroup))
+
+	app := &datastore.Application{
+		Title:          "Next application name",
+		GroupID:        newGroup.UID,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	require.NoError(t, appRepo.CreateApplication(context.Background(), app, app.GroupID))
+
+	newTitle := "Newer name"
+
+	app.Title = newTitle
+
+	require.NoError(t, appRepo.UpdateApplication(context.Background(), app, app.GroupID))
+
+	newApp, err := appRepo.FindApplicationByID(context.Background(), app.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, newTitle, newApp.Title)
+
+	app2 := &datastore.Application{
+		Title:          newTitle,
+		GroupID:        newGroup.UID,
+		UID:            uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	err = appRepo.CreateApplication(context.Background(), app2, app2.GroupID)
+	require.Equal(t, datastore.ErrDuplicateAppName, err)
+}
+
+func Test_CreateApplication(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	groupRepo := db.GroupRepo()
+	appRepo := db.AppRepo()
+
+	newOrg := &datastore.Group{
+		Name: "Random new group 2",
+		UID:  uuid.NewString(),
+	}
+
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), newOrg))
+
+	app := &datastore.Application{
+		Title:          "Next application name",
+		GroupID:        newOrg.UID,
+		UID:            uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	require.NoError(t, appRepo.CreateApplication(context.Background(), app, app.GroupID))
+
+	app2 := &datastore.Application{
+		Title:          "Next application name",
+		GroupID:        newOrg.UID,
+		UID:            uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	err := appRepo.CreateApplication(context.Background(), app2, app2.GroupID)
+	require.Equal(t, datastore.ErrDuplicateAppName, err)
+}
+
+func Test_LoadApplicationsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := db.AppRepo()
+
+	apps, _, err := appRepo.LoadApplicationsPaged(context.Background(), "", "", datastore.Pageable{
+		Page:    1,
+		PerPage: 10,
+	})
+	require.NoError(t, err)
+
+	require.True(t, len(apps) == 0)
+}
+
+func Test_FindApplicationByID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	appRepo := db.AppRepo()
+
+	_, err := appRepo.FindApplicationByID(context.Background(), uuid.New().String())
+	require.Error(t, err)
+
+	require.True(t, errors.Is(err, datastore.ErrApplicationNotFound))
+
+	groupRepo := db.GroupRepo()
+
+	newGroup := &datastore.Group{
+		Name: "Yet another Random new group",
+	}
+
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), newGroup))
+
+	app := &datastore.Application{
+		Title:   "Next application name again",
+		GroupID: newGroup.UID,
+		UID:     uuid.NewString(),
+	}
+
+	require.NoError(t, appRepo.CreateApplication(context.Background(), app, app.GroupID))
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type configRepo struct {
+	table *table
+}
+
+func newConfigRepo(c *Client) datastore.ConfigurationRepository {
+	return &configRepo{
+		table: c.tables[ConfigTable],
+	}
+}
+
+func (c *configRepo) CreateConfiguration(ctx context.Context, config *datastore.Configuration) error {
+	config.ID = primitive.NewObjectID()
+
+	return c.table.insert(config)
+}
+
+func (c *configRepo) LoadConfiguration(ctx context.Context) (*datastore.Configuration, error) {
+	config := &datastore.Configuration{}
+
+	err := c.table.findOne(all, config)
+	if errors.Is(err, errNotFound) {
+		return nil, datastore.ErrConfigNotFound
+	}
+
+	return config, err
+}
+
+func (c *configRepo) UpdateConfiguration(ctx context.Context, config *datastore.Configuration) error {
+	m := func(doc interface{}) bool { return doc.(*datastore.Configuration).UID == config.UID }
+
+	return c.table.update(m, false, func(doc interface{}) {
+		cfg := doc.(*datastore.Configuration)
+		cfg.IsAnalyticsEnabled = config.IsAnalyticsEnabled
+		cfg.IsSignupEnabled = config.IsSignupEnabled
+		cfg.StoragePolicy = config.StoragePolicy
+		cfg.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type deliveryAttemptRepo struct {
+	table *table
+}
+
+func newDeliveryAttemptRepo(c *Client) datastore.DeliveryAttemptRepository {
+	return &deliveryAttemptRepo{
+		table: c.tables[DeliveryAttemptTable],
+	}
+}
+
+func deliveryAttemptMatcher(fn func(*datastore.DeliveryAttempt) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.DeliveryAttempt)) }
+}
+
+func (db *deliveryAttemptRepo) CreateDeliveryAttempt(ctx context.Context, attempt *datastore.DeliveryAttempt) error {
+	if attempt.ID.IsZero() {
+		attempt.ID = primitive.NewObjectID()
+	}
+
+	return db.table.insert(attempt)
+}
+
+func (db *deliveryAttemptRepo) FindDeliveryAttemptByID(ctx context.Context, eventDeliveryID string, id string) (*datastore.DeliveryAttempt, error) {
+	attempt := &datastore.DeliveryAttempt{}
+
+	err := db.table.findOne(deliveryAttemptMatcher(func(a *datastore.DeliveryAttempt) bool {
+		return a.MsgID == eventDeliveryID && a.UID == id
+	}), attempt)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrEventDeliveryAttemptNotFound
+	}
+
+	return attempt, err
+}
+
+func (db *deliveryAttemptRepo) LoadDeliveryAttemptsPaged(ctx context.Context, filter *datastore.DeliveryAttemptFilter, pageable datastore.Pageable) ([]datastore.DeliveryAttempt, datastore.PaginationData, error) {
+	m := deliveryAttemptMatcher(func(a *datastore.DeliveryAttempt) bool {
+		return (filter.GroupID == "" || a.GroupID == filter.GroupID) &&
+			(filter.EventDeliveryID == "" || a.MsgID == filter.EventDeliveryID) &&
+			(filter.AppID == "" || a.AppID == filter.AppID) &&
+			(filter.EndpointID == "" || a.EndpointID == filter.EndpointID) &&
+			(filter.CreatedAtEnd == 0 || between(a.CreatedAt, filter.CreatedAtStart, filter.CreatedAtEnd))
+	})
+
+	var attempts []datastore.DeliveryAttempt
+	paginationData, err := db.table.cursorPaged(m, pageable, &attempts)
+	if err != nil {
+		return attempts, datastore.PaginationData{}, err
+	}
+
+	return attempts, paginationData, nil
+}
+
+func (db *deliveryAttemptRepo) DeleteGroupDeliveryAttempts(ctx context.Context, filter *datastore.DeliveryAttemptFilter, hardDelete bool) error {
+	m := deliveryAttemptMatcher(func(a *datastore.DeliveryAttempt) bool {
+		return a.GroupID == filter.GroupID && isActive(a) &&
+			between(a.CreatedAt, filter.CreatedAtStart, filter.CreatedAtEnd)
+	})
+
+	if hardDelete {
+		db.table.delete(m)
+		return nil
+	}
+
+	return db.table.update(m, true, softDelete)
+}
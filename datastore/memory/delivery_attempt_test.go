@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_LoadDeliveryAttemptsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	attemptRepo := db.DeliveryAttemptRepo()
+	groupID, deliveryID, endpointID := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	created := make([]string, 0)
+	for i := 0; i < 3; i++ {
+		attempt := &datastore.DeliveryAttempt{
+			UID:            uuid.NewString(),
+			GroupID:        groupID,
+			MsgID:          deliveryID,
+			EndpointID:     endpointID,
+			CreatedAt:      primitive.DateTime(1656000000000 + int64(i)),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, attemptRepo.CreateDeliveryAttempt(context.Background(), attempt))
+		created = append(created, attempt.UID)
+	}
+
+	// an attempt of another event delivery to the same endpoint.
+	other := &datastore.DeliveryAttempt{
+		UID:            uuid.NewString(),
+		GroupID:        groupID,
+		MsgID:          uuid.NewString(),
+		EndpointID:     endpointID,
+		CreatedAt:      primitive.DateTime(1656000000010),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, attemptRepo.CreateDeliveryAttempt(context.Background(), other))
+
+	attempts, data, err := attemptRepo.LoadDeliveryAttemptsPaged(context.Background(), &datastore.DeliveryAttemptFilter{GroupID: groupID, EventDeliveryID: deliveryID}, datastore.Pageable{Page: 1, PerPage: 2, Sort: 1})
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	require.Equal(t, created[0], attempts[0].UID)
+	require.Equal(t, int64(3), data.Total)
+
+	attempts, _, err = attemptRepo.LoadDeliveryAttemptsPaged(context.Background(), &datastore.DeliveryAttemptFilter{GroupID: groupID, EventDeliveryID: deliveryID}, datastore.Pageable{PerPage: 2, Sort: 1, NextCursor: data.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	require.Equal(t, created[2], attempts[0].UID)
+
+	_, data, err = attemptRepo.LoadDeliveryAttemptsPaged(context.Background(), &datastore.DeliveryAttemptFilter{GroupID: groupID, EndpointID: endpointID}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), data.Total)
+
+	attempt, err := attemptRepo.FindDeliveryAttemptByID(context.Background(), deliveryID, created[1])
+	require.NoError(t, err)
+	require.Equal(t, created[1], attempt.UID)
+
+	_, err = attemptRepo.FindDeliveryAttemptByID(context.Background(), deliveryID, other.UID)
+	require.ErrorIs(t, err, datastore.ErrEventDeliveryAttemptNotFound)
+}
+
+func Test_SnapshotMigratesDeliveryAttempts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "convoy.db")
+
+	delivery := bson.M{
+		"_id":             primitive.NewObjectID(),
+		"uid":             uuid.NewString(),
+		"group_id":        uuid.NewString(),
+		"app_id":          uuid.NewString(),
+		"document_status": datastore.ActiveDocumentStatus,
+		"attempts": bson.A{
+			bson.M{"_id": primitive.NewObjectID(), "uid": uuid.NewString(), "status": true},
+		},
+	}
+
+	raw, err := bson.Marshal(delivery)
+	require.NoError(t, err)
+
+	data, err := bson.Marshal(map[string][]bson.Raw{EventDeliveryTable: {raw}})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0o600))
+
+	db, err := New(getConfig(path))
+	require.NoError(t, err)
+	defer db.Disconnect(context.Background())
+
+	attempts, _, err := db.DeliveryAttemptRepo().LoadDeliveryAttemptsPaged(context.Background(), &datastore.DeliveryAttemptFilter{EventDeliveryID: delivery["uid"].(string)}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	require.Equal(t, delivery["group_id"], attempts[0].GroupID)
+	require.Equal(t, delivery["app_id"], attempts[0].AppID)
+
+	for _, doc := range db.tables[EventDeliveryTable].snapshot() {
+		_, err = doc.LookupErr("attempts")
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,251 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventRepo struct {
+	table *table
+}
+
+func newEventRepo(c *Client) datastore.EventRepository {
+	return &eventRepo{
+		table: c.tables[EventTable],
+	}
+}
+
+func eventMatcher(fn func(*datastore.Event) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.Event)) }
+}
+
+// the formats of the interval dates and the date parts the intervals are
+// numbered by, they mirror the $dateToString formats and date operators
+// of the mongo datastore.
+var (
+	dailyIntervalFormat   = "2006-01-02"
+	weeklyIntervalFormat  = "2006-01"
+	monthlyIntervalFormat = "2006-01"
+	yearlyIntervalFormat  = "2006"
+
+	dayOfYear = func(t time.Time) int { return t.YearDay() }
+	// weeks start on sundays, the days before the first sunday of the
+	// year are in week 0.
+	week  = func(t time.Time) int { return (t.YearDay() + 6 - int(t.Weekday())) / 7 }
+	month = func(t time.Time) int { return int(t.Month()) }
+	year  = func(t time.Time) int { return t.Year() }
+)
+
+func (db *eventRepo) CreateEvent(ctx context.Context, message *datastore.Event) error {
+	message.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(message.ProviderID) {
+		message.ProviderID = message.AppID
+	}
+	if util.IsStringEmpty(message.UID) {
+		message.UID = uuid.New().String()
+	}
+
+	return db.table.insert(message)
+}
+
+func (db *eventRepo) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
+	return db.table.count(eventMatcher(func(e *datastore.Event) bool { return e.GroupID == groupID })), nil
+}
+
+func (db *eventRepo) DeleteGroupEvents(ctx context.Context, filter *datastore.EventFilter, hardDelete bool) error {
+	m := eventMatcher(func(e *datastore.Event) bool {
+		return e.GroupID == filter.GroupID && isActive(e) &&
+			between(e.CreatedAt, filter.CreatedAtStart, filter.CreatedAtEnd)
+	})
+
+	if hardDelete {
+		db.table.delete(m)
+		return nil
+	}
+
+	return db.table.update(m, true, softDelete)
+}
+
+func (db *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, searchParams datastore.SearchParams, period datastore.Period, interval int) ([]datastore.EventInterval, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = start
+	}
+
+	var timeComponent func(time.Time) int
+	var format string
+	switch period {
+	case datastore.Daily:
+		timeComponent = dayOfYear
+		format = dailyIntervalFormat
+	case datastore.Weekly:
+		timeComponent = week
+		format = weeklyIntervalFormat
+	case datastore.Monthly:
+		timeComponent = month
+		format = monthlyIntervalFormat
+	case datastore.Yearly:
+		timeComponent = year
+		format = yearlyIntervalFormat
+	default:
+		return nil, errors.New("specified data cannot be generated for period")
+	}
+
+	m := eventMatcher(func(e *datastore.Event) bool {
+		return e.GroupID == groupID && between(e.CreatedAt, start, end)
+	})
+
+	events := make([]datastore.Event, 0)
+	if err := db.table.find(m, 1, 0, 0, &events); err != nil {
+		return nil, err
+	}
+
+	counts := map[datastore.EventIntervalData]uint64{}
+	for _, e := range events {
+		createdAt := e.CreatedAt.Time().UTC()
+
+		data := datastore.EventIntervalData{
+			Time:     createdAt.Format(format),
+			Interval: int64(timeComponent(createdAt) / interval),
+		}
+		counts[data]++
+	}
+
+	eventsIntervals := make([]datastore.EventInterval, 0, len(counts))
+	for data, count := range counts {
+		eventsIntervals = append(eventsIntervals, datastore.EventInterval{Data: data, Count: count})
+	}
+
+	sort.Slice(eventsIntervals, func(i, j int) bool {
+		a, b := eventsIntervals[i].Data, eventsIntervals[j].Data
+		if a.Time != b.Time {
+			return a.Time < b.Time
+		}
+		return a.Interval < b.Interval
+	})
+
+	return eventsIntervals, nil
+}
+
+func (db *eventRepo) FindEventByID(ctx context.Context, id string) (*datastore.Event, error) {
+	return db.findEvent(eventMatcher(func(e *datastore.Event) bool { return e.UID == id }))
+}
+
+// FindEventByIdempotencyKey returns an event of the source created since
+// the given time with the idempotency key.
+func (db *eventRepo) FindEventByIdempotencyKey(ctx context.Context, sourceID string, key string, since time.Time) (*datastore.Event, error) {
+	return db.findEvent(eventMatcher(func(e *datastore.Event) bool {
+		return e.SourceID == sourceID && e.IdempotencyKey == key && !e.CreatedAt.Time().Before(since)
+	}))
+}
+
+func (db *eventRepo) findEvent(m matcher) (*datastore.Event, error) {
+	e := new(datastore.Event)
+
+	err := db.table.findOne(m, e)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrEventNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventRepo) IncrementDuplicateCount(ctx context.Context, id string) error {
+	return db.table.update(eventMatcher(func(e *datastore.Event) bool { return e.UID == id }), false, func(doc interface{}) {
+		doc.(*datastore.Event).DuplicateCount++
+	})
+}
+
+// UpdateEventPayload stores the data, the raw body and their references
+// of event.
+func (db *eventRepo) UpdateEventPayload(ctx context.Context, event *datastore.Event) error {
+	return db.table.update(eventMatcher(func(e *datastore.Event) bool { return e.UID == event.UID }), false, func(doc interface{}) {
+		e := doc.(*datastore.Event)
+		e.Data = event.Data
+		e.Raw = event.Raw
+		e.DataRef = event.DataRef
+		e.RawRef = event.RawRef
+	})
+}
+
+func (db *eventRepo) FindEventsByIDs(ctx context.Context, ids []string) ([]datastore.Event, error) {
+	m := make([]datastore.Event, 0)
+
+	err := db.table.find(eventMatcher(func(e *datastore.Event) bool { return in(e.UID, ids) }), 1, 0, 0, &m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
+
+func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	m := eventMatcher(func(e *datastore.Event) bool {
+		if !util.IsStringEmpty(groupID) && e.GroupID != groupID {
+			return false
+		}
+
+		if !util.IsStringEmpty(appId) && e.AppID != appId {
+			return false
+		}
+
+		return createdAtFilter(e.CreatedAt, searchParams)
+	})
+
+	var messages []datastore.Event
+	paginationData, err := db.table.cursorPaged(m, pageable, &messages)
+	if err != nil {
+		return messages, datastore.PaginationData{}, err
+	}
+
+	return messages, paginationData, nil
+}
+
+func (db *eventRepo) LoadEventsByFilter(ctx context.Context, filter *datastore.EventFilter, pageable datastore.Pageable) ([]datastore.Event, error) {
+	var events []datastore.Event
+	_, err := db.table.paged(getEventFilter(filter), pageable.Sort, pageable, &events)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (db *eventRepo) CountEvents(ctx context.Context, filter *datastore.EventFilter) (int64, error) {
+	return db.table.count(getEventFilter(filter)), nil
+}
+
+func getEventFilter(filter *datastore.EventFilter) matcher {
+	searchParams := datastore.SearchParams{
+		CreatedAtStart: filter.CreatedAtStart,
+		CreatedAtEnd:   filter.CreatedAtEnd,
+	}
+
+	return eventMatcher(func(e *datastore.Event) bool {
+		fields := []struct{ value, filter string }{
+			{e.GroupID, filter.GroupID},
+			{e.AppID, filter.AppID},
+			{e.SourceID, filter.SourceID},
+		}
+
+		for _, f := range fields {
+			if !util.IsStringEmpty(f.filter) && f.value != f.filter {
+				return false
+			}
+		}
+
+		if len(filter.EventTypes) > 0 && !in(string(e.EventType), filter.EventTypes) {
+			return false
+		}
+
+		return createdAtFilter(e.CreatedAt, searchParams)
+	})
+}
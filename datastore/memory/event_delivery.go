@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventDeliveryRepo struct {
+	table *table
+}
+
+func newEventDeliveryRepo(c *Client) datastore.EventDeliveryRepository {
+	return &eventDeliveryRepo{
+		table: c.tables[EventDeliveryTable],
+	}
+}
+
+func eventDeliveryMatcher(fn func(*datastore.EventDelivery) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.EventDelivery)) }
+}
+
+func (db *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery) error {
+	eventDelivery.ID = primitive.NewObjectID()
+	if util.IsStringEmpty(eventDelivery.UID) {
+		eventDelivery.UID = uuid.New().String()
+	}
+
+	return db.table.insert(eventDelivery)
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context, id string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	err := db.table.findOne(eventDeliveryMatcher(func(d *datastore.EventDelivery) bool { return d.UID == id }), e)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, ids []string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := db.table.find(eventDeliveryMatcher(func(d *datastore.EventDelivery) bool { return in(d.UID, ids) }), 1, 0, 0, &deliveries)
+	return deliveries, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context, eventID string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := db.table.find(eventDeliveryMatcher(func(d *datastore.EventDelivery) bool { return d.EventID == eventID }), 1, 0, 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// FindEventDeliveriesBySubscriptionID returns up to limit deliveries of a
+// subscription with the given status, oldest first.
+func (db *eventDeliveryRepo) FindEventDeliveriesBySubscriptionID(ctx context.Context, groupID, subscriptionID string, status datastore.EventDeliveryStatus, limit int) ([]datastore.EventDelivery, error) {
+	m := eventDeliveryMatcher(func(d *datastore.EventDelivery) bool {
+		return d.GroupID == groupID && d.SubscriptionID == subscriptionID && d.Status == status
+	})
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.table.find(m, 1, int64(limit), 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (db *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context, status datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	m := eventDeliveryMatcher(func(d *datastore.EventDelivery) bool {
+		return d.Status == status && createdAtFilter(d.CreatedAt, searchParams)
+	})
+
+	return db.table.count(m), nil
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context, e datastore.EventDelivery, status datastore.EventDeliveryStatus) error {
+	m := eventDeliveryMatcher(func(d *datastore.EventDelivery) bool { return d.UID == e.UID })
+
+	return db.table.update(m, false, func(doc interface{}) {
+		d := doc.(*datastore.EventDelivery)
+		d.Status = status
+		d.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, ids []string, status datastore.EventDeliveryStatus) error {
+	m := eventDeliveryMatcher(func(d *datastore.EventDelivery) bool { return in(d.UID, ids) && isActive(d) })
+
+	return db.table.update(m, true, func(doc interface{}) {
+		d := doc.(*datastore.EventDelivery)
+		d.Status = status
+		d.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
+
+func (db *eventDeliveryRepo) UpdateEventDelivery(ctx context.Context, e datastore.EventDelivery) error {
+	m := eventDeliveryMatcher(func(d *datastore.EventDelivery) bool { return d.UID == e.UID })
+
+	return db.table.update(m, false, func(doc interface{}) {
+		d := doc.(*datastore.EventDelivery)
+		d.Status = e.Status
+		d.Description = e.Description
+		d.Metadata = e.Metadata
+		d.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
+
+func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	var eventDeliveries []datastore.EventDelivery
+	paginationData, err := db.table.cursorPaged(getFilter(groupID, appID, eventID, status, searchParams), pageable, &eventDeliveries)
+	if err != nil {
+		return eventDeliveries, datastore.PaginationData{}, err
+	}
+
+	return eventDeliveries, paginationData, nil
+}
+
+func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	return db.table.count(getFilter(groupID, appID, eventID, status, searchParams)), nil
+}
+
+func getFilter(groupID string, appID string, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) matcher {
+	return eventDeliveryMatcher(func(d *datastore.EventDelivery) bool {
+		fields := []struct{ value, filter string }{
+			{d.AppID, appID},
+			{d.GroupID, groupID},
+			{d.EventID, eventID},
+		}
+
+		for _, f := range fields {
+			if !util.IsStringEmpty(f.filter) && f.value != f.filter {
+				return false
+			}
+		}
+
+		if len(status) > 0 {
+			found := false
+			for _, s := range status {
+				if d.Status == s {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return false
+			}
+		}
+
+		return createdAtFilter(d.CreatedAt, searchParams)
+	})
+}
+
+func (db *eventDeliveryRepo) DeleteGroupEventDeliveries(ctx context.Context, filter *datastore.EventDeliveryFilter, hardDelete bool) error {
+	m := eventDeliveryMatcher(func(d *datastore.EventDelivery) bool {
+		return d.GroupID == filter.GroupID && isActive(d) &&
+			between(d.CreatedAt, filter.CreatedAtStart, filter.CreatedAtEnd)
+	})
+
+	if hardDelete {
+		db.table.delete(m)
+		return nil
+	}
+
+	return db.table.update(m, true, softDelete)
+}
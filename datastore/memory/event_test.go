@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_LoadEventsPagedWithCursors(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := db.EventRepo()
+	groupID := uuid.NewString()
+
+	// the events share creation times so that they are ordered by id.
+	created := make([]string, 0)
+	for i := 0; i < 5; i++ {
+		event := &datastore.Event{
+			UID:            uuid.NewString(),
+			GroupID:        groupID,
+			CreatedAt:      primitive.DateTime(1656000000000 + int64(i/2)),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), event))
+		created = append(created, event.UID)
+	}
+
+	searchParams := datastore.SearchParams{CreatedAtStart: 1656000000, CreatedAtEnd: 1656000001}
+	uids := func(events []datastore.Event) []string {
+		ids := make([]string, 0, len(events))
+		for _, e := range events {
+			ids = append(ids, e.UID)
+		}
+		return ids
+	}
+
+	events, data, err := eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{Page: 1, PerPage: 2, Sort: 1})
+	require.NoError(t, err)
+	require.Equal(t, created[:2], uids(events))
+	require.Equal(t, int64(5), data.Total)
+	require.NotEmpty(t, data.NextCursor)
+
+	events, data, err = eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{PerPage: 2, Sort: 1, NextCursor: data.NextCursor, SkipTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, created[2:4], uids(events))
+	require.Equal(t, int64(0), data.Total)
+
+	last, lastData, err := eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{PerPage: 2, Sort: 1, NextCursor: data.NextCursor, SkipTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, created[4:], uids(last))
+	require.Empty(t, lastData.NextCursor)
+
+	events, data, err = eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{PerPage: 2, Sort: 1, PrevCursor: lastData.PrevCursor})
+	require.NoError(t, err)
+	require.Equal(t, created[2:4], uids(events))
+	require.Equal(t, int64(5), data.Total)
+
+	events, data, err = eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{PerPage: 2, Sort: 1, PrevCursor: data.PrevCursor})
+	require.NoError(t, err)
+	require.Equal(t, created[:2], uids(events))
+	require.Empty(t, data.PrevCursor)
+
+	// descending order starts with the latest events.
+	events, _, err = eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{PerPage: 2, Sort: -1, SkipTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{created[4], created[3]}, uids(events))
+
+	_, _, err = eventRepo.LoadEventsPaged(context.Background(), groupID, "", searchParams, datastore.Pageable{PerPage: 2, NextCursor: "invalid"})
+	require.ErrorIs(t, err, datastore.ErrInvalidCursor)
+}
+
+func Test_UpdateEventPayload(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := db.EventRepo()
+	event := &datastore.Event{
+		UID:            uuid.NewString(),
+		GroupID:        uuid.NewString(),
+		DataRef:        "file:///tmp/payloads/event.json",
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, eventRepo.CreateEvent(context.Background(), event))
+
+	event.Data, event.DataRef = []byte(`{"name":"convoy"}`), ""
+	require.NoError(t, eventRepo.UpdateEventPayload(context.Background(), event))
+
+	e, err := eventRepo.FindEventByID(context.Background(), event.UID)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"name":"convoy"}`, string(e.Data))
+	require.Empty(t, e.DataRef)
+}
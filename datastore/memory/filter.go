@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// isActive reports whether a document's status is active, every model
+// has a DocumentStatus field.
+func isActive(doc interface{}) bool {
+	status := reflect.ValueOf(doc).Elem().FieldByName("DocumentStatus")
+	return status.IsValid() && status.String() == string(datastore.ActiveDocumentStatus)
+}
+
+// softDelete marks a document as deleted like the mongo datastore does.
+func softDelete(doc interface{}) {
+	v := reflect.ValueOf(doc).Elem()
+	v.FieldByName("DeletedAt").Set(reflect.ValueOf(primitive.NewDateTimeFromTime(time.Now())))
+	v.FieldByName("DocumentStatus").SetString(string(datastore.DeletedDocumentStatus))
+}
+
+// between reports whether t is within the start and end unix times.
+func between(t primitive.DateTime, start, end int64) bool {
+	return int64(t) >= start*1000 && int64(t) <= end*1000
+}
+
+// createdAtFilter reports whether t is within the created at range of
+// searchParams.
+func createdAtFilter(t primitive.DateTime, searchParams datastore.SearchParams) bool {
+	return between(t, searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+}
+
+// in reports whether v is one of values.
+func in(v string, values []string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// key joins the fields of a unique index, documents whose fields are all
+// empty aren't indexed.
+func key(fields ...string) string {
+	for _, f := range fields {
+		if f != "" {
+			return strings.Join(fields, "\x00")
+		}
+	}
+
+	return ""
+}
+
+// activeKey is the key of a unique index on active documents.
+func activeKey(doc interface{}, fields ...string) string {
+	if !isActive(doc) {
+		return ""
+	}
+
+	return key(fields...)
+}
+
+// all matches every document.
+func all(doc interface{}) bool {
+	return true
+}
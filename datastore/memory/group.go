@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type groupRepo struct {
+	table  *table
+	apps   *table
+	events *table
+}
+
+func newGroupRepo(c *Client) datastore.GroupRepository {
+	return &groupRepo{
+		table:  c.tables[GroupTable],
+		apps:   c.tables[AppTable],
+		events: c.tables[EventTable],
+	}
+}
+
+func groupMatcher(fn func(*datastore.Group) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.Group)) }
+}
+
+func (db *groupRepo) LoadGroups(ctx context.Context, f *datastore.GroupFilter) ([]*datastore.Group, error) {
+	groups := make([]*datastore.Group, 0)
+
+	orgID := f.OrgID
+	f = f.WithNamesTrimmed()
+
+	m := groupMatcher(func(g *datastore.Group) bool {
+		if orgID != "" && g.OrganisationID != orgID {
+			return false
+		}
+
+		return len(f.Names) == 0 || in(g.Name, f.Names)
+	})
+
+	err := db.table.find(m, 1, 0, 0, &groups)
+	return groups, err
+}
+
+func (db *groupRepo) CreateGroup(ctx context.Context, o *datastore.Group) error {
+	o.ID = primitive.NewObjectID()
+
+	err := db.table.insert(o)
+	if isDuplicateKeyError(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) UpdateGroup(ctx context.Context, o *datastore.Group) error {
+	o.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	m := groupMatcher(func(g *datastore.Group) bool { return g.UID == o.UID })
+
+	err := db.table.update(m, false, func(doc interface{}) {
+		g := doc.(*datastore.Group)
+		g.Name = o.Name
+		g.LogoURL = o.LogoURL
+		g.UpdatedAt = o.UpdatedAt
+		g.Config = o.Config
+		g.RateLimit = o.RateLimit
+		g.Metadata = o.Metadata
+		g.RateLimitDuration = o.RateLimitDuration
+	})
+	if isDuplicateKeyError(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) FetchGroupByID(ctx context.Context, id string) (*datastore.Group, error) {
+	group := new(datastore.Group)
+
+	err := db.table.findOne(groupMatcher(func(g *datastore.Group) bool { return g.UID == id }), group)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrGroupNotFound
+	}
+
+	return group, err
+}
+
+// FillGroupsStatistics sets the number of apps and events of each group,
+// deleted apps and events are counted like in the mongo datastore.
+func (db *groupRepo) FillGroupsStatistics(ctx context.Context, groups []*datastore.Group) error {
+	for i := range groups {
+		groupID := groups[i].UID
+
+		groups[i].Statistics = &datastore.GroupStatistics{
+			GroupID: groupID,
+			TotalApps: db.apps.countAll(func(doc interface{}) bool {
+				return doc.(*datastore.Application).GroupID == groupID
+			}),
+			MessagesSent: db.events.countAll(func(doc interface{}) bool {
+				return doc.(*datastore.Event).GroupID == groupID
+			}),
+		}
+	}
+
+	return nil
+}
+
+func (db *groupRepo) DeleteGroup(ctx context.Context, uid string) error {
+	return db.table.update(groupMatcher(func(g *datastore.Group) bool { return g.UID == uid }), true, softDelete)
+}
+
+func (db *groupRepo) FetchGroupsByIDs(ctx context.Context, ids []string) ([]datastore.Group, error) {
+	groups := make([]datastore.Group, 0)
+
+	err := db.table.find(groupMatcher(func(g *datastore.Group) bool { return in(g.UID, ids) }), 1, 0, 0, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,441 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	ConfigTable              = "configurations"
+	GroupTable               = "groups"
+	OrganisationTable        = "organisations"
+	OrganisationInvitesTable = "organisation_invites"
+	OrganisationMembersTable = "organisation_members"
+	AppTable                 = "applications"
+	EventTable               = "events"
+	EventDeliveryTable       = "eventdeliveries"
+	SourceTable              = "sources"
+	UserTable                = "users"
+	SubscriptionTable        = "subscriptions"
+	ReplayJobTable           = "replay_jobs"
+	TestAttemptTable         = "test_attempts"
+	DeliveryAttemptTable     = "deliveryattempts"
+	APIKeyTable              = "apiKeys"
+)
+
+// the names of the unique indexes whose violations are returned as
+// datastore errors.
+const (
+	groupNameIndex = "groups_name_key"
+	userEmailIndex = "users_email_key"
+)
+
+// snapshotInterval is how often changes are written to the snapshot file.
+var snapshotInterval = 5 * time.Second
+
+// Client keeps every document in memory, when the database dsn is set it
+// is the path of a file the documents are periodically written to and
+// loaded from on start.
+type Client struct {
+	path   string
+	tables map[string]*table
+
+	dirty int32
+	done  chan struct{}
+	wg    sync.WaitGroup
+	// snapshotMu serializes writes of the snapshot file.
+	snapshotMu sync.Mutex
+
+	apiKeyRepo        datastore.APIKeyRepository
+	groupRepo         datastore.GroupRepository
+	eventRepo         datastore.EventRepository
+	applicationRepo   datastore.ApplicationRepository
+	subscriptionRepo  datastore.SubscriptionRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	sourceRepo        datastore.SourceRepository
+	orgRepo           datastore.OrganisationRepository
+	orgMemberRepo     datastore.OrganisationMemberRepository
+	orgInviteRepo     datastore.OrganisationInviteRepository
+	userRepo          datastore.UserRepository
+	configRepo        datastore.ConfigurationRepository
+	replayJobRepo     datastore.ReplayJobRepository
+	testAttemptRepo   datastore.TestAttemptRepository
+	attemptRepo       datastore.DeliveryAttemptRepository
+}
+
+func New(cfg config.Configuration) (*Client, error) {
+	c := &Client{
+		path:   cfg.Database.Dsn,
+		tables: map[string]*table{},
+		done:   make(chan struct{}),
+	}
+
+	c.addTable(ConfigTable, &datastore.Configuration{}).
+		unique("configurations_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Configuration).UID)
+		})
+
+	c.addTable(OrganisationTable, &datastore.Organisation{}).
+		unique("organisations_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Organisation).UID)
+		})
+
+	c.addTable(OrganisationMembersTable, &datastore.OrganisationMember{}).
+		unique("organisation_members_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.OrganisationMember).UID)
+		}).
+		unique("organisation_members_user_key", func(doc interface{}) string {
+			m := doc.(*datastore.OrganisationMember)
+			return activeKey(m, m.OrganisationID, m.UserID)
+		})
+
+	c.addTable(OrganisationInvitesTable, &datastore.OrganisationInvite{}).
+		unique("organisation_invites_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.OrganisationInvite).UID)
+		}).
+		unique("organisation_invites_token_key", func(doc interface{}) string {
+			return key(doc.(*datastore.OrganisationInvite).Token)
+		}).
+		unique("organisation_invites_invitee_email_key", func(doc interface{}) string {
+			iv := doc.(*datastore.OrganisationInvite)
+			return activeKey(iv, iv.OrganisationID, iv.InviteeEmail)
+		})
+
+	c.addTable(UserTable, &datastore.User{}).
+		unique("users_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.User).UID)
+		}).
+		unique(userEmailIndex, func(doc interface{}) string {
+			u := doc.(*datastore.User)
+			return activeKey(u, u.Email)
+		})
+
+	c.addTable(APIKeyTable, &datastore.APIKey{}).
+		unique("api_keys_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.APIKey).UID)
+		})
+
+	c.addTable(GroupTable, &datastore.Group{}).
+		unique("groups_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Group).UID)
+		}).
+		unique(groupNameIndex, func(doc interface{}) string {
+			g := doc.(*datastore.Group)
+			return activeKey(g, g.OrganisationID, g.Name)
+		})
+
+	c.addTable(AppTable, &datastore.Application{}).
+		unique("applications_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Application).UID)
+		})
+
+	c.addTable(EventTable, &datastore.Event{}).
+		unique("events_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Event).UID)
+		})
+
+	c.addTable(EventDeliveryTable, &datastore.EventDelivery{}).
+		unique("event_deliveries_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.EventDelivery).UID)
+		})
+
+	c.addTable(SourceTable, &datastore.Source{}).
+		unique("sources_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Source).UID)
+		}).
+		unique("sources_mask_id_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Source).MaskID)
+		})
+
+	c.addTable(SubscriptionTable, &datastore.Subscription{}).
+		unique("subscriptions_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.Subscription).UID)
+		})
+
+	c.addTable(ReplayJobTable, &datastore.ReplayJob{}).
+		unique("replay_jobs_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.ReplayJob).UID)
+		})
+
+	c.addTable(TestAttemptTable, &datastore.TestAttempt{}).
+		unique("test_attempts_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.TestAttempt).UID)
+		})
+
+	c.addTable(DeliveryAttemptTable, &datastore.DeliveryAttempt{}).
+		unique("delivery_attempts_uid_key", func(doc interface{}) string {
+			return key(doc.(*datastore.DeliveryAttempt).UID)
+		})
+
+	if c.path != "" {
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+
+		if err := c.migrateDeliveryAttempts(); err != nil {
+			return nil, err
+		}
+
+		c.wg.Add(1)
+		go c.snapshotPeriodically()
+	}
+
+	c.apiKeyRepo = newApiKeyRepo(c)
+	c.groupRepo = newGroupRepo(c)
+	c.applicationRepo = newApplicationRepo(c)
+	c.subscriptionRepo = newSubscriptionRepo(c)
+	c.eventRepo = newEventRepo(c)
+	c.eventDeliveryRepo = newEventDeliveryRepo(c)
+	c.sourceRepo = newSourceRepo(c)
+	c.orgRepo = newOrgRepo(c)
+	c.orgMemberRepo = newOrgMemberRepo(c)
+	c.orgInviteRepo = newOrgInviteRepo(c)
+	c.userRepo = newUserRepo(c)
+	c.configRepo = newConfigRepo(c)
+	c.replayJobRepo = newReplayJobRepo(c)
+	c.testAttemptRepo = newTestAttemptRepo(c)
+	c.attemptRepo = newDeliveryAttemptRepo(c)
+
+	return c, nil
+}
+
+func (c *Client) addTable(name string, model interface{}) *table {
+	t := newTable(model, func() { atomic.StoreInt32(&c.dirty, 1) })
+	c.tables[name] = t
+	return t
+}
+
+// load restores the documents of the snapshot file if it exists.
+func (c *Client) load() error {
+	data, err := ioutil.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	snapshot := map[string][]bson.Raw{}
+	if err = bson.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	for name, docs := range snapshot {
+		t, ok := c.tables[name]
+		if !ok {
+			log.Warnf("skipping unknown table %s in snapshot", name)
+			continue
+		}
+
+		if err = t.restore(docs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateDeliveryAttempts moves the attempts embedded in the event
+// deliveries of older snapshots to the delivery attempts table.
+func (c *Client) migrateDeliveryAttempts() error {
+	deliveries, attempts := c.tables[EventDeliveryTable], c.tables[DeliveryAttemptTable]
+
+	moved := false
+	for _, raw := range deliveries.snapshot() {
+		if _, ok := raw.Lookup("attempts").ArrayOK(); !ok {
+			continue
+		}
+
+		var delivery struct {
+			UID      string                      `bson:"uid"`
+			GroupID  string                      `bson:"group_id"`
+			AppID    string                      `bson:"app_id"`
+			Attempts []datastore.DeliveryAttempt `bson:"attempts"`
+		}
+		if err := bson.Unmarshal(raw, &delivery); err != nil {
+			return err
+		}
+
+		for i := range delivery.Attempts {
+			attempt := &delivery.Attempts[i]
+			attempt.GroupID = delivery.GroupID
+			attempt.AppID = delivery.AppID
+			attempt.MsgID = delivery.UID
+			attempt.DocumentStatus = datastore.ActiveDocumentStatus
+
+			if err := attempts.insert(attempt); err != nil {
+				return err
+			}
+		}
+
+		moved = true
+	}
+
+	if !moved {
+		return nil
+	}
+
+	// the event deliveries are encoded again without their attempts.
+	log.Info("moved the delivery attempts of the snapshot to their own table")
+	return deliveries.update(all, true, func(doc interface{}) {})
+}
+
+func (c *Client) snapshotPeriodically() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.snapshot(); err != nil {
+				log.WithError(err).Error("failed to write in-memory datastore snapshot")
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// snapshot writes the documents to the snapshot file if they changed since
+// the last snapshot, the file is replaced atomically.
+func (c *Client) snapshot() error {
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+
+	if !atomic.CompareAndSwapInt32(&c.dirty, 1, 0) {
+		return nil
+	}
+
+	snapshot := make(map[string][]bson.Raw, len(c.tables))
+	for name, t := range c.tables {
+		snapshot[name] = t.snapshot()
+	}
+
+	data, err := bson.Marshal(snapshot)
+	if err != nil {
+		atomic.StoreInt32(&c.dirty, 1)
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		atomic.StoreInt32(&c.dirty, 1)
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		atomic.StoreInt32(&c.dirty, 1)
+		return err
+	}
+
+	return nil
+}
+
+// Disconnect stops the periodic snapshots and writes a last one.
+func (c *Client) Disconnect(ctx context.Context) error {
+	if c.path == "" {
+		return nil
+	}
+
+	select {
+	case <-c.done:
+		return nil
+	default:
+		close(c.done)
+	}
+
+	c.wg.Wait()
+	return c.snapshot()
+}
+
+func (c *Client) GetName() string {
+	return "in-memory"
+}
+
+func (c *Client) Client() interface{} {
+	return c
+}
+
+func (c *Client) APIRepo() datastore.APIKeyRepository {
+	return c.apiKeyRepo
+}
+
+func (c *Client) GroupRepo() datastore.GroupRepository {
+	return c.groupRepo
+}
+
+func (c *Client) AppRepo() datastore.ApplicationRepository {
+	return c.applicationRepo
+}
+
+func (c *Client) EventRepo() datastore.EventRepository {
+	return c.eventRepo
+}
+
+func (c *Client) EventDeliveryRepo() datastore.EventDeliveryRepository {
+	return c.eventDeliveryRepo
+}
+
+func (c *Client) SubRepo() datastore.SubscriptionRepository {
+	return c.subscriptionRepo
+}
+
+func (c *Client) SourceRepo() datastore.SourceRepository {
+	return c.sourceRepo
+}
+
+func (c *Client) OrganisationRepo() datastore.OrganisationRepository {
+	return c.orgRepo
+}
+
+func (c *Client) OrganisationMemberRepo() datastore.OrganisationMemberRepository {
+	return c.orgMemberRepo
+}
+
+func (c *Client) OrganisationInviteRepo() datastore.OrganisationInviteRepository {
+	return c.orgInviteRepo
+}
+
+func (c *Client) UserRepo() datastore.UserRepository {
+	return c.userRepo
+}
+
+func (c *Client) ConfigurationRepo() datastore.ConfigurationRepository {
+	return c.configRepo
+}
+
+func (c *Client) ReplayJobRepo() datastore.ReplayJobRepository {
+	return c.replayJobRepo
+}
+
+func (c *Client) TestAttemptRepo() datastore.TestAttemptRepository {
+	return c.testAttemptRepo
+}
+
+func (c *Client) DeliveryAttemptRepo() datastore.DeliveryAttemptRepository {
+	return c.attemptRepo
+}
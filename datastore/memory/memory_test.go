@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func getConfig(dsn string) config.Configuration {
+	return config.Configuration{
+		Database: config.DatabaseConfiguration{
+			Type: config.InMemoryDatabaseProvider,
+			Dsn:  dsn,
+		},
+	}
+}
+
+func getDB(t *testing.T) (*Client, func()) {
+	db, err := New(getConfig(""))
+	require.NoError(t, err)
+
+	return db, func() {
+		require.NoError(t, db.Disconnect(context.Background()))
+	}
+}
+
+func Test_Snapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "convoy.db")
+
+	db, err := New(getConfig(path))
+	require.NoError(t, err)
+
+	group := &datastore.Group{
+		UID:            uuid.NewString(),
+		Name:           "group",
+		OrganisationID: uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.NoError(t, db.GroupRepo().CreateGroup(context.Background(), group))
+	require.NoError(t, db.Disconnect(context.Background()))
+
+	db, err = New(getConfig(path))
+	require.NoError(t, err)
+	defer db.Disconnect(context.Background())
+
+	g, err := db.GroupRepo().FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+	require.Equal(t, group.Name, g.Name)
+
+	// the unique indexes are rebuilt from the snapshot.
+	duplicate := &datastore.Group{
+		UID:            uuid.NewString(),
+		Name:           group.Name,
+		OrganisationID: group.OrganisationID,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+	require.ErrorIs(t, db.GroupRepo().CreateGroup(context.Background(), duplicate), datastore.ErrDuplicateGroupName)
+}
+
+func Test_UpdateKeepsIndexesOnConflict(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	userRepo := db.UserRepo()
+
+	first := &datastore.User{UID: uuid.NewString(), Email: "first@example.com", DocumentStatus: datastore.ActiveDocumentStatus}
+	second := &datastore.User{UID: uuid.NewString(), Email: "second@example.com", DocumentStatus: datastore.ActiveDocumentStatus}
+	require.NoError(t, userRepo.CreateUser(context.Background(), first))
+	require.NoError(t, userRepo.CreateUser(context.Background(), second))
+
+	second.Email = first.Email
+	require.ErrorIs(t, userRepo.UpdateUser(context.Background(), second), datastore.ErrDuplicateEmail)
+
+	u, err := userRepo.FindUserByEmail(context.Background(), "second@example.com")
+	require.NoError(t, err)
+	require.Equal(t, second.UID, u.UID)
+
+	// the email of the second user is still taken.
+	third := &datastore.User{UID: uuid.NewString(), Email: "second@example.com", DocumentStatus: datastore.ActiveDocumentStatus}
+	require.ErrorIs(t, userRepo.CreateUser(context.Background(), third), datastore.ErrDuplicateEmail)
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgRepo struct {
+	table *table
+}
+
+func newOrgRepo(c *Client) datastore.OrganisationRepository {
+	return &orgRepo{
+		table: c.tables[OrganisationTable],
+	}
+}
+
+func orgMatcher(fn func(*datastore.Organisation) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.Organisation)) }
+}
+
+func (db *orgRepo) LoadOrganisationsPaged(ctx context.Context, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
+	organisations := make([]datastore.Organisation, 0)
+	paginationData, err := db.table.paged(all, pageable.Sort, pageable, &organisations)
+	if err != nil {
+		return organisations, datastore.PaginationData{}, err
+	}
+
+	return organisations, paginationData, nil
+}
+
+func (db *orgRepo) CreateOrganisation(ctx context.Context, org *datastore.Organisation) error {
+	org.ID = primitive.NewObjectID()
+	return db.table.insert(org)
+}
+
+func (db *orgRepo) UpdateOrganisation(ctx context.Context, org *datastore.Organisation) error {
+	org.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	m := orgMatcher(func(o *datastore.Organisation) bool { return o.UID == org.UID })
+
+	return db.table.update(m, false, func(doc interface{}) {
+		o := doc.(*datastore.Organisation)
+		o.Name = org.Name
+		o.UpdatedAt = org.UpdatedAt
+	})
+}
+
+func (db *orgRepo) DeleteOrganisation(ctx context.Context, uid string) error {
+	m := orgMatcher(func(o *datastore.Organisation) bool { return o.UID == uid })
+
+	return db.table.update(m, false, softDelete)
+}
+
+func (db *orgRepo) FetchOrganisationByID(ctx context.Context, id string) (*datastore.Organisation, error) {
+	org := new(datastore.Organisation)
+
+	err := db.table.findOne(orgMatcher(func(o *datastore.Organisation) bool { return o.UID == id }), org)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrOrgNotFound
+	}
+
+	return org, err
+}
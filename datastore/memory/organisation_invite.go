@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgInviteRepo struct {
+	table *table
+}
+
+func newOrgInviteRepo(c *Client) datastore.OrganisationInviteRepository {
+	return &orgInviteRepo{
+		table: c.tables[OrganisationInvitesTable],
+	}
+}
+
+func orgInviteMatcher(fn func(*datastore.OrganisationInvite) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.OrganisationInvite)) }
+}
+
+func (db *orgInviteRepo) LoadOrganisationsInvitesPaged(ctx context.Context, orgID string, inviteStatus datastore.InviteStatus, pageable datastore.Pageable) ([]datastore.OrganisationInvite, datastore.PaginationData, error) {
+	m := orgInviteMatcher(func(iv *datastore.OrganisationInvite) bool {
+		if !util.IsStringEmpty(orgID) && iv.OrganisationID != orgID {
+			return false
+		}
+
+		return util.IsStringEmpty(inviteStatus.String()) || iv.Status == inviteStatus
+	})
+
+	invites := make([]datastore.OrganisationInvite, 0)
+	paginationData, err := db.table.paged(m, pageable.Sort, pageable, &invites)
+	if err != nil {
+		return invites, datastore.PaginationData{}, err
+	}
+
+	return invites, paginationData, nil
+}
+
+func (db *orgInviteRepo) CreateOrganisationInvite(ctx context.Context, iv *datastore.OrganisationInvite) error {
+	iv.ID = primitive.NewObjectID()
+	return db.table.insert(iv)
+}
+
+func (db *orgInviteRepo) UpdateOrganisationInvite(ctx context.Context, iv *datastore.OrganisationInvite) error {
+	iv.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	m := orgInviteMatcher(func(i *datastore.OrganisationInvite) bool { return i.UID == iv.UID })
+
+	return db.table.update(m, false, func(doc interface{}) {
+		i := doc.(*datastore.OrganisationInvite)
+		i.Role = iv.Role
+		i.Status = iv.Status
+		i.UpdatedAt = iv.UpdatedAt
+		i.ExpiresAt = iv.ExpiresAt
+		i.DocumentStatus = iv.DocumentStatus
+	})
+}
+
+func (db *orgInviteRepo) DeleteOrganisationInvite(ctx context.Context, uid string) error {
+	m := orgInviteMatcher(func(iv *datastore.OrganisationInvite) bool { return iv.UID == uid })
+
+	return db.table.update(m, false, softDelete)
+}
+
+func (db *orgInviteRepo) FetchOrganisationInviteByID(ctx context.Context, id string) (*datastore.OrganisationInvite, error) {
+	return db.fetchOrganisationInvite(orgInviteMatcher(func(iv *datastore.OrganisationInvite) bool { return iv.UID == id }))
+}
+
+func (db *orgInviteRepo) FetchOrganisationInviteByToken(ctx context.Context, token string) (*datastore.OrganisationInvite, error) {
+	return db.fetchOrganisationInvite(orgInviteMatcher(func(iv *datastore.OrganisationInvite) bool { return iv.Token == token }))
+}
+
+func (db *orgInviteRepo) fetchOrganisationInvite(m matcher) (*datastore.OrganisationInvite, error) {
+	iv := &datastore.OrganisationInvite{}
+
+	err := db.table.findOne(m, iv)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrOrgInviteNotFound
+	}
+
+	return iv, err
+}
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgMemberRepo struct {
+	table *table
+	users *table
+	orgs  *table
+}
+
+func newOrgMemberRepo(c *Client) datastore.OrganisationMemberRepository {
+	return &orgMemberRepo{
+		table: c.tables[OrganisationMembersTable],
+		users: c.tables[UserTable],
+		orgs:  c.tables[OrganisationTable],
+	}
+}
+
+func orgMemberMatcher(fn func(*datastore.OrganisationMember) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.OrganisationMember)) }
+}
+
+func (o *orgMemberRepo) LoadOrganisationMembersPaged(ctx context.Context, organisationID string, pageable datastore.Pageable) ([]*datastore.OrganisationMember, datastore.PaginationData, error) {
+	m := orgMemberMatcher(func(om *datastore.OrganisationMember) bool {
+		return util.IsStringEmpty(organisationID) || om.OrganisationID == organisationID
+	})
+
+	members := make([]*datastore.OrganisationMember, 0)
+	paginationData, err := o.table.paged(m, pageable.Sort, pageable, &members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	return members, paginationData, nil
+}
+
+// LoadUserOrganisationsPaged returns the active organisations of the
+// user's memberships, it pages through the memberships like the mongo
+// datastore.
+func (o *orgMemberRepo) LoadUserOrganisationsPaged(ctx context.Context, userID string, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
+	skip := 0
+	if pageable.Page > 1 {
+		skip = pageable.PerPage * pageable.Page
+	}
+
+	members := make([]datastore.OrganisationMember, 0)
+	m := orgMemberMatcher(func(om *datastore.OrganisationMember) bool { return om.UserID == userID })
+
+	err := o.table.find(m, pageable.Sort, int64(pageable.PerPage), int64(skip), &members)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	organisations := make([]datastore.Organisation, 0, len(members))
+	for _, member := range members {
+		org := datastore.Organisation{}
+
+		orgID := member.OrganisationID
+		err = o.orgs.findOne(func(doc interface{}) bool { return doc.(*datastore.Organisation).UID == orgID }, &org)
+		if errors.Is(err, errNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, datastore.PaginationData{}, err
+		}
+
+		organisations = append(organisations, org)
+	}
+
+	return organisations, datastore.PaginationData{}, nil
+}
+
+func (o *orgMemberRepo) CreateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.ID = primitive.NewObjectID()
+	return o.table.insert(member)
+}
+
+func (o *orgMemberRepo) UpdateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	m := orgMemberMatcher(func(om *datastore.OrganisationMember) bool { return om.UID == member.UID })
+
+	return o.table.update(m, false, func(doc interface{}) {
+		om := doc.(*datastore.OrganisationMember)
+		om.Role = member.Role
+		om.UpdatedAt = member.UpdatedAt
+	})
+}
+
+func (o *orgMemberRepo) DeleteOrganisationMember(ctx context.Context, uid, orgID string) error {
+	m := orgMemberMatcher(func(om *datastore.OrganisationMember) bool {
+		return om.UID == uid && om.OrganisationID == orgID
+	})
+
+	return o.table.update(m, false, softDelete)
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByID(ctx context.Context, uid, orgID string) (*datastore.OrganisationMember, error) {
+	return o.fetchOrganisationMember(orgMemberMatcher(func(om *datastore.OrganisationMember) bool {
+		return om.UID == uid && om.OrganisationID == orgID
+	}))
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByUserID(ctx context.Context, userID, orgID string) (*datastore.OrganisationMember, error) {
+	return o.fetchOrganisationMember(orgMemberMatcher(func(om *datastore.OrganisationMember) bool {
+		return om.UserID == userID && om.OrganisationID == orgID
+	}))
+}
+
+func (o *orgMemberRepo) fetchOrganisationMember(m matcher) (*datastore.OrganisationMember, error) {
+	member := new(datastore.OrganisationMember)
+
+	err := o.table.findOne(m, member)
+	if errors.Is(err, errNotFound) {
+		return nil, datastore.ErrOrgMemberNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.fillOrgMemberUserMetadata([]*datastore.OrganisationMember{member})
+	return member, err
+}
+
+func (o *orgMemberRepo) fillOrgMemberUserMetadata(members []*datastore.OrganisationMember) error {
+	userIDs := make([]string, 0, len(members))
+	for i := range members {
+		userIDs = append(userIDs, members[i].UserID)
+	}
+
+	users := make([]datastore.User, 0)
+	err := o.users.find(func(doc interface{}) bool { return in(doc.(*datastore.User).UID, userIDs) }, 1, 0, 0, &users)
+	if err != nil {
+		return err
+	}
+
+	metaMap := map[string]*datastore.UserMetadata{}
+	for _, u := range users {
+		metaMap[u.UID] = &datastore.UserMetadata{
+			UserID:    u.UID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+		}
+	}
+
+	for i := range members {
+		members[i].UserMetadata = metaMap[members[i].UserID]
+	}
+
+	return nil
+}
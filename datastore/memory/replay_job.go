@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type replayJobRepo struct {
+	table *table
+}
+
+func newReplayJobRepo(c *Client) datastore.ReplayJobRepository {
+	return &replayJobRepo{
+		table: c.tables[ReplayJobTable],
+	}
+}
+
+func replayJobMatcher(fn func(*datastore.ReplayJob) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.ReplayJob)) }
+}
+
+func (r *replayJobRepo) CreateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	job.ID = primitive.NewObjectID()
+	return r.table.insert(job)
+}
+
+func (r *replayJobRepo) UpdateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	job.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	m := replayJobMatcher(func(j *datastore.ReplayJob) bool {
+		return j.UID == job.UID && j.GroupID == job.GroupID && isActive(j)
+	})
+
+	return r.table.update(m, false, func(doc interface{}) {
+		j := doc.(*datastore.ReplayJob)
+		j.Status = job.Status
+		j.TotalEvents = job.TotalEvents
+		j.ProcessedEvents = job.ProcessedEvents
+		j.FailedEvents = job.FailedEvents
+		j.Error = job.Error
+		j.StartedAt = job.StartedAt
+		j.CompletedAt = job.CompletedAt
+		j.UpdatedAt = job.UpdatedAt
+	})
+}
+
+func (r *replayJobRepo) FindReplayJobByID(ctx context.Context, groupID string, id string) (*datastore.ReplayJob, error) {
+	job := &datastore.ReplayJob{}
+
+	m := replayJobMatcher(func(j *datastore.ReplayJob) bool { return j.UID == id && j.GroupID == groupID })
+
+	err := r.table.findOne(m, job)
+	if errors.Is(err, errNotFound) {
+		return job, datastore.ErrReplayJobNotFound
+	}
+
+	return job, err
+}
+
+func (r *replayJobRepo) LoadReplayJobsPaged(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.ReplayJob, datastore.PaginationData, error) {
+	m := replayJobMatcher(func(j *datastore.ReplayJob) bool { return j.GroupID == groupID })
+
+	var jobs []datastore.ReplayJob
+	paginationData, err := r.table.paged(m, -1, pageable, &jobs)
+	if err != nil {
+		return jobs, datastore.PaginationData{}, err
+	}
+
+	return jobs, paginationData, nil
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type sourceRepo struct {
+	table *table
+}
+
+func newSourceRepo(c *Client) datastore.SourceRepository {
+	return &sourceRepo{
+		table: c.tables[SourceTable],
+	}
+}
+
+func sourceMatcher(fn func(*datastore.Source) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.Source)) }
+}
+
+func sourceByID(groupID, id string) matcher {
+	return sourceMatcher(func(s *datastore.Source) bool { return s.UID == id && s.GroupID == groupID })
+}
+
+func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source) error {
+	source.ID = primitive.NewObjectID()
+	return s.table.insert(source)
+}
+
+func (s *sourceRepo) UpdateSource(ctx context.Context, groupId string, source *datastore.Source) error {
+	m := sourceMatcher(func(so *datastore.Source) bool {
+		return so.UID == source.UID && so.GroupID == groupId && isActive(so)
+	})
+
+	return s.table.update(m, false, func(doc interface{}) {
+		so := doc.(*datastore.Source)
+		so.Name = source.Name
+		so.Type = source.Type
+		so.IsDisabled = source.IsDisabled
+		so.Verifier = source.Verifier
+		so.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		so.ProviderConfig = source.ProviderConfig
+		so.ForwardHeaders = source.ForwardHeaders
+		so.IPAllowlist = source.IPAllowlist
+		so.EventType = source.EventType
+		so.Response = source.Response
+		so.MaxBodySize = source.MaxBodySize
+		so.Deduplication = source.Deduplication
+		so.Priority = source.Priority
+		so.RateLimit = source.RateLimit
+		so.RateLimitDuration = source.RateLimitDuration
+		so.PubSub = source.PubSub
+		so.DBChangeStream = source.DBChangeStream
+		so.RestApi = source.RestApi
+		so.LastHandshakeAt = source.LastHandshakeAt
+	})
+}
+
+func (s *sourceRepo) FindSourceByID(ctx context.Context, groupId string, id string) (*datastore.Source, error) {
+	source := &datastore.Source{}
+
+	err := s.table.findOne(sourceByID(groupId, id), source)
+	if errors.Is(err, errNotFound) {
+		return source, datastore.ErrSourceNotFound
+	}
+
+	return source, err
+}
+
+func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskId string) (*datastore.Source, error) {
+	source := &datastore.Source{}
+
+	err := s.table.findOne(sourceMatcher(func(so *datastore.Source) bool { return so.MaskID == maskId }), source)
+	if errors.Is(err, errNotFound) {
+		return source, datastore.ErrSourceNotFound
+	}
+
+	return source, err
+}
+
+func (s *sourceRepo) DeleteSourceByID(ctx context.Context, groupId string, id string) error {
+	return s.table.update(sourceByID(groupId, id), false, softDelete)
+}
+
+func (s *sourceRepo) UpdateSourceStatus(ctx context.Context, groupId string, id string, status *datastore.SourceStatus) error {
+	return s.table.update(sourceByID(groupId, id), false, func(doc interface{}) {
+		doc.(*datastore.Source).Status = status
+	})
+}
+
+func (s *sourceRepo) IncrementRejectedRequests(ctx context.Context, groupId string, id string) error {
+	return s.table.update(sourceByID(groupId, id), false, func(doc interface{}) {
+		doc.(*datastore.Source).RejectedRequests++
+	})
+}
+
+func (s *sourceRepo) UpdateHandshakeNotifiedAt(ctx context.Context, groupId string, id string, notifiedAt time.Time) error {
+	return s.table.update(sourceByID(groupId, id), false, func(doc interface{}) {
+		doc.(*datastore.Source).HandshakeNotifiedAt = primitive.NewDateTimeFromTime(notifiedAt)
+	})
+}
+
+func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, groupID string, f *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
+	m := sourceMatcher(func(so *datastore.Source) bool {
+		fields := []struct{ value, filter string }{
+			{so.GroupID, groupID},
+			{string(so.Type), f.Type},
+			{string(so.Provider), f.Provider},
+		}
+
+		for _, field := range fields {
+			if !util.IsStringEmpty(field.filter) && field.value != field.filter {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	var sources []datastore.Source
+	paginationData, err := s.table.paged(m, -1, pageable, &sources)
+	if err != nil {
+		return sources, datastore.PaginationData{}, err
+	}
+
+	return sources, paginationData, nil
+}
@@ -0,0 +1,152 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type subscriptionRepo struct {
+	table *table
+}
+
+func newSubscriptionRepo(c *Client) datastore.SubscriptionRepository {
+	return &subscriptionRepo{
+		table: c.tables[SubscriptionTable],
+	}
+}
+
+func subscriptionMatcher(fn func(*datastore.Subscription) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.Subscription)) }
+}
+
+func (s *subscriptionRepo) CreateSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	subscription.ID = primitive.NewObjectID()
+	return s.table.insert(subscription)
+}
+
+func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	subscription.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	m := subscriptionMatcher(func(sub *datastore.Subscription) bool {
+		return sub.UID == subscription.UID && sub.GroupID == groupId && isActive(sub)
+	})
+
+	return s.table.update(m, false, func(doc interface{}) {
+		sub := doc.(*datastore.Subscription)
+		sub.Name = subscription.Name
+		sub.SourceID = subscription.SourceID
+		sub.EndpointID = subscription.EndpointID
+
+		if sub.FilterConfig == nil {
+			sub.FilterConfig = &datastore.FilterConfiguration{}
+		}
+		sub.FilterConfig.EventTypes = subscription.FilterConfig.EventTypes
+
+		if sub.AlertConfig == nil {
+			sub.AlertConfig = &datastore.AlertConfiguration{}
+		}
+		sub.AlertConfig.Count = subscription.AlertConfig.Count
+		sub.AlertConfig.Threshold = subscription.AlertConfig.Threshold
+
+		if sub.RetryConfig == nil {
+			sub.RetryConfig = &datastore.RetryConfiguration{}
+		}
+		sub.RetryConfig.Type = subscription.RetryConfig.Type
+		sub.RetryConfig.Duration = subscription.RetryConfig.Duration
+		sub.RetryConfig.RetryCount = subscription.RetryConfig.RetryCount
+
+		sub.ForwardRawBody = subscription.ForwardRawBody
+	})
+}
+
+func (s *subscriptionRepo) LoadSubscriptionsPaged(ctx context.Context, groupId string, pageable datastore.Pageable) ([]datastore.Subscription, datastore.PaginationData, error) {
+	m := subscriptionMatcher(func(sub *datastore.Subscription) bool { return sub.GroupID == groupId })
+
+	var subscriptions []datastore.Subscription
+	paginationData, err := s.table.paged(m, -1, pageable, &subscriptions)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return subscriptions, paginationData, nil
+}
+
+func (s *subscriptionRepo) DeleteSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	m := subscriptionMatcher(func(sub *datastore.Subscription) bool {
+		return sub.UID == subscription.UID && sub.GroupID == groupId
+	})
+
+	return s.table.update(m, false, softDelete)
+}
+
+func (s *subscriptionRepo) FindSubscriptionByID(ctx context.Context, groupId string, uid string) (*datastore.Subscription, error) {
+	subscription := &datastore.Subscription{}
+
+	m := subscriptionMatcher(func(sub *datastore.Subscription) bool { return sub.UID == uid && sub.GroupID == groupId })
+
+	err := s.table.findOne(m, subscription)
+	if errors.Is(err, errNotFound) {
+		err = datastore.ErrSubscriptionNotFound
+	}
+
+	return subscription, err
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByEventType(ctx context.Context, groupId string, appId string, eventType datastore.EventType) ([]datastore.Subscription, error) {
+	m := subscriptionMatcher(func(sub *datastore.Subscription) bool {
+		return sub.GroupID == groupId && sub.AppID == appId &&
+			sub.FilterConfig != nil && in(string(eventType), sub.FilterConfig.EventTypes)
+	})
+
+	return s.findSubscriptions(m)
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByAppID(ctx context.Context, groupId string, appID string) ([]datastore.Subscription, error) {
+	return s.findSubscriptions(subscriptionMatcher(func(sub *datastore.Subscription) bool {
+		return sub.AppID == appID && sub.GroupID == groupId
+	}))
+}
+
+func (s *subscriptionRepo) FindSubscriptionsBySourceIDs(ctx context.Context, groupId string, sourceId string) ([]datastore.Subscription, error) {
+	return s.findSubscriptions(subscriptionMatcher(func(sub *datastore.Subscription) bool {
+		return sub.GroupID == groupId && sub.SourceID == sourceId
+	}))
+}
+
+func (s *subscriptionRepo) findSubscriptions(m matcher) ([]datastore.Subscription, error) {
+	subscriptions := make([]datastore.Subscription, 0)
+	err := s.table.find(m, 1, 0, 0, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) UpdateSubscriptionStatus(ctx context.Context, groupId string, subscriptionId string, status datastore.SubscriptionStatus) error {
+	m := subscriptionMatcher(func(sub *datastore.Subscription) bool {
+		return sub.UID == subscriptionId && sub.GroupID == groupId && isActive(sub)
+	})
+
+	return s.table.update(m, false, func(doc interface{}) {
+		sub := doc.(*datastore.Subscription)
+		sub.Status = status
+		sub.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
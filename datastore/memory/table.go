@@ -0,0 +1,484 @@
+package memory
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var errNotFound = errors.New("document not found")
+
+// duplicateKeyError is returned when a write would give two documents the
+// same key of a unique index.
+type duplicateKeyError struct {
+	index string
+}
+
+func (e *duplicateKeyError) Error() string {
+	return "duplicate key error on index " + e.index
+}
+
+func isDuplicateKeyError(err error, index string) bool {
+	var dErr *duplicateKeyError
+	return errors.As(err, &dErr) && dErr.index == index
+}
+
+// matcher reports whether a decoded document matches a query, it must not
+// modify the document.
+type matcher func(doc interface{}) bool
+
+// uniqueIndex returns the key of a document in a unique index, documents
+// with an empty key aren't indexed.
+type uniqueIndex func(doc interface{}) string
+
+type record struct {
+	seq    int64
+	raw    bson.Raw
+	doc    interface{}
+	cursor datastore.Cursor
+}
+
+// table keeps the documents of a collection encoded as bson, documents are
+// decoded into new values for every read so callers never share memory
+// with the stored documents.
+type table struct {
+	mu      sync.RWMutex
+	model   reflect.Type
+	records []*record
+	seq     int64
+
+	indexes map[string]uniqueIndex
+	// keys maps the keys of each unique index to the seq of the document
+	// that has them.
+	keys map[string]map[string]int64
+
+	// changed is called after every write.
+	changed func()
+}
+
+func newTable(model interface{}, changed func()) *table {
+	return &table{
+		model:   reflect.TypeOf(model).Elem(),
+		indexes: map[string]uniqueIndex{},
+		keys:    map[string]map[string]int64{},
+		changed: changed,
+	}
+}
+
+// unique adds a unique index to the table, it must be called before any
+// document is inserted.
+func (t *table) unique(name string, key uniqueIndex) *table {
+	t.indexes[name] = key
+	t.keys[name] = map[string]int64{}
+	return t
+}
+
+func (t *table) newRecord(raw bson.Raw) (*record, error) {
+	doc := reflect.New(t.model).Interface()
+	if err := bson.Unmarshal(raw, doc); err != nil {
+		return nil, err
+	}
+
+	r := &record{raw: raw, doc: doc}
+	if v, ok := raw.Lookup("created_at").DateTimeOK(); ok {
+		r.cursor.CreatedAt = primitive.DateTime(v)
+	}
+
+	if v, ok := raw.Lookup("_id").ObjectIDOK(); ok {
+		r.cursor.ID = v
+	}
+
+	return r, nil
+}
+
+// index adds the keys of r to the unique indexes, it returns a
+// duplicateKeyError and leaves the indexes unchanged when another document
+// has one of the keys.
+func (t *table) index(r *record) error {
+	keys := map[string]string{}
+	for name, key := range t.indexes {
+		k := key(r.doc)
+		if k == "" {
+			continue
+		}
+
+		if seq, ok := t.keys[name][k]; ok && seq != r.seq {
+			return &duplicateKeyError{index: name}
+		}
+		keys[name] = k
+	}
+
+	for name, k := range keys {
+		t.keys[name][k] = r.seq
+	}
+
+	return nil
+}
+
+// unindex removes the keys of r from the unique indexes.
+func (t *table) unindex(r *record) {
+	for name, key := range t.indexes {
+		k := key(r.doc)
+		if k != "" && t.keys[name][k] == r.seq {
+			delete(t.keys[name], k)
+		}
+	}
+}
+
+func (t *table) insert(v interface{}) error {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	r, err := t.newRecord(raw)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	r.seq = t.seq
+	if err = t.index(r); err != nil {
+		return err
+	}
+
+	t.records = append(t.records, r)
+	t.changed()
+
+	return nil
+}
+
+// matching returns the active records matching m ordered by their creation
+// time and id, ascending when sortOrder is positive and descending
+// otherwise.
+func (t *table) matching(m matcher, sortOrder int) []*record {
+	records := make([]*record, 0)
+	for _, r := range t.records {
+		if isActive(r.doc) && m(r.doc) {
+			records = append(records, r)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if sortOrder <= 0 {
+			a, b = b, a
+		}
+
+		if a.cursor != b.cursor {
+			return a.cursor.Before(b.cursor)
+		}
+
+		return a.seq < b.seq
+	})
+
+	return records
+}
+
+// findOne decodes the first active document matching m into out, it
+// returns errNotFound when there is none.
+func (t *table) findOne(m matcher, out interface{}) error {
+	if !datastore.IsValidPointer(out) {
+		return datastore.ErrInvalidPtr
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, r := range t.records {
+		if isActive(r.doc) && m(r.doc) {
+			return bson.Unmarshal(r.raw, out)
+		}
+	}
+
+	return errNotFound
+}
+
+// find decodes the active documents matching m into out, a pointer to a slice of
+// models or of pointers to models, skipping skip documents and returning
+// at most limit documents when limit is positive.
+func (t *table) find(m matcher, sortOrder int, limit, skip int64, out interface{}) error {
+	t.mu.RLock()
+	records := t.matching(m, sortOrder)
+	t.mu.RUnlock()
+
+	if skip >= int64(len(records)) {
+		records = records[:0]
+	} else if skip > 0 {
+		records = records[skip:]
+	}
+
+	if limit > 0 && limit < int64(len(records)) {
+		records = records[:limit]
+	}
+
+	return decodeAll(records, out)
+}
+
+// paged loads a page of the active documents matching m ordered by their
+// creation time.
+func (t *table) paged(m matcher, sortOrder int, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	page, perPage := pageable.PageAndLimit()
+
+	t.mu.RLock()
+	records := t.matching(m, sortOrder)
+	t.mu.RUnlock()
+
+	total := int64(len(records))
+	start, end := (page-1)*perPage, page*perPage
+	if start > total {
+		start = total
+	}
+
+	if end > total {
+		end = total
+	}
+
+	if err := decodeAll(records[start:end], out); err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	return datastore.NewPaginationData(total, page, perPage), nil
+}
+
+// cursorPaged loads the page of the active documents matching m pageable
+// refers to, pageable may refer to a page number or to a cursor.
+func (t *table) cursorPaged(m matcher, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	q, err := pageable.CursorPage()
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	t.mu.RLock()
+	records := t.matching(m, q.Sort)
+	t.mu.RUnlock()
+
+	total := int64(len(records))
+	if q.After != nil {
+		after := *q.After
+		i := sort.Search(len(records), func(i int) bool {
+			if q.Sort > 0 {
+				return after.Before(records[i].cursor)
+			}
+			return records[i].cursor.Before(after)
+		})
+		records = records[i:]
+	}
+
+	if q.Skip >= int64(len(records)) {
+		records = records[:0]
+	} else if q.Skip > 0 {
+		records = records[q.Skip:]
+	}
+
+	if q.Limit < int64(len(records)) {
+		records = records[:q.Limit]
+	}
+
+	if err = decodeAll(records, out); err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	return q.Paginate(out, total)
+}
+
+// count returns the number of active documents matching m.
+func (t *table) count(m matcher) int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var count int64
+	for _, r := range t.records {
+		if isActive(r.doc) && m(r.doc) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// countAll returns the number of documents matching m, including the
+// soft deleted ones.
+func (t *table) countAll(m matcher) int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var count int64
+	for _, r := range t.records {
+		if m(r.doc) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// update applies fn to a copy of the first document matching m, or of
+// every matching document when many is set, and stores the copies. No
+// document is changed when a copy has the key of another document in a
+// unique index.
+func (t *table) update(m matcher, many bool, fn func(doc interface{})) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	positions := make([]int, 0)
+	updated := make([]*record, 0)
+	for i, r := range t.records {
+		if !m(r.doc) {
+			continue
+		}
+
+		doc := reflect.New(t.model).Interface()
+		if err := bson.Unmarshal(r.raw, doc); err != nil {
+			return err
+		}
+
+		fn(doc)
+
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		nr, err := t.newRecord(raw)
+		if err != nil {
+			return err
+		}
+		nr.seq = r.seq
+
+		positions = append(positions, i)
+		updated = append(updated, nr)
+
+		if !many {
+			break
+		}
+	}
+
+	if len(updated) == 0 {
+		return nil
+	}
+
+	for _, i := range positions {
+		t.unindex(t.records[i])
+	}
+
+	for j, r := range updated {
+		if err := t.index(r); err != nil {
+			for _, indexed := range updated[:j] {
+				t.unindex(indexed)
+			}
+
+			for _, i := range positions {
+				_ = t.index(t.records[i])
+			}
+
+			return err
+		}
+	}
+
+	for j, i := range positions {
+		t.records[i] = updated[j]
+	}
+
+	t.changed()
+	return nil
+}
+
+// delete removes the documents matching m.
+func (t *table) delete(m matcher) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	records := t.records[:0]
+	for _, r := range t.records {
+		if m(r.doc) {
+			t.unindex(r)
+			continue
+		}
+		records = append(records, r)
+	}
+
+	for i := len(records); i < len(t.records); i++ {
+		t.records[i] = nil
+	}
+	t.records = records
+
+	t.changed()
+}
+
+// snapshot returns the encoded documents of the table.
+func (t *table) snapshot() []bson.Raw {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	docs := make([]bson.Raw, 0, len(t.records))
+	for _, r := range t.records {
+		docs = append(docs, r.raw)
+	}
+
+	return docs
+}
+
+// restore adds the documents of a snapshot to an empty table.
+func (t *table) restore(docs []bson.Raw) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, raw := range docs {
+		r, err := t.newRecord(raw)
+		if err != nil {
+			return err
+		}
+
+		t.seq++
+		r.seq = t.seq
+		if err = t.index(r); err != nil {
+			return err
+		}
+
+		t.records = append(t.records, r)
+	}
+
+	return nil
+}
+
+func decodeAll(records []*record, out interface{}) error {
+	if !datastore.IsValidPointer(out) {
+		return datastore.ErrInvalidPtr
+	}
+
+	slice := reflect.ValueOf(out).Elem()
+	if slice.Kind() != reflect.Slice {
+		return datastore.ErrInvalidPtr
+	}
+
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	results := reflect.MakeSlice(slice.Type(), 0, len(records))
+	for _, r := range records {
+		elem := reflect.New(elemType)
+		if err := bson.Unmarshal(r.raw, elem.Interface()); err != nil {
+			return err
+		}
+
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		results = reflect.Append(results, elem)
+	}
+
+	slice.Set(results)
+	return nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testAttemptRepo struct {
+	table *table
+}
+
+func newTestAttemptRepo(c *Client) datastore.TestAttemptRepository {
+	return &testAttemptRepo{
+		table: c.tables[TestAttemptTable],
+	}
+}
+
+func (t *testAttemptRepo) CreateTestAttempt(ctx context.Context, attempt *datastore.TestAttempt) error {
+	attempt.ID = primitive.NewObjectID()
+	return t.table.insert(attempt)
+}
+
+func (t *testAttemptRepo) LoadTestAttemptsPaged(ctx context.Context, groupID string, appID string, endpointID string, pageable datastore.Pageable) ([]datastore.TestAttempt, datastore.PaginationData, error) {
+	m := func(doc interface{}) bool {
+		a := doc.(*datastore.TestAttempt)
+		return a.GroupID == groupID && a.AppID == appID && a.EndpointID == endpointID
+	}
+
+	var attempts []datastore.TestAttempt
+	paginationData, err := t.table.paged(m, -1, pageable, &attempts)
+	if err != nil {
+		return attempts, datastore.PaginationData{}, err
+	}
+
+	return attempts, paginationData, nil
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userRepo struct {
+	table *table
+}
+
+func newUserRepo(c *Client) datastore.UserRepository {
+	return &userRepo{
+		table: c.tables[UserTable],
+	}
+}
+
+func userMatcher(fn func(*datastore.User) bool) matcher {
+	return func(doc interface{}) bool { return fn(doc.(*datastore.User)) }
+}
+
+func (u *userRepo) CreateUser(ctx context.Context, user *datastore.User) error {
+	user.ID = primitive.NewObjectID()
+	user.ResetPasswordToken = uuid.NewString()
+
+	err := u.table.insert(user)
+	if isDuplicateKeyError(err, userEmailIndex) {
+		return datastore.ErrDuplicateEmail
+	}
+
+	return err
+}
+
+func (u *userRepo) FindUserByEmail(ctx context.Context, email string) (*datastore.User, error) {
+	return u.findUser(userMatcher(func(user *datastore.User) bool { return user.Email == email }))
+}
+
+func (u *userRepo) FindUserByID(ctx context.Context, id string) (*datastore.User, error) {
+	return u.findUser(userMatcher(func(user *datastore.User) bool { return user.UID == id }))
+}
+
+func (u *userRepo) FindUserByToken(ctx context.Context, token string) (*datastore.User, error) {
+	return u.findUser(userMatcher(func(user *datastore.User) bool { return user.ResetPasswordToken == token }))
+}
+
+func (u *userRepo) findUser(m matcher) (*datastore.User, error) {
+	user := &datastore.User{}
+
+	err := u.table.findOne(m, user)
+	if errors.Is(err, errNotFound) {
+		return user, datastore.ErrUserNotFound
+	}
+
+	return user, err
+}
+
+func (u *userRepo) LoadUsersPaged(ctx context.Context, pageable datastore.Pageable) ([]datastore.User, datastore.PaginationData, error) {
+	var users []datastore.User
+	paginationData, err := u.table.paged(all, -1, pageable, &users)
+	if err != nil {
+		return users, datastore.PaginationData{}, err
+	}
+
+	return users, paginationData, nil
+}
+
+func (u *userRepo) UpdateUser(ctx context.Context, user *datastore.User) error {
+	m := userMatcher(func(us *datastore.User) bool { return us.UID == user.UID })
+
+	err := u.table.update(m, false, func(doc interface{}) {
+		us := doc.(*datastore.User)
+		us.FirstName = user.FirstName
+		us.LastName = user.LastName
+		us.Email = user.Email
+		us.Password = user.Password
+		us.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		us.ResetPasswordToken = user.ResetPasswordToken
+		us.ResetPasswordExpiresAt = user.ResetPasswordExpiresAt
+	})
+	if isDuplicateKeyError(err, userEmailIndex) {
+		return datastore.ErrDuplicateEmail
+	}
+
+	return err
+}
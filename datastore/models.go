@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"strings"
 
@@ -20,6 +21,14 @@ type Pageable struct {
 	Page    int `json:"page" bson:"page"`
 	PerPage int `json:"per_page" bson:"per_page"`
 	Sort    int `json:"sort" bson:"sort"`
+
+	// NextCursor and PrevCursor load the page after or before a cursor
+	// instead of a page number, SkipTotal skips counting the matching
+	// documents. They are only supported by the events, event deliveries
+	// and delivery attempts.
+	NextCursor string `json:"next_cursor" bson:"next_cursor"`
+	PrevCursor string `json:"prev_cursor" bson:"prev_cursor"`
+	SkipTotal  bool   `json:"skip_total" bson:"skip_total"`
 }
 
 type PaginationData struct {
@@ -29,6 +38,45 @@ type PaginationData struct {
 	Prev      int64 `json:"prev"`
 	Next      int64 `json:"next"`
 	TotalPage int64 `json:"totalPage"`
+
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// PageAndLimit returns the page number and size of p, they default to 1
+// and 10 when they aren't positive like in the mongo pager.
+func (p Pageable) PageAndLimit() (int64, int64) {
+	page, perPage := int64(p.Page), int64(p.PerPage)
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	return page, perPage
+}
+
+// NewPaginationData returns the pagination data of a page of total
+// documents computed like the mongo pager.
+func NewPaginationData(total, page, perPage int64) PaginationData {
+	data := PaginationData{
+		Total:     total,
+		Page:      page,
+		PerPage:   perPage,
+		TotalPage: int64(math.Ceil(float64(total) / float64(perPage))),
+	}
+
+	if page > 1 && total > 0 {
+		data.Prev = page - 1
+	}
+
+	if page != data.TotalPage && total > 0 && page <= data.TotalPage {
+		data.Next = page + 1
+	}
+
+	return data
 }
 
 type Period int
@@ -90,6 +138,14 @@ const (
 	GithubSourceProvider  SourceProvider = "github"
 	TwitterSourceProvider SourceProvider = "twitter"
 	ShopifySourceProvider SourceProvider = "shopify"
+	StripeSourceProvider  SourceProvider = "stripe"
+	MetaSourceProvider    SourceProvider = "meta"
+)
+
+type PubSubType string
+
+const (
+	RedisPubSub PubSubType = "redis"
 )
 
 const (
@@ -165,8 +221,28 @@ const (
 	ActiveSubscriptionStatus   SubscriptionStatus = "active"
 	InactiveSubscriptionStatus SubscriptionStatus = "inactive"
 	PendingSubscriptionStatus  SubscriptionStatus = "pending"
+	PausedSubscriptionStatus   SubscriptionStatus = "paused"
+
+	// ResumingSubscriptionStatus is the status of a resumed subscription
+	// until the deliveries held while it was paused are released, new
+	// deliveries are held behind them so they go out in order.
+	ResumingSubscriptionStatus SubscriptionStatus = "resuming"
 )
 
+// DefaultResumeRate is the number of held deliveries released per second
+// when a paused subscription is resumed without an explicit rate.
+const DefaultResumeRate = 50
+
+// ResumeSubscriptionPayload is the payload of the task releasing the
+// deliveries held while a subscription was paused.
+type ResumeSubscriptionPayload struct {
+	GroupID        string `json:"group_id"`
+	SubscriptionID string `json:"subscription_id"`
+
+	// Rate is the number of held deliveries released per second.
+	Rate int `json:"rate"`
+}
+
 type Application struct {
 	ID              primitive.ObjectID `json:"-" bson:"_id"`
 	UID             string             `json:"uid" bson:"uid"`
@@ -188,12 +264,25 @@ type Application struct {
 
 type SubscriptionStatus string
 
+type EndpointStatus string
+
+const (
+	ActiveEndpointStatus  EndpointStatus = "active"
+	PendingEndpointStatus EndpointStatus = "pending"
+)
+
 type Endpoint struct {
 	UID         string `json:"uid" bson:"uid"`
 	TargetURL   string `json:"target_url" bson:"target_url"`
 	Description string `json:"description" bson:"description"`
 	Secret      string `json:"secret" bson:"secret"`
 
+	// Status is empty for endpoints created before verification existed,
+	// those are treated as active.
+	Status            EndpointStatus     `json:"status,omitempty" bson:"status,omitempty"`
+	VerificationToken string             `json:"-" bson:"verification_token,omitempty"`
+	VerifiedAt        primitive.DateTime `json:"verified_at,omitempty" bson:"verified_at,omitempty" swaggertype:"string"`
+
 	HttpTimeout       string `json:"http_timeout" bson:"http_timeout"`
 	RateLimit         int    `json:"rate_limit" bson:"rate_limit"`
 	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration"`
@@ -205,6 +294,12 @@ type Endpoint struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// IsPending reports whether the endpoint is waiting for its owner to
+// complete the verification handshake.
+func (e *Endpoint) IsPending() bool {
+	return e.Status == PendingEndpointStatus
+}
+
 var ErrOrgNotFound = errors.New("organisation not found")
 var ErrOrgInviteNotFound = errors.New("organisation invite not found")
 var ErrOrgMemberNotFound = errors.New("organisation member not found")
@@ -243,6 +338,7 @@ type GroupConfig struct {
 	DisableEndpoint          bool                          `json:"disable_endpoint" bson:"disable_endpoint"`
 	ReplayAttacks            bool                          `json:"replay_attacks" bson:"replay_attacks"`
 	IsRetentionPolicyEnabled bool                          `json:"is_retention_policy_enabled" bson:"is_retention_policy_enabled"`
+	VerifyEndpoints          bool                          `json:"verify_endpoints" bson:"verify_endpoints"`
 }
 
 type RateLimitConfiguration struct {
@@ -283,6 +379,9 @@ type GroupFilter struct {
 
 type EventFilter struct {
 	GroupID        string         `json:"group_id" bson:"group_id"`
+	AppID          string         `json:"app_id,omitempty" bson:"app_id,omitempty"`
+	SourceID       string         `json:"source_id,omitempty" bson:"source_id,omitempty"`
+	EventTypes     []string       `json:"event_types,omitempty" bson:"event_types,omitempty"`
 	DocumentStatus DocumentStatus `json:"document_status" bson:"document_status"`
 	CreatedAtStart int64          `json:"created_at_start" bson:"created_at_start"`
 	CreatedAtEnd   int64          `json:"created_at_end" bson:"created_at_end"`
@@ -294,6 +393,17 @@ type EventDeliveryFilter struct {
 	CreatedAtEnd   int64  `json:"created_at_end" bson:"created_at_end"`
 }
 
+// DeliveryAttemptFilter selects the delivery attempts of a group, the
+// empty fields and a zero CreatedAtEnd match every attempt.
+type DeliveryAttemptFilter struct {
+	GroupID         string `json:"group_id" bson:"group_id"`
+	EventDeliveryID string `json:"event_delivery_id" bson:"event_delivery_id"`
+	AppID           string `json:"app_id" bson:"app_id"`
+	EndpointID      string `json:"endpoint_id" bson:"endpoint_id"`
+	CreatedAtStart  int64  `json:"created_at_start" bson:"created_at_start"`
+	CreatedAtEnd    int64  `json:"created_at_end" bson:"created_at_end"`
+}
+
 func (g *GroupFilter) WithNamesTrimmed() *GroupFilter {
 	f := GroupFilter{OrgID: g.OrgID, Names: []string{}}
 
@@ -328,6 +438,7 @@ var (
 	ErrSubscriptionNotFound          = errors.New("subscription not found")
 	ErrEventDeliveryNotFound         = errors.New("event delivery not found")
 	ErrEventDeliveryAttemptNotFound  = errors.New("event delivery attempt not found")
+	ErrReplayJobNotFound             = errors.New("replay job not found")
 	ErrDuplicateAppName              = errors.New("an application with this name exists")
 	ErrNotAuthorisedToAccessDocument = errors.New("your credentials cannot access or modify this resource")
 	ErrConfigNotFound                = errors.New("config not found")
@@ -360,6 +471,7 @@ type Event struct {
 	// This is optional
 	// If not provided, we will generate one for you
 	ProviderID string                `json:"provider_id,omitempty" bson:"provider_id"`
+	Priority   EventPriority         `json:"priority,omitempty" bson:"priority,omitempty"`
 	SourceID   string                `json:"source_id,omitempty" bson:"source_id"`
 	GroupID    string                `json:"group_id,omitempty" bson:"group_id"`
 	AppID      string                `json:"app_id,omitempty" bson:"app_id"`
@@ -371,6 +483,28 @@ type Event struct {
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data,omitempty" bson:"data"`
 
+	// DataRef is the object store reference Data was offloaded to when it
+	// was above the payload offload threshold of the storage policy, Data
+	// is empty in the database then.
+	DataRef string `json:"data_ref,omitempty" bson:"data_ref,omitempty"`
+
+	// Raw and ContentType hold the original body of ingested requests
+	// that were not JSON, Data holds their JSON form.
+	Raw         []byte `json:"raw,omitempty" bson:"raw,omitempty"`
+	ContentType string `json:"content_type,omitempty" bson:"content_type,omitempty"`
+
+	// RawRef is the object store reference Raw was offloaded to, like
+	// DataRef.
+	RawRef string `json:"raw_ref,omitempty" bson:"raw_ref,omitempty"`
+
+	// IdempotencyKey identifies the provider delivery an ingested event
+	// was created from, retries of the delivery share it.
+	IdempotencyKey string `json:"idempotency_key,omitempty" bson:"idempotency_key,omitempty"`
+
+	// DuplicateCount counts the retries of the event's delivery that
+	// were dropped by its source's deduplication.
+	DuplicateCount int64 `json:"duplicate_count" bson:"duplicate_count"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -378,6 +512,51 @@ type Event struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// EventPriority decides which queues an event and its deliveries go
+// through, so urgent events are not held up behind bulk traffic.
+type EventPriority string
+
+const (
+	HighEventPriority   EventPriority = "high"
+	NormalEventPriority EventPriority = "normal"
+	LowEventPriority    EventPriority = "low"
+)
+
+func (p EventPriority) IsValid() bool {
+	switch p {
+	case HighEventPriority, NormalEventPriority, LowEventPriority, "":
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateEventQueue returns the queue events of this priority are created on.
+// An empty priority is treated as normal.
+func (p EventPriority) CreateEventQueue() convoy.QueueName {
+	switch p {
+	case HighEventPriority:
+		return convoy.HighPriorityCreateEventQueue
+	case LowEventPriority:
+		return convoy.LowPriorityCreateEventQueue
+	default:
+		return convoy.CreateEventQueue
+	}
+}
+
+// EventQueue returns the queue deliveries of this priority are sent on.
+// An empty priority is treated as normal.
+func (p EventPriority) EventQueue() convoy.QueueName {
+	switch p {
+	case HighEventPriority:
+		return convoy.HighPriorityEventQueue
+	case LowEventPriority:
+		return convoy.LowPriorityEventQueue
+	default:
+		return convoy.EventQueue
+	}
+}
+
 type EventDeliveryStatus string
 type HttpHeader map[string]string
 
@@ -395,6 +574,9 @@ const (
 	FailureEventStatus    EventDeliveryStatus = "Failure"
 	SuccessEventStatus    EventDeliveryStatus = "Success"
 	RetryEventStatus      EventDeliveryStatus = "Retry"
+	// PausedEventStatus : when a delivery is held because its subscription is paused
+	// or its endpoint has not been verified
+	PausedEventStatus EventDeliveryStatus = "Paused"
 )
 
 func (e EventDeliveryStatus) IsValid() bool {
@@ -404,7 +586,8 @@ func (e EventDeliveryStatus) IsValid() bool {
 		DiscardedEventStatus,
 		FailureEventStatus,
 		SuccessEventStatus,
-		RetryEventStatus:
+		RetryEventStatus,
+		PausedEventStatus:
 		return true
 	default:
 		return false
@@ -413,8 +596,19 @@ func (e EventDeliveryStatus) IsValid() bool {
 
 type Metadata struct {
 	// Data to be sent to endpoint.
-	Data     json.RawMessage  `json:"data" bson:"data"`
+	Data json.RawMessage `json:"data" bson:"data"`
+
+	// DataRef is the object store reference of Data when the event's data
+	// was offloaded, it is shared by the deliveries of the event.
+	DataRef  string           `json:"data_ref,omitempty" bson:"data_ref,omitempty"`
 	Strategy StrategyProvider `json:"strategy" bson:"strategy"`
+
+	// Raw is sent instead of Data, with its ContentType, when the
+	// subscription forwards the original body of ingested events. RawRef
+	// is the object store reference of Raw when it was offloaded.
+	Raw         []byte `json:"raw,omitempty" bson:"raw,omitempty"`
+	RawRef      string `json:"raw_ref,omitempty" bson:"raw_ref,omitempty"`
+	ContentType string `json:"content_type,omitempty" bson:"content_type,omitempty"`
 	// NextSendTime denotes the next time a Event will be published in
 	// case it failed the first time
 	NextSendTime primitive.DateTime `json:"next_send_time" bson:"next_send_time"`
@@ -448,14 +642,21 @@ type EventInterval struct {
 	Count uint64            `json:"count" bson:"count"`
 }
 
+// DeliveryAttempt records a request sent for an event delivery, attempts
+// are kept apart from the event deliveries so that retries don't grow the
+// deliveries.
 type DeliveryAttempt struct {
-	ID         primitive.ObjectID `json:"-" bson:"_id"`
-	UID        string             `json:"uid" bson:"uid"`
-	MsgID      string             `json:"msg_id" bson:"msg_id"`
-	URL        string             `json:"url" bson:"url"`
-	Method     string             `json:"method" bson:"method"`
-	EndpointID string             `json:"endpoint_id" bson:"endpoint_id"`
-	APIVersion string             `json:"api_version" bson:"api_version"`
+	ID      primitive.ObjectID `json:"-" bson:"_id"`
+	UID     string             `json:"uid" bson:"uid"`
+	GroupID string             `json:"group_id,omitempty" bson:"group_id"`
+	AppID   string             `json:"app_id,omitempty" bson:"app_id"`
+
+	// MsgID is the id of the event delivery the attempt was made for.
+	MsgID      string `json:"msg_id" bson:"msg_id"`
+	URL        string `json:"url" bson:"url"`
+	Method     string `json:"method" bson:"method"`
+	EndpointID string `json:"endpoint_id" bson:"endpoint_id"`
+	APIVersion string `json:"api_version" bson:"api_version"`
 
 	IPAddress        string     `json:"ip_address,omitempty" bson:"ip_address,omitempty"`
 	RequestHeader    HttpHeader `json:"request_http_header,omitempty" bson:"request_http_header,omitempty"`
@@ -468,6 +669,8 @@ type DeliveryAttempt struct {
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
+
+	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
 // Event defines a payload to be sent to an application
@@ -479,16 +682,17 @@ type EventDelivery struct {
 	EventID        string                `json:"event_id,omitempty" bson:"event_id"`
 	EndpointID     string                `json:"endpoint_id,omitempty" bson:"endpoint_id"`
 	SubscriptionID string                `json:"subscription_id,omitempty" bson:"subscription_id"`
+	ReplayJobID    string                `json:"replay_job_id,omitempty" bson:"replay_job_id,omitempty"`
+	Priority       EventPriority         `json:"priority,omitempty" bson:"priority,omitempty"`
 	Headers        httpheader.HTTPHeader `json:"headers" bson:"headers"`
 
 	Event    *Event       `json:"event_metadata,omitempty" bson:"-"`
 	Endpoint *Endpoint    `json:"endpoint_metadata,omitempty" bson:"-"`
 	App      *Application `json:"app_metadata,omitempty" bson:"-"`
 
-	DeliveryAttempts []DeliveryAttempt   `json:"-" bson:"attempts"`
-	Status           EventDeliveryStatus `json:"status" bson:"status"`
-	Metadata         *Metadata           `json:"metadata" bson:"metadata"`
-	Description      string              `json:"description,omitempty" bson:"description"`
+	Status      EventDeliveryStatus `json:"status" bson:"status"`
+	Metadata    *Metadata           `json:"metadata" bson:"metadata"`
+	Description string              `json:"description,omitempty" bson:"description"`
 
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
@@ -499,6 +703,78 @@ type EventDelivery struct {
 
 type KeyType string
 
+type ReplayJobStatus string
+
+const (
+	PendingReplayJobStatus   ReplayJobStatus = "pending"
+	RunningReplayJobStatus   ReplayJobStatus = "running"
+	CompletedReplayJobStatus ReplayJobStatus = "completed"
+	CancelledReplayJobStatus ReplayJobStatus = "cancelled"
+	FailedReplayJobStatus    ReplayJobStatus = "failed"
+)
+
+// DefaultReplayJobRateLimit is the number of events a replay job
+// processes per second when no rate limit is specified.
+const DefaultReplayJobRateLimit = 100
+
+// ReplayJob replays every event matching Filter to the subscriptions
+// that currently match each event.
+type ReplayJob struct {
+	ID      primitive.ObjectID `json:"-" bson:"_id"`
+	UID     string             `json:"uid" bson:"uid"`
+	GroupID string             `json:"group_id" bson:"group_id"`
+	Filter  *EventFilter       `json:"filter" bson:"filter"`
+	Status  ReplayJobStatus    `json:"status" bson:"status"`
+
+	// RateLimit is the maximum number of events replayed per second.
+	RateLimit int `json:"rate_limit" bson:"rate_limit"`
+
+	TotalEvents     int64  `json:"total_events" bson:"total_events"`
+	ProcessedEvents int64  `json:"processed_events" bson:"processed_events"`
+	FailedEvents    int64  `json:"failed_events" bson:"failed_events"`
+	Error           string `json:"error,omitempty" bson:"error,omitempty"`
+
+	StartedAt   primitive.DateTime `json:"started_at,omitempty" bson:"started_at,omitempty" swaggertype:"string"`
+	CompletedAt primitive.DateTime `json:"completed_at,omitempty" bson:"completed_at,omitempty" swaggertype:"string"`
+	CreatedAt   primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt   primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt   primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
+
+	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
+}
+
+// IsDone reports whether the job has reached a terminal state.
+func (r *ReplayJob) IsDone() bool {
+	switch r.Status {
+	case CompletedReplayJobStatus, CancelledReplayJobStatus, FailedReplayJobStatus:
+		return true
+	default:
+		return false
+	}
+}
+
+// TestAttempt records a test event sent to an endpoint on demand. Test
+// attempts are kept apart from event deliveries so they never count
+// towards delivery statistics.
+type TestAttempt struct {
+	ID         primitive.ObjectID `json:"-" bson:"_id"`
+	UID        string             `json:"uid" bson:"uid"`
+	GroupID    string             `json:"group_id" bson:"group_id"`
+	AppID      string             `json:"app_id" bson:"app_id"`
+	EndpointID string             `json:"endpoint_id" bson:"endpoint_id"`
+	Payload    json.RawMessage    `json:"payload" bson:"payload"`
+	Attempt    DeliveryAttempt    `json:"attempt" bson:"attempt"`
+
+	// Duration is the time taken for the endpoint to respond, in milliseconds.
+	Duration int64 `json:"duration" bson:"duration"`
+
+	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
+
+	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
+}
+
 type APIKey struct {
 	ID        primitive.ObjectID `json:"-" bson:"_id"`
 	UID       string             `json:"uid" bson:"uid"`
@@ -536,6 +812,10 @@ type Subscription struct {
 	RetryConfig  *RetryConfiguration  `json:"retry_config,omitempty" bson:"retry_config,omitempty"`
 	FilterConfig *FilterConfiguration `json:"filter_config,omitempty" bson:"filter_config,omitempty"`
 
+	// ForwardRawBody sends the original body of ingested events that were
+	// not JSON instead of their JSON form.
+	ForwardRawBody bool `json:"forward_raw_body" bson:"forward_raw_body"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at" swaggertype:"string"`
@@ -556,6 +836,59 @@ type Source struct {
 	ProviderConfig *ProviderConfig    `json:"provider_config" bson:"provider_config"`
 	ForwardHeaders []string           `json:"forward_headers" bson:"forward_headers"`
 
+	// EventType configures how the event type of ingested requests is
+	// derived, it defaults to the provider's event type.
+	EventType *SourceEventType `json:"event_type,omitempty" bson:"event_type,omitempty"`
+
+	// IPAllowlist are the CIDRs requests to the source are accepted
+	// from, an empty allowlist accepts requests from anywhere.
+	IPAllowlist []string `json:"ip_allowlist" bson:"ip_allowlist"`
+
+	// LastHandshakeAt is when the provider last completed a challenge
+	// handshake with the source.
+	LastHandshakeAt primitive.DateTime `json:"last_handshake_at,omitempty" bson:"last_handshake_at,omitempty" swaggertype:"string"`
+
+	// HandshakeNotifiedAt is when the apps subscribed to the source were
+	// last notified of a missed handshake.
+	HandshakeNotifiedAt primitive.DateTime `json:"handshake_notified_at,omitempty" bson:"handshake_notified_at,omitempty" swaggertype:"string"`
+
+	// RejectedRequests counts requests rejected by the ip allowlist.
+	RejectedRequests int64 `json:"rejected_requests" bson:"rejected_requests"`
+
+	// Response is sent to providers for ingested requests instead of the
+	// default response.
+	Response *SourceResponse `json:"response,omitempty" bson:"response,omitempty"`
+
+	// MaxBodySize is the largest request body in bytes the source
+	// accepts, it defaults to config.MaxRequestSize.
+	MaxBodySize int64 `json:"max_body_size,omitempty" bson:"max_body_size,omitempty"`
+
+	// Deduplication drops retries of requests the source already
+	// ingested, it is disabled when nil.
+	Deduplication *SourceDeduplication `json:"deduplication,omitempty" bson:"deduplication,omitempty"`
+
+	// Priority is the priority of the events the source ingests.
+	Priority EventPriority `json:"priority,omitempty" bson:"priority,omitempty"`
+
+	// RateLimit is the number of requests the source accepts per
+	// RateLimitDuration, they default to convoy.RATE_LIMIT and
+	// convoy.RATE_LIMIT_DURATION.
+	RateLimit         int    `json:"rate_limit" bson:"rate_limit"`
+	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration"`
+
+	// PubSub configures the broker pub_sub sources consume events from.
+	PubSub *PubSubConfig `json:"pub_sub,omitempty" bson:"pub_sub,omitempty"`
+
+	// DBChangeStream configures the collection db_change_stream sources
+	// watch for changes.
+	DBChangeStream *DBChangeStreamConfig `json:"db_change_stream,omitempty" bson:"db_change_stream,omitempty"`
+
+	// RestApi configures the API rest_api sources poll for new items.
+	RestApi *RestApiConfig `json:"rest_api,omitempty" bson:"rest_api,omitempty"`
+
+	// Status is reported by the workers consuming non-HTTP sources.
+	Status *SourceStatus `json:"status,omitempty" bson:"status,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at" swaggertype:"string"`
@@ -563,6 +896,49 @@ type Source struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// SourceResponse is the response sent for requests a source ingested.
+type SourceResponse struct {
+	// StatusCode is the 2xx status code of the response, it defaults to 200.
+	StatusCode int `json:"status_code,omitempty" bson:"status_code,omitempty"`
+
+	// ContentType of the body, it defaults to text/plain.
+	ContentType string `json:"content_type,omitempty" bson:"content_type,omitempty"`
+
+	// Body is a template executed with the EventID and SourceID of the
+	// ingested event, e.g. {"id": "{{ .EventID }}"}.
+	Body string `json:"body,omitempty" bson:"body,omitempty"`
+}
+
+// SourceDeduplication identifies retries of a request by a key read from
+// one of a request header or a JSON path in the body, it defaults to the
+// provider's delivery ID.
+type SourceDeduplication struct {
+	// Header is the request header holding the key, e.g. X-GitHub-Delivery.
+	Header string `json:"header,omitempty" bson:"header,omitempty"`
+
+	// JSONPath is the dot separated path of the key in the body, e.g. id.
+	JSONPath string `json:"json_path,omitempty" bson:"json_path,omitempty"`
+
+	// Window is how long in seconds requests with the same key are
+	// dropped, it defaults to config.DefaultDeduplicationWindow.
+	Window int64 `json:"window,omitempty" bson:"window,omitempty"`
+}
+
+// SourceEventType derives the event type of requests to a source from
+// one of a request header, a JSON path in the body or a template.
+type SourceEventType struct {
+	// Header is the request header holding the event type, e.g. X-GitHub-Event.
+	Header string `json:"header,omitempty" bson:"header,omitempty"`
+
+	// JSONPath is the dot separated path of the body field holding the
+	// event type, e.g. data.object.type.
+	JSONPath string `json:"json_path,omitempty" bson:"json_path,omitempty"`
+
+	// Template combines headers and body fields into the event type,
+	// e.g. {{ .Header "X-GitHub-Event" }}.{{ .JSON "action" }}.
+	Template string `json:"template,omitempty" bson:"template,omitempty"`
+}
+
 type User struct {
 	ID                     primitive.ObjectID `json:"-" bson:"_id"`
 	UID                    string             `json:"uid" bson:"uid"`
@@ -595,8 +971,98 @@ type FilterConfiguration struct {
 	EventTypes []string `json:"event_types" bson:"event_types,omitempty"`
 }
 
+// PubSubConfig is the broker a pub_sub source consumes messages from.
+type PubSubConfig struct {
+	Type PubSubType `json:"type" bson:"type"`
+
+	// Workers is the number of consumers started for the source.
+	Workers int `json:"workers" bson:"workers"`
+
+	Redis *RedisPubSubConfig `json:"redis,omitempty" bson:"redis,omitempty"`
+}
+
+// RedisPubSubConfig consumes a Redis stream through a consumer group.
+type RedisPubSubConfig struct {
+	Dsn           string `json:"dsn" bson:"dsn"`
+	Stream        string `json:"stream" bson:"stream"`
+	ConsumerGroup string `json:"consumer_group" bson:"consumer_group"`
+
+	// ClaimIdleTime is how long in seconds a message stays unacknowledged
+	// before another consumer reclaims it.
+	ClaimIdleTime int64 `json:"claim_idle_time" bson:"claim_idle_time"`
+}
+
+// DBChangeStreamConfig is the MongoDB collection a db_change_stream
+// source watches, its changes are sent as <collection>.<operationType>
+// events, e.g. users.insert.
+type DBChangeStreamConfig struct {
+	URI        string `json:"uri" bson:"uri"`
+	Database   string `json:"database" bson:"database"`
+	Collection string `json:"collection" bson:"collection"`
+
+	// Pipeline is a JSON array of aggregation stages filtering the changes,
+	// e.g. [{"$match": {"operationType": "insert"}}]. It's kept as JSON
+	// since stored field names can't start with $.
+	Pipeline string `json:"pipeline,omitempty" bson:"pipeline,omitempty"`
+}
+
+// RestApiConfig is the list API a rest_api source polls, every new item
+// it returns is sent as an event.
+type RestApiConfig struct {
+	URL string `json:"url" bson:"url"`
+
+	// Headers are sent with every request, e.g. Authorization.
+	Headers map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+
+	// Interval is the number of seconds between polls.
+	Interval int64 `json:"interval" bson:"interval"`
+
+	// ItemsPath is the dot separated path of the array of items in
+	// responses, the response itself is the array when it's empty.
+	ItemsPath string `json:"items_path,omitempty" bson:"items_path,omitempty"`
+
+	// IDPath is the path of the field identifying an item, items that
+	// were already sent are dropped.
+	IDPath string `json:"id_path" bson:"id_path"`
+
+	// CursorParam is the query parameter the cursor is sent in, e.g.
+	// starting_after or since.
+	CursorParam string `json:"cursor_param" bson:"cursor_param"`
+
+	// CursorPath is the path of the next cursor in responses, the ID of
+	// the last item of a page is the next cursor when it's empty.
+	CursorPath string `json:"cursor_path,omitempty" bson:"cursor_path,omitempty"`
+}
+
+// SourceStatus is the state of the consumers of a non-HTTP source.
+type SourceStatus struct {
+	// Lag is the number of messages not yet consumed.
+	Lag int64 `json:"lag" bson:"lag"`
+
+	// ResumeToken is the position of the last processed message of
+	// sources that resume from one, like db_change_stream sources, or the
+	// cursor rest_api sources poll from.
+	ResumeToken string `json:"resume_token,omitempty" bson:"resume_token,omitempty"`
+
+	LastPolledAt primitive.DateTime `json:"last_polled_at,omitempty" bson:"last_polled_at,omitempty" swaggertype:"string"`
+
+	// LastSuccessAt is when rest_api sources were last polled without
+	// an error, LastError is cleared then.
+	LastSuccessAt primitive.DateTime `json:"last_success_at,omitempty" bson:"last_success_at,omitempty" swaggertype:"string"`
+
+	LastError   string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	LastErrorAt primitive.DateTime `json:"last_error_at,omitempty" bson:"last_error_at,omitempty" swaggertype:"string"`
+	UpdatedAt   primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+}
+
 type ProviderConfig struct {
 	Twitter *TwitterProviderConfig `json:"twitter" bson:"twitter"`
+	Meta    *MetaProviderConfig    `json:"meta,omitempty" bson:"meta,omitempty"`
+}
+
+type MetaProviderConfig struct {
+	// VerifyToken is the token Meta sends with subscription challenges.
+	VerifyToken string `json:"verify_token" bson:"verify_token"`
 }
 
 type TwitterProviderConfig struct {
@@ -615,6 +1081,23 @@ type HMac struct {
 	Hash     string       `json:"hash" bson:"hash" valid:"supported_hash,required"`
 	Secret   string       `json:"secret" bson:"secret" valid:"required"`
 	Encoding EncodingType `json:"encoding" bson:"encoding" valid:"supported_encoding~please provide a valid encoding type,required"`
+
+	// TimestampKey and SignatureKey are set for composite signature
+	// headers like Stripe's t=...,v1=..., the header may carry
+	// several signatures under SignatureKey.
+	TimestampKey string `json:"timestamp_key,omitempty" bson:"timestamp_key,omitempty"`
+	SignatureKey string `json:"signature_key,omitempty" bson:"signature_key,omitempty"`
+
+	// TimestampHeader is set when the signature timestamp is sent in
+	// a header of its own rather than in the signature header.
+	TimestampHeader string `json:"timestamp_header,omitempty" bson:"timestamp_header,omitempty"`
+
+	// SignedPayload is the template of the signed content, e.g.
+	// {timestamp}.{payload}. It defaults to the request body.
+	SignedPayload string `json:"signed_payload,omitempty" bson:"signed_payload,omitempty"`
+
+	// Tolerance is the maximum age in seconds of a timestamped signature.
+	Tolerance int `json:"tolerance,omitempty" bson:"tolerance,omitempty"`
 }
 
 type BasicAuth struct {
@@ -655,6 +1138,11 @@ type StoragePolicyConfiguration struct {
 	Type   StorageType    `json:"type,omitempty" bson:"type" valid:"supported_storage~please provide a valid storage type,required"`
 	S3     *S3Storage     `json:"s3" bson:"s3"`
 	OnPrem *OnPremStorage `json:"on_prem" bson:"on_prem"`
+
+	// PayloadOffloadThreshold is the size in bytes above which event
+	// payloads are written to the object store instead of the database,
+	// payloads are never offloaded when it is 0.
+	PayloadOffloadThreshold int64 `json:"payload_offload_threshold,omitempty" bson:"payload_offload_threshold,omitempty"`
 }
 
 type S3Storage struct {
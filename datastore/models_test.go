@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/stretchr/testify/require"
@@ -70,3 +71,99 @@ func TestGroup_IsOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestEventPriority_Queues(t *testing.T) {
+	tt := []struct {
+		name             string
+		priority         EventPriority
+		createEventQueue convoy.QueueName
+		eventQueue       convoy.QueueName
+	}{
+		{
+			name:             "high priority",
+			priority:         HighEventPriority,
+			createEventQueue: convoy.HighPriorityCreateEventQueue,
+			eventQueue:       convoy.HighPriorityEventQueue,
+		},
+		{
+			name:             "normal priority",
+			priority:         NormalEventPriority,
+			createEventQueue: convoy.CreateEventQueue,
+			eventQueue:       convoy.EventQueue,
+		},
+		{
+			name:             "empty priority",
+			createEventQueue: convoy.CreateEventQueue,
+			eventQueue:       convoy.EventQueue,
+		},
+		{
+			name:             "low priority",
+			priority:         LowEventPriority,
+			createEventQueue: convoy.LowPriorityCreateEventQueue,
+			eventQueue:       convoy.LowPriorityEventQueue,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.True(t, tc.priority.IsValid())
+			require.Equal(t, tc.createEventQueue, tc.priority.CreateEventQueue())
+			require.Equal(t, tc.eventQueue, tc.priority.EventQueue())
+		})
+	}
+}
+
+func TestNewPaginationData(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int64
+		page     int64
+		perPage  int64
+		expected PaginationData
+	}{
+		{
+			name:     "first_page",
+			total:    25,
+			page:     1,
+			perPage:  10,
+			expected: PaginationData{Total: 25, Page: 1, PerPage: 10, Prev: 0, Next: 2, TotalPage: 3},
+		},
+		{
+			name:     "last_page",
+			total:    25,
+			page:     3,
+			perPage:  10,
+			expected: PaginationData{Total: 25, Page: 3, PerPage: 10, Prev: 2, Next: 0, TotalPage: 3},
+		},
+		{
+			name:     "past_last_page",
+			total:    25,
+			page:     5,
+			perPage:  10,
+			expected: PaginationData{Total: 25, Page: 5, PerPage: 10, Prev: 4, Next: 0, TotalPage: 3},
+		},
+		{
+			name:     "no_documents",
+			total:    0,
+			page:     1,
+			perPage:  10,
+			expected: PaginationData{Total: 0, Page: 1, PerPage: 10, Prev: 0, Next: 0, TotalPage: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, NewPaginationData(tt.total, tt.page, tt.perPage))
+		})
+	}
+}
+
+func TestPageable_PageAndLimit(t *testing.T) {
+	page, perPage := Pageable{Page: 2, PerPage: 20}.PageAndLimit()
+	require.Equal(t, int64(2), page)
+	require.Equal(t, int64(20), perPage)
+
+	page, perPage = Pageable{Page: 0, PerPage: -1}.PageAndLimit()
+	require.Equal(t, int64(1), page)
+	require.Equal(t, int64(10), perPage)
+}
@@ -134,5 +134,5 @@ func (db *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.
 		return nil, datastore.PaginationData{}, err
 	}
 
-	return apiKeys, datastore.PaginationData(paginatedData.Pagination), nil
+	return apiKeys, paginationData(paginatedData.Pagination), nil
 }
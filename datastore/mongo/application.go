@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/frain-dev/convoy/util"
 	log "github.com/sirupsen/logrus"
 
@@ -20,13 +21,15 @@ type appRepo struct {
 	innerDB *mongo.Database
 	client  *mongo.Collection
 	store   datastore.Store
+	cipher  *encryption.Cipher
 }
 
-func NewApplicationRepo(db *mongo.Database, store datastore.Store) datastore.ApplicationRepository {
+func NewApplicationRepo(db *mongo.Database, store datastore.Store, cipher *encryption.Cipher) datastore.ApplicationRepository {
 	return &appRepo{
 		innerDB: db,
 		client:  db.Collection(AppCollection, nil),
 		store:   store,
+		cipher:  cipher,
 	}
 }
 
@@ -41,7 +44,13 @@ func (db *appRepo) CreateApplication(ctx context.Context, app *datastore.Applica
 	}
 
 	app.ID = primitive.NewObjectID()
-	err = db.store.Save(ctx, app, nil)
+
+	encrypted, err := encryptApplication(db.cipher, app)
+	if err != nil {
+		return err
+	}
+
+	err = db.store.Save(ctx, encrypted, nil)
 	return err
 }
 
@@ -66,6 +75,10 @@ func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string,
 		apps = make([]datastore.Application, 0)
 	}
 
+	if err = decryptApplications(db.cipher, apps); err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
 	msgCollection := db.innerDB.Collection(EventCollection)
 	for i, app := range apps {
 		filter = bson.M{"app_id": app.UID, "document_status": datastore.ActiveDocumentStatus}
@@ -77,7 +90,7 @@ func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string,
 		apps[i].Events = count
 	}
 
-	return apps, datastore.PaginationData(paginatedData.Pagination), nil
+	return apps, paginationData(paginatedData.Pagination), nil
 }
 
 func (db *appRepo) assertUniqueAppTitle(ctx context.Context, app *datastore.Application, groupID string) error {
@@ -117,6 +130,10 @@ func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID s
 		applications = make([]datastore.Application, 0)
 	}
 
+	if err = decryptApplications(db.cipher, applications); err != nil {
+		return applications, datastore.PaginationData{}, err
+	}
+
 	msgCollection := db.innerDB.Collection(EventCollection)
 	for i, app := range applications {
 		filter = bson.M{"app_id": app.UID, "document_status": datastore.ActiveDocumentStatus}
@@ -128,7 +145,7 @@ func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID s
 		applications[i].Events = count
 	}
 
-	return applications, datastore.PaginationData(paginatedData.Pagination), nil
+	return applications, paginationData(paginatedData.Pagination), nil
 }
 
 func (db *appRepo) CountGroupApplications(ctx context.Context, groupID string) (int64, error) {
@@ -168,6 +185,10 @@ func (db *appRepo) SearchApplicationsByGroupId(ctx context.Context, groupId stri
 		return apps, err
 	}
 
+	if err = decryptApplications(db.cipher, apps); err != nil {
+		return apps, err
+	}
+
 	msgCollection := db.innerDB.Collection(EventCollection)
 	for i, app := range apps {
 		filter = bson.M{"app_id": app.UID, "document_status": datastore.ActiveDocumentStatus}
@@ -193,6 +214,14 @@ func (db *appRepo) FindApplicationByID(ctx context.Context,
 		return app, err
 	}
 
+	if err != nil {
+		return app, err
+	}
+
+	if err = decryptApplication(db.cipher, app); err != nil {
+		return app, err
+	}
+
 	msgCollection := db.innerDB.Collection(EventCollection)
 	filter := bson.M{"app_id": app.UID, "document_status": datastore.ActiveDocumentStatus}
 	count, err := msgCollection.CountDocuments(ctx, filter)
@@ -215,6 +244,23 @@ func (db *appRepo) FindApplicationEndpointByID(ctx context.Context, appID string
 	return findEndpoint(&app.Endpoints, endpointID)
 }
 
+func (db *appRepo) FindApplicationByEndpointVerificationToken(ctx context.Context, token string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	filter := bson.M{"endpoints.verification_token": token, "document_status": datastore.ActiveDocumentStatus}
+
+	err := db.store.FindOne(ctx, filter, nil, app)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return app, datastore.ErrEndpointNotFound
+	}
+
+	if err != nil {
+		return app, err
+	}
+
+	return app, decryptApplication(db.cipher, app)
+}
+
 func findEndpoint(endpoints *[]datastore.Endpoint, id string) (*datastore.Endpoint, error) {
 	for _, endpoint := range *endpoints {
 		if endpoint.UID == id && endpoint.DeletedAt == 0 {
@@ -236,8 +282,13 @@ func (db *appRepo) UpdateApplication(ctx context.Context, app *datastore.Applica
 
 	app.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
+	endpoints, err := encryptEndpoints(db.cipher, app.Endpoints)
+	if err != nil {
+		return err
+	}
+
 	update := bson.M{
-		"endpoints":     app.Endpoints,
+		"endpoints":     endpoints,
 		"updated_at":    app.UpdatedAt,
 		"title":         app.Title,
 		"support_email": app.SupportEmail,
@@ -248,17 +299,22 @@ func (db *appRepo) UpdateApplication(ctx context.Context, app *datastore.Applica
 }
 
 func (db *appRepo) CreateApplicationEndpoint(ctx context.Context, groupID string, appID string, endpoint *datastore.Endpoint) error {
+	encrypted, err := encryptEndpoint(db.cipher, endpoint)
+	if err != nil {
+		return err
+	}
+
 	filter := bson.M{"uid": appID, "document_status": datastore.ActiveDocumentStatus}
 	update := bson.M{
 		"$push": bson.M{
-			"endpoints": endpoint,
+			"endpoints": encrypted,
 		},
 		"$set": bson.M{
 			"updated_at": primitive.NewDateTimeFromTime(time.Now()),
 		},
 	}
 
-	_, err := db.client.UpdateOne(ctx, filter, update)
+	_, err = db.client.UpdateOne(ctx, filter, update)
 	return err
 }
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +19,7 @@ func Test_UpdateApplication(t *testing.T) {
 	defer closeFn()
 
 	groupRepo := NewGroupRepo(db, getStore(db, GroupCollection))
-	appRepo := NewApplicationRepo(db, getStore(db, AppCollection))
+	appRepo := NewApplicationRepo(db, getStore(db, AppCollection), encryption.NewCipher(nil))
 
 	newGroup := &datastore.Group{
 		Name: "Random new group",
@@ -62,7 +63,7 @@ func Test_CreateApplication(t *testing.T) {
 	defer closeFn()
 
 	groupRepo := NewGroupRepo(db, getStore(db, GroupCollection))
-	appRepo := NewApplicationRepo(db, getStore(db, AppCollection))
+	appRepo := NewApplicationRepo(db, getStore(db, AppCollection), encryption.NewCipher(nil))
 
 	newOrg := &datastore.Group{
 		Name: "Random new group 2",
@@ -95,7 +96,7 @@ func Test_LoadApplicationsPaged(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
 
-	appRepo := NewApplicationRepo(db, getStore(db, AppCollection))
+	appRepo := NewApplicationRepo(db, getStore(db, AppCollection), encryption.NewCipher(nil))
 
 	apps, _, err := appRepo.LoadApplicationsPaged(context.Background(), "", "", datastore.Pageable{
 		Page:    1,
@@ -110,7 +111,7 @@ func Test_FindApplicationByID(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
 
-	appRepo := NewApplicationRepo(db, getStore(db, AppCollection))
+	appRepo := NewApplicationRepo(db, getStore(db, AppCollection), encryption.NewCipher(nil))
 
 	_, err := appRepo.FindApplicationByID(context.Background(), uuid.New().String())
 	require.Error(t, err)
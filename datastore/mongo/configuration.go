@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -15,20 +16,27 @@ type configRepo struct {
 	innerDB *mongo.Database
 	client  *mongo.Collection
 	store   datastore.Store
+	cipher  *encryption.Cipher
 }
 
-func NewConfigRepo(db *mongo.Database, store datastore.Store) datastore.ConfigurationRepository {
+func NewConfigRepo(db *mongo.Database, store datastore.Store, cipher *encryption.Cipher) datastore.ConfigurationRepository {
 	return &configRepo{
 		innerDB: db,
 		client:  db.Collection(ConfigCollection),
 		store:   store,
+		cipher:  cipher,
 	}
 }
 
 func (c *configRepo) CreateConfiguration(ctx context.Context, config *datastore.Configuration) error {
 	config.ID = primitive.NewObjectID()
 
-	err := c.store.Save(ctx, config, nil)
+	encrypted, err := encryptConfiguration(c.cipher, config)
+	if err != nil {
+		return err
+	}
+
+	err = c.store.Save(ctx, encrypted, nil)
 	return err
 }
 
@@ -43,19 +51,28 @@ func (c *configRepo) LoadConfiguration(ctx context.Context) (*datastore.Configur
 		return nil, datastore.ErrConfigNotFound
 	}
 
-	return config, err
+	if err != nil {
+		return config, err
+	}
+
+	return config, transformSecrets(configurationSecrets(config), c.cipher.Decrypt)
 }
 
 func (c *configRepo) UpdateConfiguration(ctx context.Context, config *datastore.Configuration) error {
 	filter := bson.M{"uid": config.UID}
 
+	policy, err := encryptStoragePolicy(c.cipher, config.StoragePolicy)
+	if err != nil {
+		return err
+	}
+
 	update := bson.D{
 		primitive.E{Key: "is_analytics_enabled", Value: config.IsAnalyticsEnabled},
 		primitive.E{Key: "is_signup_enabled", Value: config.IsSignupEnabled},
-		primitive.E{Key: "storage_policy", Value: config.StoragePolicy},
+		primitive.E{Key: "storage_policy", Value: policy},
 		primitive.E{Key: "updated_at", Value: primitive.NewDateTimeFromTime(time.Now())},
 	}
 
-	err := c.store.UpdateOne(ctx, filter, update)
+	err = c.store.UpdateOne(ctx, filter, update)
 	return err
 }
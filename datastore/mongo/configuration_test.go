@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +19,7 @@ func Test_CreateConfiguration(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, ConfigCollection)
-	configRepo := NewConfigRepo(db, store)
+	configRepo := NewConfigRepo(db, store, encryption.NewCipher(nil))
 	config := generateConfig()
 
 	require.NoError(t, configRepo.CreateConfiguration(context.Background(), config))
@@ -35,7 +36,7 @@ func Test_LoadConfiguration(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, ConfigCollection)
-	configRepo := NewConfigRepo(db, store)
+	configRepo := NewConfigRepo(db, store, encryption.NewCipher(nil))
 	config := generateConfig()
 
 	_, err := configRepo.LoadConfiguration(context.Background())
@@ -56,7 +57,7 @@ func Test_UpdateConfiguration(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, ConfigCollection)
-	configRepo := NewConfigRepo(db, store)
+	configRepo := NewConfigRepo(db, store, encryption.NewCipher(nil))
 	config := generateConfig()
 
 	require.NoError(t, configRepo.CreateConfiguration(context.Background(), config))
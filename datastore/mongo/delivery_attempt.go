@@ -0,0 +1,99 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type deliveryAttemptRepo struct {
+	inner *mongo.Collection
+	store datastore.Store
+}
+
+func NewDeliveryAttemptRepo(db *mongo.Database, store datastore.Store) datastore.DeliveryAttemptRepository {
+	return &deliveryAttemptRepo{
+		inner: db.Collection(DeliveryAttemptCollection),
+		store: store,
+	}
+}
+
+func (db *deliveryAttemptRepo) CreateDeliveryAttempt(ctx context.Context, attempt *datastore.DeliveryAttempt) error {
+	if attempt.ID.IsZero() {
+		attempt.ID = primitive.NewObjectID()
+	}
+
+	return db.store.Save(ctx, attempt, nil)
+}
+
+func (db *deliveryAttemptRepo) FindDeliveryAttemptByID(ctx context.Context, eventDeliveryID string, id string) (*datastore.DeliveryAttempt, error) {
+	attempt := &datastore.DeliveryAttempt{}
+
+	filter := bson.M{"msg_id": eventDeliveryID, "uid": id}
+
+	err := db.store.FindOne(ctx, filter, nil, attempt)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrEventDeliveryAttemptNotFound
+	}
+
+	return attempt, err
+}
+
+func (db *deliveryAttemptRepo) LoadDeliveryAttemptsPaged(ctx context.Context, filter *datastore.DeliveryAttemptFilter, pageable datastore.Pageable) ([]datastore.DeliveryAttempt, datastore.PaginationData, error) {
+	f := bson.M{"document_status": datastore.ActiveDocumentStatus}
+
+	if !util.IsStringEmpty(filter.GroupID) {
+		f["group_id"] = filter.GroupID
+	}
+
+	if !util.IsStringEmpty(filter.EventDeliveryID) {
+		f["msg_id"] = filter.EventDeliveryID
+	}
+
+	if !util.IsStringEmpty(filter.AppID) {
+		f["app_id"] = filter.AppID
+	}
+
+	if !util.IsStringEmpty(filter.EndpointID) {
+		f["endpoint_id"] = filter.EndpointID
+	}
+
+	if filter.CreatedAtEnd > 0 {
+		f["created_at"] = getCreatedDateFilter(datastore.SearchParams{
+			CreatedAtStart: filter.CreatedAtStart,
+			CreatedAtEnd:   filter.CreatedAtEnd,
+		})
+	}
+
+	attempts := make([]datastore.DeliveryAttempt, 0)
+	paginationData, err := cursorPaged(ctx, db.inner, f, pageable, &attempts)
+	if err != nil {
+		return attempts, datastore.PaginationData{}, err
+	}
+
+	return attempts, paginationData, nil
+}
+
+func (db *deliveryAttemptRepo) DeleteGroupDeliveryAttempts(ctx context.Context, filter *datastore.DeliveryAttemptFilter, hardDelete bool) error {
+	update := bson.M{
+		"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+		"document_status": datastore.DeletedDocumentStatus,
+	}
+
+	f := bson.M{
+		"group_id":        filter.GroupID,
+		"document_status": datastore.ActiveDocumentStatus,
+		"created_at": bson.M{
+			"$gte": primitive.NewDateTimeFromTime(time.Unix(filter.CreatedAtStart, 0)),
+			"$lte": primitive.NewDateTimeFromTime(time.Unix(filter.CreatedAtEnd, 0)),
+		},
+	}
+
+	return db.store.DeleteMany(ctx, f, update, hardDelete)
+}
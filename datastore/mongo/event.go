@@ -163,6 +163,46 @@ func (db *eventRepo) FindEventByID(ctx context.Context, id string) (*datastore.E
 	return m, err
 }
 
+// FindEventByIdempotencyKey returns an event of the source created since
+// the given time with the idempotency key.
+func (db *eventRepo) FindEventByIdempotencyKey(ctx context.Context, sourceID string, key string, since time.Time) (*datastore.Event, error) {
+	m := new(datastore.Event)
+
+	filter := bson.M{
+		"source_id":       sourceID,
+		"idempotency_key": key,
+		"created_at":      bson.M{"$gte": primitive.NewDateTimeFromTime(since)},
+	}
+
+	err := db.store.FindOne(ctx, filter, nil, m)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrEventNotFound
+	}
+
+	return m, err
+}
+
+func (db *eventRepo) IncrementDuplicateCount(ctx context.Context, id string) error {
+	filter := bson.M{"uid": id}
+
+	err := db.store.Inc(ctx, filter, bson.M{"duplicate_count": 1})
+	return err
+}
+
+// UpdateEventPayload stores the data, the raw body and their references
+// of event.
+func (db *eventRepo) UpdateEventPayload(ctx context.Context, event *datastore.Event) error {
+	filter := bson.M{"uid": event.UID}
+	update := bson.M{
+		"data":     event.Data,
+		"raw":      event.Raw,
+		"data_ref": event.DataRef,
+		"raw_ref":  event.RawRef,
+	}
+
+	return db.store.UpdateOne(ctx, filter, update)
+}
+
 func (db *eventRepo) FindEventsByIDs(ctx context.Context, ids []string) ([]datastore.Event, error) {
 	m := make([]datastore.Event, 0)
 
@@ -192,17 +232,58 @@ func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId
 			"created_at": getCreatedDateFilter(searchParams)}
 	}
 
-	var messages []datastore.Event
-	paginatedData, err := pager.New(db.inner).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", pageable.Sort).Filter(filter).Decode(&messages).Find()
+	messages := make([]datastore.Event, 0)
+	paginationData, err := cursorPaged(ctx, db.inner, filter, pageable, &messages)
 	if err != nil {
 		return messages, datastore.PaginationData{}, err
 	}
 
-	if messages == nil {
-		messages = make([]datastore.Event, 0)
+	return messages, paginationData, nil
+}
+
+func (db *eventRepo) LoadEventsByFilter(ctx context.Context, filter *datastore.EventFilter, pageable datastore.Pageable) ([]datastore.Event, error) {
+	var events []datastore.Event
+	_, err := pager.New(db.inner).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", pageable.Sort).Sort("_id", pageable.Sort).Filter(getEventFilter(filter)).Decode(&events).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = make([]datastore.Event, 0)
+	}
+
+	return events, nil
+}
+
+func (db *eventRepo) CountEvents(ctx context.Context, filter *datastore.EventFilter) (int64, error) {
+	count, err := db.store.Count(ctx, getEventFilter(filter))
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in group %s", filter.GroupID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func getEventFilter(filter *datastore.EventFilter) bson.M {
+	f := bson.M{
+		"group_id":        filter.GroupID,
+		"app_id":          filter.AppID,
+		"source_id":       filter.SourceID,
+		"document_status": datastore.ActiveDocumentStatus,
+		"created_at": getCreatedDateFilter(datastore.SearchParams{
+			CreatedAtStart: filter.CreatedAtStart,
+			CreatedAtEnd:   filter.CreatedAtEnd,
+		}),
+	}
+
+	removeUnusedFields(f)
+
+	if len(filter.EventTypes) > 0 {
+		f["event_type"] = bson.M{"$in": filter.EventTypes}
 	}
 
-	return messages, datastore.PaginationData(paginatedData.Pagination), nil
+	return f
 }
 
 func getCreatedDateFilter(searchParams datastore.SearchParams) bson.M {
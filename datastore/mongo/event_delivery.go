@@ -7,7 +7,6 @@ import (
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/util"
-	pager "github.com/gobeam/mongo-go-pagination"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
@@ -119,6 +118,30 @@ func (db *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context,
 	return deliveries, nil
 }
 
+// FindEventDeliveriesBySubscriptionID returns up to limit deliveries of a
+// subscription with the given status, oldest first.
+func (db *eventDeliveryRepo) FindEventDeliveriesBySubscriptionID(ctx context.Context, groupID, subscriptionID string, status datastore.EventDeliveryStatus, limit int) ([]datastore.EventDelivery, error) {
+	filter := bson.M{
+		"group_id":        groupID,
+		"subscription_id": subscriptionID,
+		"status":          status,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	sort := bson.D{
+		{Key: "created_at", Value: 1},
+		{Key: "_id", Value: 1},
+	}
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.FindMany(ctx, filter, nil, sort, int64(limit), 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
 func (db *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context,
 	status datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
 
@@ -179,8 +202,7 @@ func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context,
 	return nil
 }
 
-func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
-	e datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
+func (db *eventDeliveryRepo) UpdateEventDelivery(ctx context.Context, e datastore.EventDelivery) error {
 
 	filter := bson.M{"uid": e.UID}
 	update := bson.M{
@@ -190,9 +212,6 @@ func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
 			"metadata":    e.Metadata,
 			"updated_at":  primitive.NewDateTimeFromTime(time.Now()),
 		},
-		"$push": bson.M{
-			"attempts": attempt,
-		},
 	}
 
 	_, err := db.inner.UpdateOne(ctx, filter, update)
@@ -207,17 +226,13 @@ func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
 func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
 	filter := getFilter(groupID, appID, eventID, status, searchParams)
 
-	var eventDeliveries []datastore.EventDelivery
-	paginatedData, err := pager.New(db.inner).Context(ctx).Limit(int64(pageable.PerPage)).Page(int64(pageable.Page)).Sort("created_at", pageable.Sort).Filter(filter).Decode(&eventDeliveries).Find()
+	eventDeliveries := make([]datastore.EventDelivery, 0)
+	paginationData, err := cursorPaged(ctx, db.inner, filter, pageable, &eventDeliveries)
 	if err != nil {
 		return eventDeliveries, datastore.PaginationData{}, err
 	}
 
-	if eventDeliveries == nil {
-		eventDeliveries = make([]datastore.EventDelivery, 0)
-	}
-
-	return eventDeliveries, datastore.PaginationData(paginatedData.Pagination), nil
+	return eventDeliveries, paginationData, nil
 }
 
 func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
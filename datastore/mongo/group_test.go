@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -194,7 +195,7 @@ func Test_FillGroupsStatistics(t *testing.T) {
 		GroupID: group2.UID,
 	}
 
-	appRepo := NewApplicationRepo(db, getStore(db, AppCollection))
+	appRepo := NewApplicationRepo(db, getStore(db, AppCollection), encryption.NewCipher(nil))
 	err = appRepo.CreateApplication(context.Background(), app1, group1.UID)
 	require.NoError(t, err)
 
@@ -0,0 +1,327 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
+	"github.com/frain-dev/convoy/internal/pkg/migrate"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migrationLockID is the id of the document held in the lock collection
+// while migrations run.
+const migrationLockID = "migrations"
+
+// Migrations returns the migrations of db in the order they must run,
+// new migrations are appended with the next version. cipher encrypts the
+// secrets stored in db.
+func Migrations(db *mongo.Database, cipher *encryption.Cipher) []migrate.Migration {
+	return []migrate.Migration{
+		{
+			Version:     1,
+			Description: "set the default rate limit of groups",
+			Up:          func(ctx context.Context) error { return setGroupRateLimits(ctx, db) },
+		},
+		{
+			Version:     2,
+			Description: "limit api keys to a single group and app",
+			Up:          func(ctx context.Context) error { return convertAPIKeyRoles(ctx, db) },
+		},
+		{
+			Version:     3,
+			Description: "move delivery attempts to their own collection",
+			Up:          func(ctx context.Context) error { return moveDeliveryAttempts(ctx, db) },
+			Down:        func(ctx context.Context) error { return restoreDeliveryAttempts(ctx, db) },
+		},
+		{
+			Version:     4,
+			Description: "encrypt the secrets stored in plaintext",
+			Up:          func(ctx context.Context) error { return encryptSecrets(ctx, db, cipher) },
+			Down:        func(ctx context.Context) error { return decryptSecrets(ctx, db, cipher) },
+		},
+	}
+}
+
+type migrationStore struct {
+	migrations *mongo.Collection
+	locks      *mongo.Collection
+}
+
+// NewMigrationStore returns the store recording the migrations applied
+// to db.
+func NewMigrationStore(db *mongo.Database) migrate.Store {
+	return &migrationStore{
+		migrations: db.Collection(MigrationCollection),
+		locks:      db.Collection(MigrationLockCollection),
+	}
+}
+
+func (s *migrationStore) Lock(ctx context.Context, owner string) (*migrate.Lock, error) {
+	lock := &migrate.Lock{Owner: owner, LockedAt: time.Now()}
+
+	_, err := s.locks.InsertOne(ctx, bson.M{"_id": migrationLockID, "owner": lock.Owner, "locked_at": lock.LockedAt})
+	if mongo.IsDuplicateKeyError(err) {
+		held := &migrate.Lock{}
+		if err = s.locks.FindOne(ctx, bson.M{"_id": migrationLockID}).Decode(held); err != nil {
+			return nil, err
+		}
+
+		return held, migrate.ErrLocked
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+func (s *migrationStore) Unlock(ctx context.Context) error {
+	_, err := s.locks.DeleteOne(ctx, bson.M{"_id": migrationLockID})
+	return err
+}
+
+func (s *migrationStore) Applied(ctx context.Context) ([]migrate.Record, error) {
+	cursor, err := s.migrations.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]migrate.Record, 0)
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (s *migrationStore) Insert(ctx context.Context, record migrate.Record) error {
+	_, err := s.migrations.InsertOne(ctx, record)
+	return err
+}
+
+func (s *migrationStore) Delete(ctx context.Context, version int) error {
+	_, err := s.migrations.DeleteOne(ctx, bson.M{"_id": version})
+	return err
+}
+
+// setGroupRateLimits sets the default rate limit of the groups created
+// before group rate limits were added.
+func setGroupRateLimits(ctx context.Context, db *mongo.Database) error {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"rate_limit": bson.M{"$exists": false}},
+		bson.M{"rate_limit": 0},
+	}}
+
+	update := bson.M{"$set": bson.M{
+		"rate_limit":          5000,
+		"rate_limit_duration": "1m",
+		"updated_at":          primitive.NewDateTimeFromTime(time.Now()),
+	}}
+
+	_, err := db.Collection(GroupCollection).UpdateMany(ctx, filter, update)
+	return err
+}
+
+// convertAPIKeyRoles converts the roles of api keys from lists of groups
+// and apps to a single group and app, keys with an app become app portal
+// keys.
+func convertAPIKeyRoles(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection(APIKeyCollection)
+
+	rename := bson.M{"$rename": bson.M{"role.groups": "role.group", "role.apps": "role.app"}}
+	_, err := collection.UpdateMany(ctx, bson.M{"role.groups": bson.M{"$exists": true}}, rename)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"role.group": bson.M{"$type": "array"}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var apiKey struct {
+			UID  string `bson:"uid"`
+			Role struct {
+				Type  auth.RoleType `bson:"type"`
+				Group []string      `bson:"group"`
+				App   []string      `bson:"app"`
+			} `bson:"role"`
+		}
+
+		if err = cursor.Decode(&apiKey); err != nil {
+			return err
+		}
+
+		role := auth.Role{Type: apiKey.Role.Type}
+		if len(apiKey.Role.Group) > 0 {
+			role.Group = apiKey.Role.Group[0]
+		}
+
+		keyType := datastore.ProjectKey
+		if len(apiKey.Role.Group) > 0 && len(apiKey.Role.App) > 0 {
+			keyType = datastore.AppPortalKey
+			role.App = apiKey.Role.App[0]
+		}
+
+		update := bson.M{"$set": bson.M{
+			"role":       role,
+			"key_type":   keyType,
+			"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+		}}
+
+		if _, err = collection.UpdateOne(ctx, bson.M{"uid": apiKey.UID}, update); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// moveDeliveryAttempts moves the attempts embedded in the event deliveries
+// to the delivery attempts collection, attempts that were already moved
+// are skipped.
+func moveDeliveryAttempts(ctx context.Context, db *mongo.Database) error {
+	deliveries := db.Collection(EventDeliveryCollection)
+	attempts := db.Collection(DeliveryAttemptCollection)
+
+	filter := bson.M{"attempts": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"uid": 1, "group_id": 1, "app_id": 1, "attempts": 1})
+
+	cursor, err := deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var moved int64
+	for cursor.Next(ctx) {
+		var delivery struct {
+			UID      string                      `bson:"uid"`
+			GroupID  string                      `bson:"group_id"`
+			AppID    string                      `bson:"app_id"`
+			Attempts []datastore.DeliveryAttempt `bson:"attempts"`
+		}
+
+		if err = cursor.Decode(&delivery); err != nil {
+			return err
+		}
+
+		for i := range delivery.Attempts {
+			attempt := &delivery.Attempts[i]
+			if attempt.ID.IsZero() {
+				attempt.ID = primitive.NewObjectID()
+			}
+
+			attempt.GroupID = delivery.GroupID
+			attempt.AppID = delivery.AppID
+			attempt.MsgID = delivery.UID
+			attempt.DocumentStatus = datastore.ActiveDocumentStatus
+
+			_, err = attempts.UpdateOne(ctx,
+				bson.M{"_id": attempt.ID},
+				bson.M{"$setOnInsert": attempt},
+				options.Update().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = deliveries.UpdateOne(ctx, bson.M{"uid": delivery.UID}, bson.M{"$unset": bson.M{"attempts": ""}})
+		if err != nil {
+			return err
+		}
+
+		moved++
+		if moved%1000 == 0 {
+			log.Infof("moved the attempts of %d event deliveries", moved)
+		}
+	}
+
+	return cursor.Err()
+}
+
+// restoreDeliveryAttempts embeds the delivery attempts back in their
+// event deliveries and empties the delivery attempts collection.
+func restoreDeliveryAttempts(ctx context.Context, db *mongo.Database) error {
+	deliveries := db.Collection(EventDeliveryCollection)
+	attempts := db.Collection(DeliveryAttemptCollection)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$msg_id", "attempts": bson.M{"$push": "$$ROOT"}}}},
+	}
+
+	cursor, err := attempts.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var group struct {
+			MsgID    string   `bson:"_id"`
+			Attempts []bson.M `bson:"attempts"`
+		}
+
+		if err = cursor.Decode(&group); err != nil {
+			return err
+		}
+
+		_, err = deliveries.UpdateOne(ctx, bson.M{"uid": group.MsgID}, bson.M{"$set": bson.M{"attempts": group.Attempts}})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = cursor.Err(); err != nil {
+		return err
+	}
+
+	_, err = attempts.DeleteMany(ctx, bson.M{})
+	return err
+}
+
+// encryptSecrets encrypts the secrets stored before encryption was
+// enabled, it skips when no key provider is configured so the migration
+// stays pending until one is.
+func encryptSecrets(ctx context.Context, db *mongo.Database, cipher *encryption.Cipher) error {
+	if !cipher.Enabled() {
+		log.Warn("no encryption key provider is configured, configure one and run the migrations again to encrypt the secrets")
+		return migrate.Skip(encryption.ErrNoKeyProvider)
+	}
+
+	updated, err := rewriteSecrets(ctx, db, func(value string) (string, bool, error) {
+		encrypted, err := cipher.Encrypt(value)
+		return encrypted, encrypted != value, err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("encrypted the secrets of %d documents", updated)
+	return nil
+}
+
+// decryptSecrets stores the secrets of db in plaintext again.
+func decryptSecrets(ctx context.Context, db *mongo.Database, cipher *encryption.Cipher) error {
+	updated, err := rewriteSecrets(ctx, db, func(value string) (string, bool, error) {
+		decrypted, err := cipher.Decrypt(value)
+		return decrypted, decrypted != value, err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("decrypted the secrets of %d documents", updated)
+	return nil
+}
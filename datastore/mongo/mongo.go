@@ -11,6 +11,7 @@ import (
 
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/newrelic/go-agent/v3/integrations/nrmongo"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -27,6 +28,11 @@ const (
 	SourceCollection              = "sources"
 	UserCollection                = "users"
 	SubscriptionCollection        = "subscriptions"
+	ReplayJobCollection           = "replay_jobs"
+	TestAttemptCollection         = "test_attempts"
+	DeliveryAttemptCollection     = "deliveryattempts"
+	MigrationCollection           = "migrations"
+	MigrationLockCollection       = "migrationlocks"
 )
 
 type Client struct {
@@ -43,9 +49,17 @@ type Client struct {
 	orgInviteRepo     datastore.OrganisationInviteRepository
 	userRepo          datastore.UserRepository
 	configRepo        datastore.ConfigurationRepository
+	replayJobRepo     datastore.ReplayJobRepository
+	testAttemptRepo   datastore.TestAttemptRepository
+	attemptRepo       datastore.DeliveryAttemptRepository
 }
 
 func New(cfg config.Configuration) (*Client, error) {
+	cipher, err := encryption.New(cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	opts := options.Client()
@@ -83,21 +97,27 @@ func New(cfg config.Configuration) (*Client, error) {
 	users := datastore.New(conn, UserCollection)
 	config := datastore.New(conn, ConfigCollection)
 	event_delivery := datastore.New(conn, EventDeliveryCollection)
+	replay_jobs := datastore.New(conn, ReplayJobCollection)
+	test_attempts := datastore.New(conn, TestAttemptCollection)
+	delivery_attempts := datastore.New(conn, DeliveryAttemptCollection)
 
 	c := &Client{
 		db:                conn,
 		apiKeyRepo:        NewApiKeyRepo(conn),
 		groupRepo:         NewGroupRepo(conn, groups),
-		applicationRepo:   NewApplicationRepo(conn, apps),
+		applicationRepo:   NewApplicationRepo(conn, apps, cipher),
 		subscriptionRepo:  NewSubscriptionRepo(conn, subscriptions),
 		eventRepo:         NewEventRepository(conn, events),
 		eventDeliveryRepo: NewEventDeliveryRepository(conn, event_delivery),
-		sourceRepo:        NewSourceRepo(conn, sources),
+		sourceRepo:        NewSourceRepo(conn, sources, cipher),
 		orgRepo:           NewOrgRepo(conn, orgs),
 		orgMemberRepo:     NewOrgMemberRepo(conn, org_member),
 		orgInviteRepo:     NewOrgInviteRepo(conn, org_invite),
 		userRepo:          NewUserRepo(conn, users),
-		configRepo:        NewConfigRepo(conn, config),
+		configRepo:        NewConfigRepo(conn, config, cipher),
+		replayJobRepo:     NewReplayJobRepo(conn, replay_jobs),
+		testAttemptRepo:   NewTestAttemptRepo(conn, test_attempts),
+		attemptRepo:       NewDeliveryAttemptRepo(conn, delivery_attempts),
 	}
 
 	c.ensureMongoIndices()
@@ -165,6 +185,18 @@ func (c *Client) ConfigurationRepo() datastore.ConfigurationRepository {
 	return c.configRepo
 }
 
+func (c *Client) ReplayJobRepo() datastore.ReplayJobRepository {
+	return c.replayJobRepo
+}
+
+func (c *Client) TestAttemptRepo() datastore.TestAttemptRepository {
+	return c.testAttemptRepo
+}
+
+func (c *Client) DeliveryAttemptRepo() datastore.DeliveryAttemptRepository {
+	return c.attemptRepo
+}
+
 func (c *Client) ensureMongoIndices() {
 	c.ensureIndex(GroupCollection, "uid", true, nil)
 
@@ -180,6 +212,7 @@ func (c *Client) ensureMongoIndices() {
 	c.ensureIndex(AppCollection, "group_id", false, nil)
 	c.ensureIndex(UserCollection, "uid", true, nil)
 	c.ensureIndex(AppCollection, "uid", true, nil)
+	c.ensureIndex(AppCollection, "endpoints.verification_token", false, nil)
 
 	c.ensureIndex(EventCollection, "uid", true, nil)
 	c.ensureIndex(EventCollection, "app_id", false, nil)
@@ -190,6 +223,10 @@ func (c *Client) ensureMongoIndices() {
 	c.ensureIndex(SourceCollection, "mask_id", true, nil)
 	c.ensureIndex(SubscriptionCollection, "uid", true, nil)
 	c.ensureIndex(SubscriptionCollection, "filter_config.event_type", false, nil)
+	c.ensureIndex(ReplayJobCollection, "uid", true, nil)
+	c.ensureIndex(ReplayJobCollection, "group_id", false, nil)
+	c.ensureIndex(TestAttemptCollection, "uid", true, nil)
+	c.ensureIndex(DeliveryAttemptCollection, "uid", true, nil)
 	c.ensureCompoundIndex(AppCollection)
 	c.ensureCompoundIndex(EventCollection)
 	c.ensureCompoundIndex(UserCollection)
@@ -197,6 +234,8 @@ func (c *Client) ensureMongoIndices() {
 	c.ensureCompoundIndex(EventDeliveryCollection)
 	c.ensureCompoundIndex(OrganisationInvitesCollection)
 	c.ensureCompoundIndex(OrganisationMembersCollection)
+	c.ensureCompoundIndex(TestAttemptCollection)
+	c.ensureCompoundIndex(DeliveryAttemptCollection)
 }
 
 // ensureIndex - ensures an index is created for a specific field in a collection
@@ -263,6 +302,15 @@ func compoundIndices() map[string][]mongo.IndexModel {
 			},
 		},
 		EventCollection: {
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+
 			{
 				Keys: bson.D{
 					{Key: "group_id", Value: 1},
@@ -326,9 +374,46 @@ func compoundIndices() map[string][]mongo.IndexModel {
 					{Key: "created_at", Value: -1},
 				},
 			},
+
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "event_type", Value: 1},
+					{Key: "created_at", Value: 1},
+				},
+			},
+
+			{
+				Keys: bson.D{
+					{Key: "source_id", Value: 1},
+					{Key: "idempotency_key", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+				Options: options.Index().SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+			},
 		},
 
 		EventDeliveryCollection: {
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "subscription_id", Value: 1},
+					{Key: "status", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: 1},
+				},
+			},
+
 			{
 				Keys: bson.D{
 					{Key: "event_id", Value: 1},
@@ -463,6 +548,47 @@ func compoundIndices() map[string][]mongo.IndexModel {
 				Options: options.Index().SetUnique(true),
 			},
 		},
+
+		DeliveryAttemptCollection: {
+			{
+				Keys: bson.D{
+					{Key: "msg_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "endpoint_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: -1},
+					{Key: "_id", Value: -1},
+				},
+			},
+
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+			},
+		},
+
+		TestAttemptCollection: {
+			{
+				Keys: bson.D{
+					{Key: "group_id", Value: 1},
+					{Key: "app_id", Value: 1},
+					{Key: "endpoint_id", Value: 1},
+					{Key: "document_status", Value: 1},
+					{Key: "created_at", Value: -1},
+				},
+			},
+		},
 	}
 
 	return compoundIndices
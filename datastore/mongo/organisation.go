@@ -35,7 +35,7 @@ func (db *orgRepo) LoadOrganisationsPaged(ctx context.Context, pageable datastor
 		return organisations, datastore.PaginationData{}, err
 	}
 
-	return organisations, datastore.PaginationData(paginatedData.Pagination), nil
+	return organisations, paginationData(paginatedData.Pagination), nil
 }
 
 func (db *orgRepo) CreateOrganisation(ctx context.Context, org *datastore.Organisation) error {
@@ -45,7 +45,7 @@ func (db *orgInviteRepo) LoadOrganisationsInvitesPaged(ctx context.Context, orgI
 		return organisations, datastore.PaginationData{}, err
 	}
 
-	return organisations, datastore.PaginationData(paginatedData.Pagination), nil
+	return organisations, paginationData(paginatedData.Pagination), nil
 }
 
 func (db *orgInviteRepo) CreateOrganisationInvite(ctx context.Context, iv *datastore.OrganisationInvite) error {
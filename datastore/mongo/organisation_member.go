@@ -54,7 +54,7 @@ func (o *orgMemberRepo) LoadOrganisationMembersPaged(ctx context.Context, organi
 		return members, datastore.PaginationData{}, err
 	}
 
-	return members, datastore.PaginationData(paginatedData.Pagination), nil
+	return members, paginationData(paginatedData.Pagination), nil
 }
 
 func (o *orgMemberRepo) LoadUserOrganisationsPaged(ctx context.Context, userID string, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
@@ -0,0 +1,70 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func paginationData(p pager.PaginationData) datastore.PaginationData {
+	return datastore.PaginationData{
+		Total:     p.Total,
+		Page:      p.Page,
+		PerPage:   p.PerPage,
+		Prev:      p.Prev,
+		Next:      p.Next,
+		TotalPage: p.TotalPage,
+	}
+}
+
+// cursorPaged loads the page of the documents matching filter pageable
+// refers to, ordered by created_at and _id so that it can be paged with
+// cursors.
+func cursorPaged(ctx context.Context, collection *mongo.Collection, filter bson.M, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	q, err := pageable.CursorPage()
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	var total int64
+	if q.CountTotal {
+		total, err = collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return datastore.PaginationData{}, err
+		}
+	}
+
+	if q.After != nil {
+		op := "$lt"
+		if q.Sort > 0 {
+			op = "$gt"
+		}
+
+		filter = bson.M{"$and": bson.A{filter, bson.M{"$or": bson.A{
+			bson.M{"created_at": bson.M{op: q.After.CreatedAt}},
+			bson.M{"created_at": q.After.CreatedAt, "_id": bson.M{op: q.After.ID}},
+		}}}}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: q.Sort}, {Key: "_id", Value: q.Sort}}).
+		SetLimit(q.Limit)
+	if q.Skip > 0 {
+		opts.SetSkip(q.Skip)
+	}
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	if err = cursor.All(ctx, out); err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	return q.Paginate(out, total)
+}
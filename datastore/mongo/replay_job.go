@@ -0,0 +1,90 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type replayJobRepo struct {
+	client *mongo.Collection
+	store  datastore.Store
+}
+
+func NewReplayJobRepo(db *mongo.Database, store datastore.Store) datastore.ReplayJobRepository {
+	return &replayJobRepo{
+		client: db.Collection(ReplayJobCollection),
+		store:  store,
+	}
+}
+
+func (r *replayJobRepo) CreateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	job.ID = primitive.NewObjectID()
+	return r.store.Save(ctx, job, nil)
+}
+
+func (r *replayJobRepo) UpdateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	job.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	filter := bson.M{
+		"uid":             job.UID,
+		"group_id":        job.GroupID,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	update := bson.M{
+		"status":           job.Status,
+		"total_events":     job.TotalEvents,
+		"processed_events": job.ProcessedEvents,
+		"failed_events":    job.FailedEvents,
+		"error":            job.Error,
+		"started_at":       job.StartedAt,
+		"completed_at":     job.CompletedAt,
+		"updated_at":       job.UpdatedAt,
+	}
+
+	return r.store.UpdateOne(ctx, filter, update)
+}
+
+func (r *replayJobRepo) FindReplayJobByID(ctx context.Context, groupID string, id string) (*datastore.ReplayJob, error) {
+	job := &datastore.ReplayJob{}
+
+	filter := bson.M{"uid": id, "group_id": groupID}
+
+	err := r.store.FindOne(ctx, filter, nil, job)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return job, datastore.ErrReplayJobNotFound
+	}
+
+	return job, err
+}
+
+func (r *replayJobRepo) LoadReplayJobsPaged(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.ReplayJob, datastore.PaginationData, error) {
+	filter := bson.M{"group_id": groupID, "document_status": datastore.ActiveDocumentStatus}
+
+	var jobs []datastore.ReplayJob
+	paginatedData, err := pager.
+		New(r.client).
+		Context(ctx).
+		Limit(int64(pageable.PerPage)).
+		Page(int64(pageable.Page)).
+		Sort("created_at", -1).
+		Filter(filter).
+		Decode(&jobs).
+		Find()
+	if err != nil {
+		return jobs, datastore.PaginationData{}, err
+	}
+
+	if jobs == nil {
+		jobs = make([]datastore.ReplayJob, 0)
+	}
+
+	return jobs, paginationData(paginatedData.Pagination), nil
+}
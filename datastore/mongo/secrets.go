@@ -0,0 +1,403 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The repositories encrypt the secrets of the documents they write and
+// decrypt the documents they read, the documents passed to them are never
+// modified so callers keep the plaintext secrets. The secrets of a
+// document are keyed by their path in the document.
+
+func endpointSecrets(e *datastore.Endpoint) map[string]*string {
+	return map[string]*string{"secret": &e.Secret}
+}
+
+func sourceSecrets(s *datastore.Source) map[string]*string {
+	secrets := map[string]*string{}
+	if s.Verifier != nil {
+		if s.Verifier.HMac != nil {
+			secrets["verifier.hmac.secret"] = &s.Verifier.HMac.Secret
+		}
+
+		if s.Verifier.BasicAuth != nil {
+			secrets["verifier.basic_auth.password"] = &s.Verifier.BasicAuth.Password
+		}
+
+		if s.Verifier.ApiKey != nil {
+			secrets["verifier.api_key.header_value"] = &s.Verifier.ApiKey.HeaderValue
+		}
+	}
+
+	if s.PubSub != nil && s.PubSub.Redis != nil {
+		secrets["pub_sub.redis.dsn"] = &s.PubSub.Redis.Dsn
+	}
+
+	if s.DBChangeStream != nil {
+		secrets["db_change_stream.uri"] = &s.DBChangeStream.URI
+	}
+
+	// The values of a map aren't addressable, so the headers are copied
+	// here and written back by transformSourceSecrets.
+	if s.RestApi != nil {
+		for name, value := range s.RestApi.Headers {
+			value := value
+			secrets["rest_api.headers."+name] = &value
+		}
+	}
+
+	return secrets
+}
+
+func configurationSecrets(c *datastore.Configuration) map[string]*string {
+	if c.StoragePolicy == nil || c.StoragePolicy.S3 == nil {
+		return map[string]*string{}
+	}
+
+	s3 := c.StoragePolicy.S3
+	return map[string]*string{
+		"storage_policy.s3.access_key":    &s3.AccessKey,
+		"storage_policy.s3.secret_key":    &s3.SecretKey,
+		"storage_policy.s3.session_token": &s3.SessionToken,
+	}
+}
+
+func transformSecrets(secrets map[string]*string, fn func(string) (string, error)) error {
+	for _, secret := range secrets {
+		value, err := fn(*secret)
+		if err != nil {
+			return err
+		}
+
+		*secret = value
+	}
+
+	return nil
+}
+
+func encryptEndpoints(cipher *encryption.Cipher, endpoints []datastore.Endpoint) ([]datastore.Endpoint, error) {
+	if endpoints == nil {
+		return nil, nil
+	}
+
+	encrypted := make([]datastore.Endpoint, len(endpoints))
+	for i := range endpoints {
+		encrypted[i] = endpoints[i]
+		if err := transformSecrets(endpointSecrets(&encrypted[i]), cipher.Encrypt); err != nil {
+			return nil, err
+		}
+	}
+
+	return encrypted, nil
+}
+
+func encryptEndpoint(cipher *encryption.Cipher, endpoint *datastore.Endpoint) (*datastore.Endpoint, error) {
+	encrypted := *endpoint
+	if err := transformSecrets(endpointSecrets(&encrypted), cipher.Encrypt); err != nil {
+		return nil, err
+	}
+
+	return &encrypted, nil
+}
+
+func encryptApplication(cipher *encryption.Cipher, app *datastore.Application) (*datastore.Application, error) {
+	endpoints, err := encryptEndpoints(cipher, app.Endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := *app
+	encrypted.Endpoints = endpoints
+	return &encrypted, nil
+}
+
+func decryptApplication(cipher *encryption.Cipher, app *datastore.Application) error {
+	for i := range app.Endpoints {
+		if err := transformSecrets(endpointSecrets(&app.Endpoints[i]), cipher.Decrypt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decryptApplications(cipher *encryption.Cipher, apps []datastore.Application) error {
+	for i := range apps {
+		if err := decryptApplication(cipher, &apps[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// transformSourceSecrets replaces the secrets of s with the values
+// returned by fn.
+func transformSourceSecrets(s *datastore.Source, fn func(string) (string, error)) error {
+	secrets := sourceSecrets(s)
+	if err := transformSecrets(secrets, fn); err != nil {
+		return err
+	}
+
+	if s.RestApi != nil {
+		for name := range s.RestApi.Headers {
+			s.RestApi.Headers[name] = *secrets["rest_api.headers."+name]
+		}
+	}
+
+	return nil
+}
+
+func encryptSource(cipher *encryption.Cipher, source *datastore.Source) (*datastore.Source, error) {
+	encrypted := *source
+	if source.Verifier != nil {
+		verifier := *source.Verifier
+		if verifier.HMac != nil {
+			hmac := *verifier.HMac
+			verifier.HMac = &hmac
+		}
+
+		if verifier.BasicAuth != nil {
+			basicAuth := *verifier.BasicAuth
+			verifier.BasicAuth = &basicAuth
+		}
+
+		if verifier.ApiKey != nil {
+			apiKey := *verifier.ApiKey
+			verifier.ApiKey = &apiKey
+		}
+
+		encrypted.Verifier = &verifier
+	}
+
+	if source.PubSub != nil {
+		pubSub := *source.PubSub
+		if pubSub.Redis != nil {
+			redis := *pubSub.Redis
+			pubSub.Redis = &redis
+		}
+
+		encrypted.PubSub = &pubSub
+	}
+
+	if source.DBChangeStream != nil {
+		changeStream := *source.DBChangeStream
+		encrypted.DBChangeStream = &changeStream
+	}
+
+	if source.RestApi != nil {
+		restApi := *source.RestApi
+		if restApi.Headers != nil {
+			restApi.Headers = make(map[string]string, len(source.RestApi.Headers))
+			for name, value := range source.RestApi.Headers {
+				restApi.Headers[name] = value
+			}
+		}
+
+		encrypted.RestApi = &restApi
+	}
+
+	if err := transformSourceSecrets(&encrypted, cipher.Encrypt); err != nil {
+		return nil, err
+	}
+
+	return &encrypted, nil
+}
+
+func decryptSources(cipher *encryption.Cipher, sources []datastore.Source) error {
+	for i := range sources {
+		if err := transformSourceSecrets(&sources[i], cipher.Decrypt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encryptStoragePolicy(cipher *encryption.Cipher, policy *datastore.StoragePolicyConfiguration) (*datastore.StoragePolicyConfiguration, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	encrypted := *policy
+	if policy.S3 != nil {
+		s3 := *policy.S3
+		encrypted.S3 = &s3
+	}
+
+	config := &datastore.Configuration{StoragePolicy: &encrypted}
+	if err := transformSecrets(configurationSecrets(config), cipher.Encrypt); err != nil {
+		return nil, err
+	}
+
+	return &encrypted, nil
+}
+
+func encryptConfiguration(cipher *encryption.Cipher, config *datastore.Configuration) (*datastore.Configuration, error) {
+	policy, err := encryptStoragePolicy(cipher, config.StoragePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := *config
+	encrypted.StoragePolicy = policy
+	return &encrypted, nil
+}
+
+// RotateSecrets encrypts the secrets stored in db again with the primary
+// key of cipher, secrets stored in plaintext are encrypted too. It returns
+// the number of documents that were updated.
+func RotateSecrets(ctx context.Context, db *mongo.Database, cipher *encryption.Cipher) (int64, error) {
+	if !cipher.Enabled() {
+		return 0, encryption.ErrNoKeyProvider
+	}
+
+	return rewriteSecrets(ctx, db, cipher.Rotate)
+}
+
+// sourceSecretsFilter matches the sources that may hold secrets.
+var sourceSecretsFilter = bson.M{"$or": bson.A{
+	bson.M{"verifier": bson.M{"$ne": nil}},
+	bson.M{"pub_sub.redis": bson.M{"$ne": nil}},
+	bson.M{"db_change_stream": bson.M{"$ne": nil}},
+	bson.M{"rest_api.headers": bson.M{"$ne": nil}},
+}}
+
+// rewriteSecrets replaces the secrets stored in db with the values
+// returned by fn, fn reports whether it changed a secret.
+func rewriteSecrets(ctx context.Context, db *mongo.Database, fn func(string) (string, bool, error)) (int64, error) {
+	apps, err := rewriteEndpointSecrets(ctx, db.Collection(AppCollection), fn)
+	if err != nil {
+		return apps, err
+	}
+
+	sources, err := rewriteDocumentSecrets(ctx, db.Collection(SourceCollection), sourceSecretsFilter,
+		func(cursor *mongo.Cursor) (string, map[string]*string, error) {
+			source := &datastore.Source{}
+			if err := cursor.Decode(source); err != nil {
+				return "", nil, err
+			}
+
+			return source.UID, sourceSecrets(source), nil
+		}, fn)
+	if err != nil {
+		return apps + sources, err
+	}
+
+	configs, err := rewriteDocumentSecrets(ctx, db.Collection(ConfigCollection), bson.M{"storage_policy.s3": bson.M{"$ne": nil}},
+		func(cursor *mongo.Cursor) (string, map[string]*string, error) {
+			config := &datastore.Configuration{}
+			if err := cursor.Decode(config); err != nil {
+				return "", nil, err
+			}
+
+			return config.UID, configurationSecrets(config), nil
+		}, fn)
+
+	return apps + sources + configs, err
+}
+
+// rewriteEndpointSecrets rewrites the secrets of the endpoints of the
+// applications in collection, an endpoint is only updated when its secret
+// wasn't changed since it was read.
+func rewriteEndpointSecrets(ctx context.Context, collection *mongo.Collection, fn func(string) (string, bool, error)) (int64, error) {
+	filter := bson.M{"endpoints.0": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"uid": 1, "endpoints": 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	for cursor.Next(ctx) {
+		app := &datastore.Application{}
+		if err = cursor.Decode(app); err != nil {
+			return updated, err
+		}
+
+		changed := false
+		for _, endpoint := range app.Endpoints {
+			secret, ok, err := fn(endpoint.Secret)
+			if err != nil {
+				return updated, fmt.Errorf("failed to rewrite the secret of endpoint %s: %v", endpoint.UID, err)
+			}
+
+			if !ok {
+				continue
+			}
+
+			filter := bson.M{
+				"uid":       app.UID,
+				"endpoints": bson.M{"$elemMatch": bson.M{"uid": endpoint.UID, "secret": endpoint.Secret}},
+			}
+
+			update := bson.M{"$set": bson.M{"endpoints.$.secret": secret}}
+			if _, err = collection.UpdateOne(ctx, filter, update); err != nil {
+				return updated, err
+			}
+
+			changed = true
+		}
+
+		if changed {
+			updated++
+		}
+	}
+
+	return updated, cursor.Err()
+}
+
+// rewriteDocumentSecrets rewrites the secrets of the documents in
+// collection matching filter, decode returns the uid and the secrets of a
+// document.
+func rewriteDocumentSecrets(ctx context.Context, collection *mongo.Collection, filter bson.M,
+	decode func(*mongo.Cursor) (string, map[string]*string, error), fn func(string) (string, bool, error)) (int64, error) {
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var updated int64
+	for cursor.Next(ctx) {
+		uid, secrets, err := decode(cursor)
+		if err != nil {
+			return updated, err
+		}
+
+		filter := bson.M{"uid": uid}
+		set := bson.M{}
+		for path, secret := range secrets {
+			value, ok, err := fn(*secret)
+			if err != nil {
+				return updated, fmt.Errorf("failed to rewrite %s of %s: %v", path, uid, err)
+			}
+
+			if ok {
+				filter[path] = *secret
+				set[path] = value
+			}
+		}
+
+		if len(set) == 0 {
+			continue
+		}
+
+		if _, err = collection.UpdateOne(ctx, filter, bson.M{"$set": set}); err != nil {
+			return updated, err
+		}
+
+		updated++
+	}
+
+	return updated, cursor.Err()
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/frain-dev/convoy/util"
 	pager "github.com/gobeam/mongo-go-pagination"
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,36 +18,61 @@ type sourceRepo struct {
 	innerDB *mongo.Database
 	client  *mongo.Collection
 	store   datastore.Store
+	cipher  *encryption.Cipher
 }
 
-func NewSourceRepo(db *mongo.Database, store datastore.Store) datastore.SourceRepository {
+func NewSourceRepo(db *mongo.Database, store datastore.Store, cipher *encryption.Cipher) datastore.SourceRepository {
 	return &sourceRepo{
 		innerDB: db,
 		client:  db.Collection(SourceCollection),
 		store:   store,
+		cipher:  cipher,
 	}
 }
 
 func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source) error {
 	source.ID = primitive.NewObjectID()
 
-	err := s.store.Save(ctx, source, nil)
+	encrypted, err := encryptSource(s.cipher, source)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.Save(ctx, encrypted, nil)
 	return err
 }
 
 func (s *sourceRepo) UpdateSource(ctx context.Context, groupId string, source *datastore.Source) error {
 	filter := bson.M{"uid": source.UID, "group_id": groupId, "document_status": datastore.ActiveDocumentStatus}
 
+	encrypted, err := encryptSource(s.cipher, source)
+	if err != nil {
+		return err
+	}
+
 	update := bson.D{
 		primitive.E{Key: "name", Value: source.Name},
 		primitive.E{Key: "type", Value: source.Type},
 		primitive.E{Key: "is_disabled", Value: source.IsDisabled},
-		primitive.E{Key: "verifier", Value: source.Verifier},
+		primitive.E{Key: "verifier", Value: encrypted.Verifier},
 		primitive.E{Key: "updated_at", Value: primitive.NewDateTimeFromTime(time.Now())},
 		primitive.E{Key: "provider_config", Value: source.ProviderConfig},
+		primitive.E{Key: "forward_headers", Value: source.ForwardHeaders},
+		primitive.E{Key: "ip_allowlist", Value: source.IPAllowlist},
+		primitive.E{Key: "event_type", Value: source.EventType},
+		primitive.E{Key: "response", Value: source.Response},
+		primitive.E{Key: "max_body_size", Value: source.MaxBodySize},
+		primitive.E{Key: "deduplication", Value: source.Deduplication},
+		primitive.E{Key: "priority", Value: source.Priority},
+		primitive.E{Key: "rate_limit", Value: source.RateLimit},
+		primitive.E{Key: "rate_limit_duration", Value: source.RateLimitDuration},
+		primitive.E{Key: "pub_sub", Value: encrypted.PubSub},
+		primitive.E{Key: "db_change_stream", Value: encrypted.DBChangeStream},
+		primitive.E{Key: "rest_api", Value: encrypted.RestApi},
+		primitive.E{Key: "last_handshake_at", Value: source.LastHandshakeAt},
 	}
 
-	err := s.store.UpdateOne(ctx, filter, update)
+	err = s.store.UpdateOne(ctx, filter, update)
 	return err
 }
 
@@ -60,7 +86,11 @@ func (s *sourceRepo) FindSourceByID(ctx context.Context, groupId string, id stri
 		return source, datastore.ErrSourceNotFound
 	}
 
-	return source, err
+	if err != nil {
+		return source, err
+	}
+
+	return source, transformSourceSecrets(source, s.cipher.Decrypt)
 }
 
 func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskId string) (*datastore.Source, error) {
@@ -73,7 +103,11 @@ func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskId string) (*da
 		return source, datastore.ErrSourceNotFound
 	}
 
-	return source, nil
+	if err != nil {
+		return source, err
+	}
+
+	return source, transformSourceSecrets(source, s.cipher.Decrypt)
 }
 
 func (s *sourceRepo) DeleteSourceByID(ctx context.Context, groupId string, id string) error {
@@ -88,6 +122,31 @@ func (s *sourceRepo) DeleteSourceByID(ctx context.Context, groupId string, id st
 	return err
 }
 
+func (s *sourceRepo) UpdateSourceStatus(ctx context.Context, groupId string, id string, status *datastore.SourceStatus) error {
+	filter := bson.M{"uid": id, "group_id": groupId}
+
+	err := s.store.UpdateOne(ctx, filter, bson.M{"status": status})
+	return err
+}
+
+func (s *sourceRepo) IncrementRejectedRequests(ctx context.Context, groupId string, id string) error {
+	filter := bson.M{"uid": id, "group_id": groupId}
+
+	err := s.store.Inc(ctx, filter, bson.M{"rejected_requests": 1})
+	return err
+}
+
+func (s *sourceRepo) UpdateHandshakeNotifiedAt(ctx context.Context, groupId string, id string, notifiedAt time.Time) error {
+	filter := bson.M{"uid": id, "group_id": groupId}
+
+	update := bson.D{
+		primitive.E{Key: "handshake_notified_at", Value: primitive.NewDateTimeFromTime(notifiedAt)},
+	}
+
+	err := s.store.UpdateOne(ctx, filter, update)
+	return err
+}
+
 func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, groupID string, f *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
 	var sources []datastore.Source
 
@@ -104,7 +163,11 @@ func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, groupID string, f *da
 		sources = make([]datastore.Source, 0)
 	}
 
-	return sources, datastore.PaginationData(paginatedData.Pagination), nil
+	if err = decryptSources(s.cipher, sources); err != nil {
+		return sources, datastore.PaginationData{}, err
+	}
+
+	return sources, paginationData(paginatedData.Pagination), nil
 }
 
 func removeUnusedFields(filter map[string]interface{}) {
@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/dchest/uniuri"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/encryption"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -19,7 +21,7 @@ func Test_CreateSource(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, SourceCollection)
-	sourceRepo := NewSourceRepo(db, store)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
 	source := generateSource(t)
 
 	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
@@ -38,7 +40,7 @@ func Test_FindSourceByID(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, SourceCollection)
-	sourceRepo := NewSourceRepo(db, store)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
 	source := generateSource(t)
 
 	_, err := sourceRepo.FindSourceByID(context.Background(), source.GroupID, source.UID)
@@ -59,7 +61,7 @@ func Test_FindSourceByMaskID(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, SourceCollection)
-	sourceRepo := NewSourceRepo(db, store)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
 	source := generateSource(t)
 
 	_, err := sourceRepo.FindSourceByMaskID(context.Background(), source.MaskID)
@@ -80,13 +82,15 @@ func Test_UpdateSource(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, SourceCollection)
-	sourceRepo := NewSourceRepo(db, store)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
 	source := generateSource(t)
 
 	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
 
 	name := "Convoy-Dev"
 	source.Name = name
+	source.IPAllowlist = []string{"192.30.252.0/22"}
+	source.ForwardHeaders = []string{"X-GitHub-*"}
 
 	require.NoError(t, sourceRepo.UpdateSource(context.Background(), source.GroupID, source))
 
@@ -94,6 +98,46 @@ func Test_UpdateSource(t *testing.T) {
 	require.NoError(t, err)
 
 	require.Equal(t, name, newSource.Name)
+	require.Equal(t, source.IPAllowlist, newSource.IPAllowlist)
+	require.Equal(t, source.ForwardHeaders, newSource.ForwardHeaders)
+}
+
+func Test_IncrementRejectedRequests(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	store := getStore(db, SourceCollection)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
+	source := generateSource(t)
+
+	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
+
+	require.NoError(t, sourceRepo.IncrementRejectedRequests(context.Background(), source.GroupID, source.UID))
+	require.NoError(t, sourceRepo.IncrementRejectedRequests(context.Background(), source.GroupID, source.UID))
+
+	newSource, err := sourceRepo.FindSourceByID(context.Background(), source.GroupID, source.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(2), newSource.RejectedRequests)
+}
+
+func Test_UpdateHandshakeNotifiedAt(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	store := getStore(db, SourceCollection)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
+	source := generateSource(t)
+
+	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
+
+	notifiedAt := time.Now()
+	require.NoError(t, sourceRepo.UpdateHandshakeNotifiedAt(context.Background(), source.GroupID, source.UID, notifiedAt))
+
+	newSource, err := sourceRepo.FindSourceByID(context.Background(), source.GroupID, source.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, notifiedAt.Unix(), newSource.HandshakeNotifiedAt.Time().Unix())
 }
 
 func Test_DeleteSource(t *testing.T) {
@@ -101,7 +145,7 @@ func Test_DeleteSource(t *testing.T) {
 	defer closeFn()
 
 	store := getStore(db, SourceCollection)
-	sourceRepo := NewSourceRepo(db, store)
+	sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
 	source := generateSource(t)
 
 	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
@@ -183,7 +227,7 @@ func Test_LoadSourcesPaged(t *testing.T) {
 			defer closeFn()
 
 			store := getStore(db, SourceCollection)
-			sourceRepo := NewSourceRepo(db, store)
+			sourceRepo := NewSourceRepo(db, store, encryption.NewCipher(nil))
 			groupId := uuid.NewString()
 
 			for i := 0; i < tc.count; i++ {
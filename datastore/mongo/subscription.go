@@ -58,6 +58,8 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, groupId strin
 		"retry_config.type":        string(subscription.RetryConfig.Type),
 		"retry_config.duration":    subscription.RetryConfig.Duration,
 		"retry_config.retry_count": subscription.RetryConfig.RetryCount,
+
+		"forward_raw_body": subscription.ForwardRawBody,
 	}
 
 	err := s.store.UpdateOne(ctx, filter, update)
@@ -82,7 +84,7 @@ func (s *subscriptionRepo) LoadSubscriptionsPaged(ctx context.Context, groupId s
 		return nil, datastore.PaginationData{}, err
 	}
 
-	return subscriptions, datastore.PaginationData(paginatedData.Pagination), nil
+	return subscriptions, paginationData(paginatedData.Pagination), nil
 }
 
 func (s *subscriptionRepo) DeleteSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
@@ -0,0 +1,57 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/datastore"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type testAttemptRepo struct {
+	client *mongo.Collection
+	store  datastore.Store
+}
+
+func NewTestAttemptRepo(db *mongo.Database, store datastore.Store) datastore.TestAttemptRepository {
+	return &testAttemptRepo{
+		client: db.Collection(TestAttemptCollection),
+		store:  store,
+	}
+}
+
+func (t *testAttemptRepo) CreateTestAttempt(ctx context.Context, attempt *datastore.TestAttempt) error {
+	attempt.ID = primitive.NewObjectID()
+	return t.store.Save(ctx, attempt, nil)
+}
+
+func (t *testAttemptRepo) LoadTestAttemptsPaged(ctx context.Context, groupID string, appID string, endpointID string, pageable datastore.Pageable) ([]datastore.TestAttempt, datastore.PaginationData, error) {
+	filter := bson.M{
+		"group_id":        groupID,
+		"app_id":          appID,
+		"endpoint_id":     endpointID,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	var attempts []datastore.TestAttempt
+	paginatedData, err := pager.
+		New(t.client).
+		Context(ctx).
+		Limit(int64(pageable.PerPage)).
+		Page(int64(pageable.Page)).
+		Sort("created_at", -1).
+		Filter(filter).
+		Decode(&attempts).
+		Find()
+	if err != nil {
+		return attempts, datastore.PaginationData{}, err
+	}
+
+	if attempts == nil {
+		attempts = make([]datastore.TestAttempt, 0)
+	}
+
+	return attempts, paginationData(paginatedData.Pagination), nil
+}
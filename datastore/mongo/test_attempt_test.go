@@ -0,0 +1,52 @@
+//go:build integration
+// +build integration
+
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadTestAttemptsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	store := getStore(db, TestAttemptCollection)
+	testAttemptRepo := NewTestAttemptRepo(db, store)
+
+	groupID := uuid.NewString()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, testAttemptRepo.CreateTestAttempt(context.Background(), generateTestAttempt(groupID, "endpoint-1")))
+	}
+	require.NoError(t, testAttemptRepo.CreateTestAttempt(context.Background(), generateTestAttempt(groupID, "endpoint-2")))
+
+	attempts, pageable, err := testAttemptRepo.LoadTestAttemptsPaged(context.Background(), groupID, "app-1", "endpoint-1", datastore.Pageable{Page: 1, PerPage: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(attempts))
+	require.Equal(t, int64(3), pageable.Total)
+	require.Equal(t, "endpoint-1", attempts[0].EndpointID)
+}
+
+func generateTestAttempt(groupID string, endpointID string) *datastore.TestAttempt {
+	return &datastore.TestAttempt{
+		UID:        uuid.NewString(),
+		GroupID:    groupID,
+		AppID:      "app-1",
+		EndpointID: endpointID,
+		Payload:    []byte(`{"event_type":"convoy.test"}`),
+		Attempt: datastore.DeliveryAttempt{
+			UID:              uuid.NewString(),
+			EndpointID:       endpointID,
+			HttpResponseCode: "200 OK",
+			Status:           true,
+		},
+		Duration:       12,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+}
@@ -80,7 +80,7 @@ func (u *userRepo) LoadUsersPaged(ctx context.Context, pageable datastore.Pageab
 		users = make([]datastore.User, 0)
 	}
 
-	return users, datastore.PaginationData(paginatedData.Pagination), nil
+	return users, paginationData(paginatedData.Pagination), nil
 }
 
 func (u *userRepo) UpdateUser(ctx context.Context, user *datastore.User) error {
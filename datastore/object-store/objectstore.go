@@ -1,7 +1,21 @@
 package objectstore
 
+import "errors"
+
+var ErrObjectNotFound = errors.New("object not found")
+
 type ObjectStore interface {
 	Save(string) error
+
+	// Put writes data under key, replacing the object stored under it.
+	Put(key string, data []byte) error
+
+	// Get returns the data stored under key or ErrObjectNotFound.
+	Get(key string) ([]byte, error)
+
+	// Delete removes the object stored under key, deleting a missing
+	// object isn't an error.
+	Delete(key string) error
 }
 
 type ObjectStoreOptions struct {
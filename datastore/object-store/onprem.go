@@ -1,7 +1,10 @@
 package objectstore
 
 import (
+	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -25,3 +28,30 @@ func (o *OnPremClient) Save(filename string) error {
 	log.Printf("Successfully saved %q \n", filename)
 	return nil
 }
+
+func (o *OnPremClient) Put(key string, data []byte) error {
+	filename := filepath.Join(o.opts.OnPremStorageDir, key)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filename, data, 0o644)
+}
+
+func (o *OnPremClient) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(o.opts.OnPremStorageDir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+
+	return data, err
+}
+
+func (o *OnPremClient) Delete(key string) error {
+	err := os.Remove(filepath.Join(o.opts.OnPremStorageDir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
@@ -1,11 +1,15 @@
 package objectstore
 
 import (
+	"bytes"
+	"errors"
 	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	log "github.com/sirupsen/logrus"
 )
@@ -56,3 +60,53 @@ func (s3 *S3Client) Save(filename string) error {
 	log.Printf("Successfully saved %q to %q\n", filename, s3.opts.Bucket)
 	return nil
 }
+
+func (s3 *S3Client) Put(key string, data []byte) error {
+	uploader := s3manager.NewUploader(s3.session)
+	_, err := uploader.Upload(&s3manager.UploadInput{
+		Bucket: aws.String(s3.opts.Bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		log.WithError(err).Errorf("Unable to save %q to %q", key, s3.opts.Bucket)
+		return err
+	}
+
+	return nil
+}
+
+func (s3 *S3Client) Get(key string) ([]byte, error) {
+	buf := aws.NewWriteAtBuffer([]byte{})
+
+	downloader := s3manager.NewDownloader(s3.session)
+	_, err := downloader.Download(buf, &awss3.GetObjectInput{
+		Bucket: aws.String(s3.opts.Bucket),
+		Key:    aws.String(key),
+	})
+
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == awss3.ErrCodeNoSuchKey {
+		return nil, ErrObjectNotFound
+	}
+
+	if err != nil {
+		log.WithError(err).Errorf("Unable to load %q from %q", key, s3.opts.Bucket)
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s3 *S3Client) Delete(key string) error {
+	_, err := awss3.New(s3.session).DeleteObject(&awss3.DeleteObjectInput{
+		Bucket: aws.String(s3.opts.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		log.WithError(err).Errorf("Unable to delete %q from %q", key, s3.opts.Bucket)
+		return err
+	}
+
+	return nil
+}
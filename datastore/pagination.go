@@ -0,0 +1,225 @@
+package datastore
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor is the position of a document in a list ordered by creation time,
+// documents created at the same time are ordered by their id.
+type Cursor struct {
+	CreatedAt primitive.DateTime
+	ID        primitive.ObjectID
+}
+
+// String returns the opaque form of the cursor returned to clients.
+func (c Cursor) String() string {
+	b := make([]byte, 8+len(c.ID))
+	binary.BigEndian.PutUint64(b, uint64(c.CreatedAt))
+	copy(b[8:], c.ID[:])
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Before reports whether c comes before o in ascending order.
+func (c Cursor) Before(o Cursor) bool {
+	if c.CreatedAt != o.CreatedAt {
+		return c.CreatedAt < o.CreatedAt
+	}
+
+	for i := range c.ID {
+		if c.ID[i] != o.ID[i] {
+			return c.ID[i] < o.ID[i]
+		}
+	}
+
+	return false
+}
+
+// ParseCursor parses the opaque form of a cursor.
+func ParseCursor(s string) (Cursor, error) {
+	var c Cursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != 8+len(c.ID) {
+		return c, ErrInvalidCursor
+	}
+
+	c.CreatedAt = primitive.DateTime(binary.BigEndian.Uint64(b))
+	copy(c.ID[:], b[8:])
+
+	return c, nil
+}
+
+func (e Event) Cursor() Cursor {
+	return Cursor{CreatedAt: e.CreatedAt, ID: e.ID}
+}
+
+func (d EventDelivery) Cursor() Cursor {
+	return Cursor{CreatedAt: d.CreatedAt, ID: d.ID}
+}
+
+func (a DeliveryAttempt) Cursor() Cursor {
+	return Cursor{CreatedAt: a.CreatedAt, ID: a.ID}
+}
+
+type cursorer interface {
+	Cursor() Cursor
+}
+
+// CursorPage is the query of a page of documents ordered by their cursor,
+// it is built from a Pageable either for a page number or for the page
+// after or before a cursor.
+type CursorPage struct {
+	// Sort is the order the documents are loaded in, 1 for ascending and
+	// -1 for descending.
+	Sort int
+
+	// After, when set, restricts the documents to the ones after it in
+	// Sort order.
+	After *Cursor
+
+	Skip int64
+
+	// Limit is one more than the page size so that backends tell whether
+	// there is a next page without counting the documents.
+	Limit int64
+
+	// CountTotal is set when the matching documents must be counted, the
+	// count ignores After.
+	CountTotal bool
+
+	page     int64
+	perPage  int64
+	backward bool
+}
+
+// CursorPage returns the query of the page p refers to, it returns
+// ErrInvalidCursor when a cursor can't be parsed or both are set.
+func (p Pageable) CursorPage() (CursorPage, error) {
+	page, perPage := p.PageAndLimit()
+
+	q := CursorPage{
+		Sort:       -1,
+		Limit:      perPage + 1,
+		CountTotal: !p.SkipTotal,
+		perPage:    perPage,
+	}
+
+	if p.Sort > 0 {
+		q.Sort = 1
+	}
+
+	switch {
+	case p.NextCursor != "" && p.PrevCursor != "":
+		return q, ErrInvalidCursor
+	case p.NextCursor != "":
+		c, err := ParseCursor(p.NextCursor)
+		if err != nil {
+			return q, err
+		}
+		q.After = &c
+	case p.PrevCursor != "":
+		c, err := ParseCursor(p.PrevCursor)
+		if err != nil {
+			return q, err
+		}
+
+		// the previous page is loaded in the reverse order and
+		// reversed by Paginate.
+		q.After = &c
+		q.Sort = -q.Sort
+		q.backward = true
+	default:
+		q.page = page
+		q.Skip = (page - 1) * perPage
+	}
+
+	return q, nil
+}
+
+// Paginate trims the documents loaded for the query in out, a pointer to a
+// slice of events, event deliveries or delivery attempts, to the page size
+// and returns the pagination data of the page. total is ignored unless CountTotal is set.
+func (q CursorPage) Paginate(out interface{}, total int64) (PaginationData, error) {
+	if !IsValidPointer(out) {
+		return PaginationData{}, ErrInvalidPtr
+	}
+
+	slice := reflect.ValueOf(out).Elem()
+	if slice.Kind() != reflect.Slice {
+		return PaginationData{}, ErrInvalidPtr
+	}
+
+	hasMore := int64(slice.Len()) > q.perPage
+	if hasMore {
+		slice.Set(slice.Slice(0, int(q.perPage)))
+	}
+
+	n := slice.Len()
+	if q.backward {
+		swap := reflect.Swapper(slice.Interface())
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	cursor := func(i int) (string, error) {
+		c, ok := slice.Index(i).Interface().(cursorer)
+		if !ok {
+			return "", ErrInvalidPtr
+		}
+
+		return c.Cursor().String(), nil
+	}
+
+	data := PaginationData{PerPage: q.perPage}
+	if q.page > 0 {
+		if q.CountTotal {
+			data = NewPaginationData(total, q.page, q.perPage)
+		} else {
+			data.Page = q.page
+			if q.page > 1 {
+				data.Prev = q.page - 1
+			}
+
+			if hasMore {
+				data.Next = q.page + 1
+			}
+		}
+	} else if q.CountTotal {
+		data.Total = total
+		data.TotalPage = int64(math.Ceil(float64(total) / float64(q.perPage)))
+	}
+
+	if n == 0 {
+		return data, nil
+	}
+
+	var err error
+	hasNext, hasPrev := hasMore, q.page > 1 || q.After != nil
+	if q.backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		if data.NextCursor, err = cursor(n - 1); err != nil {
+			return PaginationData{}, err
+		}
+	}
+
+	if hasPrev {
+		if data.PrevCursor, err = cursor(0); err != nil {
+			return PaginationData{}, err
+		}
+	}
+
+	return data, nil
+}
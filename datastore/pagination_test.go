@@ -0,0 +1,102 @@
+package datastore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCursor(t *testing.T) {
+	c := Cursor{CreatedAt: primitive.DateTime(1656000000000), ID: primitive.NewObjectID()}
+
+	parsed, err := ParseCursor(c.String())
+	require.NoError(t, err)
+	require.Equal(t, c, parsed)
+
+	for _, s := range []string{"", "not a cursor", "AAAA"} {
+		_, err = ParseCursor(s)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	}
+
+	later := Cursor{CreatedAt: c.CreatedAt + 1}
+	require.True(t, c.Before(later))
+	require.False(t, later.Before(c))
+	require.False(t, c.Before(c))
+
+	sameTime := Cursor{CreatedAt: c.CreatedAt, ID: primitive.NewObjectID()}
+	require.True(t, c.Before(sameTime))
+}
+
+func TestPageable_CursorPage(t *testing.T) {
+	cursor := Cursor{CreatedAt: primitive.DateTime(1656000000000), ID: primitive.NewObjectID()}
+
+	q, err := Pageable{Page: 3, PerPage: 10}.CursorPage()
+	require.NoError(t, err)
+	require.Equal(t, -1, q.Sort)
+	require.Nil(t, q.After)
+	require.Equal(t, int64(20), q.Skip)
+	require.Equal(t, int64(11), q.Limit)
+	require.True(t, q.CountTotal)
+
+	q, err = Pageable{PerPage: 10, Sort: 1, NextCursor: cursor.String(), SkipTotal: true}.CursorPage()
+	require.NoError(t, err)
+	require.Equal(t, 1, q.Sort)
+	require.Equal(t, cursor, *q.After)
+	require.Equal(t, int64(0), q.Skip)
+	require.False(t, q.CountTotal)
+
+	q, err = Pageable{PerPage: 10, Sort: 1, PrevCursor: cursor.String()}.CursorPage()
+	require.NoError(t, err)
+	require.Equal(t, -1, q.Sort)
+	require.Equal(t, cursor, *q.After)
+
+	_, err = Pageable{NextCursor: "invalid"}.CursorPage()
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = Pageable{NextCursor: cursor.String(), PrevCursor: cursor.String()}.CursorPage()
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestCursorPage_Paginate(t *testing.T) {
+	events := make([]Event, 4)
+	for i := range events {
+		events[i] = Event{ID: primitive.NewObjectID(), CreatedAt: primitive.DateTime(1656000000000 + int64(i))}
+	}
+
+	// the first page of three events in ascending order.
+	q, err := Pageable{Page: 1, PerPage: 3, Sort: 1}.CursorPage()
+	require.NoError(t, err)
+
+	page := append([]Event{}, events...)
+	data, err := q.Paginate(&page, 4)
+	require.NoError(t, err)
+	require.Equal(t, events[:3], page)
+	require.Equal(t, int64(2), data.Next)
+	require.Equal(t, int64(4), data.Total)
+	require.Equal(t, events[2].Cursor().String(), data.NextCursor)
+	require.Empty(t, data.PrevCursor)
+
+	// the page before the last event is loaded in descending order.
+	q, err = Pageable{PerPage: 2, Sort: 1, PrevCursor: events[3].Cursor().String(), SkipTotal: true}.CursorPage()
+	require.NoError(t, err)
+
+	page = []Event{events[2], events[1], events[0]}
+	data, err = q.Paginate(&page, 0)
+	require.NoError(t, err)
+	require.Equal(t, []Event{events[1], events[2]}, page)
+	require.Equal(t, int64(0), data.Total)
+	require.Equal(t, events[1].Cursor().String(), data.PrevCursor)
+	require.Equal(t, events[2].Cursor().String(), data.NextCursor)
+
+	// the page after the last event is empty.
+	q, err = Pageable{PerPage: 2, Sort: 1, NextCursor: events[3].Cursor().String(), SkipTotal: true}.CursorPage()
+	require.NoError(t, err)
+
+	page = []Event{}
+	data, err = q.Paginate(&page, 0)
+	require.NoError(t, err)
+	require.Empty(t, page)
+	require.Empty(t, data.NextCursor)
+	require.Empty(t, data.PrevCursor)
+}
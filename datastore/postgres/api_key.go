@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type apiKeyRepo struct {
+	store *store
+}
+
+func NewApiKeyRepo(db *sql.DB) datastore.APIKeyRepository {
+	return &apiKeyRepo{
+		store: newStore(db, APIKeyTable),
+	}
+}
+
+func (db *apiKeyRepo) CreateAPIKey(ctx context.Context, apiKey *datastore.APIKey) error {
+	apiKey.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(apiKey.UID) {
+		apiKey.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, apiKey)
+}
+
+func (db *apiKeyRepo) UpdateAPIKey(ctx context.Context, apiKey *datastore.APIKey) error {
+	doc, err := marshalDocument(apiKey)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{}
+	if err = unmarshalDocument(doc, &update); err != nil {
+		return err
+	}
+
+	return db.store.updateOne(ctx, newQuery().eq("uid", apiKey.UID), update)
+}
+
+func (db *apiKeyRepo) FindAPIKeyByID(ctx context.Context, uid string) (*datastore.APIKey, error) {
+	return db.findAPIKey(ctx, newQuery().eq("uid", uid))
+}
+
+func (db *apiKeyRepo) FindAPIKeyByMaskID(ctx context.Context, maskID string) (*datastore.APIKey, error) {
+	return db.findAPIKey(ctx, newQuery().eq("mask_id", maskID))
+}
+
+func (db *apiKeyRepo) FindAPIKeyByHash(ctx context.Context, hash string) (*datastore.APIKey, error) {
+	return db.findAPIKey(ctx, newQuery().eq("hash", hash))
+}
+
+func (db *apiKeyRepo) findAPIKey(ctx context.Context, q *query) (*datastore.APIKey, error) {
+	apiKey := &datastore.APIKey{}
+
+	err := db.store.findOne(ctx, q, apiKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrAPIKeyNotFound
+	}
+
+	return apiKey, err
+}
+
+func (db *apiKeyRepo) RevokeAPIKeys(ctx context.Context, uids []string) error {
+	return db.store.deleteMany(ctx, newQuery().in("uid", uids), false)
+}
+
+func (db *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	var apiKeys []datastore.APIKey
+
+	paginationData, err := db.store.paged(ctx, newQuery(), pageable.Sort, *pageable, &apiKeys)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return apiKeys, paginationData, nil
+}
@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type appRepo struct {
+	db     *sql.DB
+	store  *store
+	events *store
+}
+
+func NewApplicationRepo(db *sql.DB) datastore.ApplicationRepository {
+	return &appRepo{
+		db:     db,
+		store:  newStore(db, AppTable),
+		events: newStore(db, EventTable),
+	}
+}
+
+func (db *appRepo) CreateApplication(ctx context.Context, app *datastore.Application, groupID string) error {
+	err := db.assertUniqueAppTitle(ctx, app, groupID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateAppName) {
+			return err
+		}
+
+		return fmt.Errorf("failed to check if application name is unique: %v", err)
+	}
+
+	app.ID = primitive.NewObjectID()
+	return db.store.insert(ctx, app)
+}
+
+func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	filter := newQuery()
+	if !util.IsStringEmpty(groupID) {
+		filter.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(q) {
+		filter.match("title", q)
+	}
+
+	var apps []datastore.Application
+	paginationData, err := db.store.paged(ctx, filter, -1, pageable, &apps)
+	if err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	if err = db.countEvents(ctx, apps); err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	return apps, paginationData, nil
+}
+
+func (db *appRepo) assertUniqueAppTitle(ctx context.Context, app *datastore.Application, groupID string) error {
+	q := newQuery().ne("uid", app.UID).eq("title", app.Title).eq("group_id", groupID)
+
+	count, err := db.store.count(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if count != 0 {
+		return datastore.ErrDuplicateAppName
+	}
+
+	return nil
+}
+
+func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	var apps []datastore.Application
+	paginationData, err := db.store.paged(ctx, newQuery().eq("group_id", groupID), -1, pageable, &apps)
+	if err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	if err = db.countEvents(ctx, apps); err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	return apps, paginationData, nil
+}
+
+func (db *appRepo) CountGroupApplications(ctx context.Context, groupID string) (int64, error) {
+	count, err := db.store.count(ctx, newQuery().eq("group_id", groupID))
+	if err != nil {
+		log.WithError(err).Errorf("failed to count apps in group %s", groupID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *appRepo) SearchApplicationsByGroupId(ctx context.Context, groupId string, searchParams datastore.SearchParams) ([]datastore.Application, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = searchParams.CreatedAtStart
+	}
+
+	q := newQuery().eq("group_id", groupId).between("created_at", time.Unix(start, 0), time.Unix(end, 0))
+
+	apps := make([]datastore.Application, 0)
+	err := db.store.findMany(ctx, q, 1, 0, 0, &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	if err = db.countEvents(ctx, apps); err != nil {
+		return apps, err
+	}
+
+	return apps, nil
+}
+
+func (db *appRepo) FindApplicationByID(ctx context.Context, id string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	err := db.store.findOne(ctx, newQuery().eq("uid", id), app)
+	if errors.Is(err, sql.ErrNoRows) {
+		return app, datastore.ErrApplicationNotFound
+	}
+
+	if err != nil {
+		return app, err
+	}
+
+	app.Events, err = db.events.count(ctx, newQuery().eq("app_id", app.UID))
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in %s", app.UID)
+		return app, err
+	}
+
+	return app, nil
+}
+
+// countEvents sets the number of events of each app.
+func (db *appRepo) countEvents(ctx context.Context, apps []datastore.Application) error {
+	for i := range apps {
+		count, err := db.events.count(ctx, newQuery().eq("app_id", apps[i].UID))
+		if err != nil {
+			log.Errorf("failed to count events in %s. Reason: %s", apps[i].UID, err)
+			return err
+		}
+		apps[i].Events = count
+	}
+
+	return nil
+}
+
+func (db *appRepo) FindApplicationEndpointByID(ctx context.Context, appID string, endpointID string) (*datastore.Endpoint, error) {
+	app, err := db.FindApplicationByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findEndpoint(&app.Endpoints, endpointID)
+}
+
+func (db *appRepo) FindApplicationByEndpointVerificationToken(ctx context.Context, token string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	q, err := newQuery().contains("endpoints", []map[string]string{{"verification_token": token}})
+	if err != nil {
+		return app, err
+	}
+
+	err = db.store.findOne(ctx, q, app)
+	if errors.Is(err, sql.ErrNoRows) {
+		return app, datastore.ErrEndpointNotFound
+	}
+
+	return app, err
+}
+
+func findEndpoint(endpoints *[]datastore.Endpoint, id string) (*datastore.Endpoint, error) {
+	for _, endpoint := range *endpoints {
+		if endpoint.UID == id && endpoint.DeletedAt == 0 {
+			return &endpoint, nil
+		}
+	}
+	return nil, datastore.ErrEndpointNotFound
+}
+
+func (db *appRepo) UpdateApplication(ctx context.Context, app *datastore.Application, groupID string) error {
+	err := db.assertUniqueAppTitle(ctx, app, groupID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateAppName) {
+			return err
+		}
+
+		return fmt.Errorf("failed to check if application name is unique: %v", err)
+	}
+
+	app.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"endpoints":     app.Endpoints,
+		"updated_at":    app.UpdatedAt,
+		"title":         app.Title,
+		"support_email": app.SupportEmail,
+		"is_disabled":   app.IsDisabled,
+	}
+
+	return db.store.updateOne(ctx, newQuery().eq("uid", app.UID), update)
+}
+
+func (db *appRepo) CreateApplicationEndpoint(ctx context.Context, groupID string, appID string, endpoint *datastore.Endpoint) error {
+	q := newQuery().eq("uid", appID).active()
+	update := bson.M{"updated_at": primitive.NewDateTimeFromTime(time.Now())}
+
+	return db.store.push(ctx, q, "endpoints", endpoint, update)
+}
+
+func (db *appRepo) DeleteGroupApps(ctx context.Context, groupID string) error {
+	return db.store.updateMany(ctx, newQuery().eq("group_id", groupID), softDelete())
+}
+
+// DeleteApplication soft deletes the app and its events in a transaction.
+func (db *appRepo) DeleteApplication(ctx context.Context, app *datastore.Application) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = db.events.withTx(tx).deleteMany(ctx, newQuery().eq("app_id", app.UID), false)
+	if err != nil {
+		_ = tx.Rollback()
+		log.Errorf("failed to delete messages in %s. Reason: %s", app.UID, err)
+		return err
+	}
+
+	err = db.store.withTx(tx).deleteMany(ctx, newQuery().eq("uid", app.UID), false)
+	if err != nil {
+		_ = tx.Rollback()
+		log.Errorf("%s an error has occurred while deleting app - %s", app.UID, err)
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type deliveryAttemptRepo struct {
+	store *store
+}
+
+func NewDeliveryAttemptRepo(db *sql.DB) datastore.DeliveryAttemptRepository {
+	return &deliveryAttemptRepo{
+		store: newStore(db, DeliveryAttemptTable),
+	}
+}
+
+func (db *deliveryAttemptRepo) CreateDeliveryAttempt(ctx context.Context, attempt *datastore.DeliveryAttempt) error {
+	if attempt.ID.IsZero() {
+		attempt.ID = primitive.NewObjectID()
+	}
+
+	return db.store.insert(ctx, attempt)
+}
+
+func (db *deliveryAttemptRepo) FindDeliveryAttemptByID(ctx context.Context, eventDeliveryID string, id string) (*datastore.DeliveryAttempt, error) {
+	attempt := &datastore.DeliveryAttempt{}
+
+	err := db.store.findOne(ctx, newQuery().eq("msg_id", eventDeliveryID).eq("uid", id), attempt)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventDeliveryAttemptNotFound
+	}
+
+	return attempt, err
+}
+
+func (db *deliveryAttemptRepo) LoadDeliveryAttemptsPaged(ctx context.Context, filter *datastore.DeliveryAttemptFilter, pageable datastore.Pageable) ([]datastore.DeliveryAttempt, datastore.PaginationData, error) {
+	q := newQuery()
+
+	if !util.IsStringEmpty(filter.GroupID) {
+		q.eq("group_id", filter.GroupID)
+	}
+
+	if !util.IsStringEmpty(filter.EventDeliveryID) {
+		q.eq("msg_id", filter.EventDeliveryID)
+	}
+
+	if !util.IsStringEmpty(filter.AppID) {
+		q.eq("app_id", filter.AppID)
+	}
+
+	if !util.IsStringEmpty(filter.EndpointID) {
+		q.eq("endpoint_id", filter.EndpointID)
+	}
+
+	if filter.CreatedAtEnd > 0 {
+		q.between("created_at", time.Unix(filter.CreatedAtStart, 0), time.Unix(filter.CreatedAtEnd, 0))
+	}
+
+	var attempts []datastore.DeliveryAttempt
+	paginationData, err := db.store.cursorPaged(ctx, q, pageable, &attempts)
+	if err != nil {
+		return attempts, datastore.PaginationData{}, err
+	}
+
+	return attempts, paginationData, nil
+}
+
+func (db *deliveryAttemptRepo) DeleteGroupDeliveryAttempts(ctx context.Context, filter *datastore.DeliveryAttemptFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		between("created_at", time.Unix(filter.CreatedAtStart, 0), time.Unix(filter.CreatedAtEnd, 0))
+
+	return db.store.deleteMany(ctx, q, hardDelete)
+}
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventRepo struct {
+	store *store
+}
+
+func NewEventRepository(db *sql.DB) datastore.EventRepository {
+	return &eventRepo{
+		store: newStore(db, EventTable),
+	}
+}
+
+// the formats of the interval dates and the date parts the intervals are
+// numbered by, they mirror the $dateToString formats and date operators
+// of the mongo datastore.
+var (
+	dailyIntervalFormat   = "YYYY-MM-DD"
+	weeklyIntervalFormat  = "YYYY-MM"
+	monthlyIntervalFormat = "YYYY-MM"
+	yearlyIntervalFormat  = "YYYY"
+
+	dayOfYear = "EXTRACT(DOY FROM created_at)"
+	// weeks start on sundays, the days before the first sunday of the
+	// year are in week 0.
+	week  = "FLOOR((EXTRACT(DOY FROM created_at) + 6 - EXTRACT(DOW FROM created_at)) / 7)"
+	month = "EXTRACT(MONTH FROM created_at)"
+	year  = "EXTRACT(YEAR FROM created_at)"
+)
+
+func (db *eventRepo) CreateEvent(ctx context.Context, message *datastore.Event) error {
+	message.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(message.ProviderID) {
+		message.ProviderID = message.AppID
+	}
+	if util.IsStringEmpty(message.UID) {
+		message.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, message)
+}
+
+func (db *eventRepo) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
+	count, err := db.store.count(ctx, newQuery().eq("group_id", groupID))
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in group %s", groupID)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (db *eventRepo) DeleteGroupEvents(ctx context.Context, filter *datastore.EventFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		between("created_at", time.Unix(filter.CreatedAtStart, 0), time.Unix(filter.CreatedAtEnd, 0))
+
+	return db.store.deleteMany(ctx, q, hardDelete)
+}
+
+func (db *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, searchParams datastore.SearchParams, period datastore.Period, interval int) ([]datastore.EventInterval, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = start
+	}
+
+	var timeComponent string
+	var format string
+	switch period {
+	case datastore.Daily:
+		timeComponent = dayOfYear
+		format = dailyIntervalFormat
+	case datastore.Weekly:
+		timeComponent = week
+		format = weeklyIntervalFormat
+	case datastore.Monthly:
+		timeComponent = month
+		format = monthlyIntervalFormat
+	case datastore.Yearly:
+		timeComponent = year
+		format = yearlyIntervalFormat
+	default:
+		return nil, errors.New("specified data cannot be generated for period")
+	}
+
+	q := newQuery().eq("group_id", groupID).active().between("created_at", time.Unix(start, 0), time.Unix(end, 0))
+
+	stmt := fmt.Sprintf(`SELECT total_time, index, COUNT(*) FROM (
+		SELECT TO_CHAR(created_at, '%s') AS total_time, TRUNC(%s / %s)::BIGINT AS index FROM (
+			SELECT convoy_datetime(document->'created_at') AT TIME ZONE 'UTC' AS created_at FROM %s WHERE %s
+		) AS events
+	) AS intervals GROUP BY total_time, index ORDER BY total_time, index`, format, timeComponent, q.arg(interval), EventTable, q.where())
+
+	rows, err := db.store.db.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		log.WithError(err).Errorln("aggregate error")
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventsIntervals := make([]datastore.EventInterval, 0)
+	for rows.Next() {
+		var i datastore.EventInterval
+		if err = rows.Scan(&i.Data.Time, &i.Data.Interval, &i.Count); err != nil {
+			return nil, err
+		}
+		eventsIntervals = append(eventsIntervals, i)
+	}
+
+	return eventsIntervals, rows.Err()
+}
+
+func (db *eventRepo) FindEventByID(ctx context.Context, id string) (*datastore.Event, error) {
+	m := new(datastore.Event)
+
+	err := db.store.findOne(ctx, newQuery().eq("uid", id), m)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventNotFound
+	}
+
+	return m, err
+}
+
+// FindEventByIdempotencyKey returns an event of the source created since
+// the given time with the idempotency key.
+func (db *eventRepo) FindEventByIdempotencyKey(ctx context.Context, sourceID string, key string, since time.Time) (*datastore.Event, error) {
+	m := new(datastore.Event)
+
+	q := newQuery().eq("source_id", sourceID).eq("idempotency_key", key).since("created_at", since)
+
+	err := db.store.findOne(ctx, q, m)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventNotFound
+	}
+
+	return m, err
+}
+
+func (db *eventRepo) IncrementDuplicateCount(ctx context.Context, id string) error {
+	return db.store.inc(ctx, newQuery().eq("uid", id), "duplicate_count", 1)
+}
+
+// UpdateEventPayload stores the data, the raw body and their references
+// of event.
+func (db *eventRepo) UpdateEventPayload(ctx context.Context, event *datastore.Event) error {
+	update := bson.M{
+		"data":     event.Data,
+		"raw":      event.Raw,
+		"data_ref": event.DataRef,
+		"raw_ref":  event.RawRef,
+	}
+
+	return db.store.updateOne(ctx, newQuery().eq("uid", event.UID), update)
+}
+
+func (db *eventRepo) FindEventsByIDs(ctx context.Context, ids []string) ([]datastore.Event, error) {
+	m := make([]datastore.Event, 0)
+
+	err := db.store.findMany(ctx, newQuery().in("uid", ids), 1, 0, 0, &m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
+
+func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	q := createdAtFilter(newQuery(), searchParams)
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(appId) {
+		q.eq("app_id", appId)
+	}
+
+	var messages []datastore.Event
+	paginationData, err := db.store.cursorPaged(ctx, q, pageable, &messages)
+	if err != nil {
+		return messages, datastore.PaginationData{}, err
+	}
+
+	return messages, paginationData, nil
+}
+
+func (db *eventRepo) LoadEventsByFilter(ctx context.Context, filter *datastore.EventFilter, pageable datastore.Pageable) ([]datastore.Event, error) {
+	var events []datastore.Event
+	_, err := db.store.paged(ctx, getEventFilter(filter), pageable.Sort, pageable, &events)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (db *eventRepo) CountEvents(ctx context.Context, filter *datastore.EventFilter) (int64, error) {
+	count, err := db.store.count(ctx, getEventFilter(filter))
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in group %s", filter.GroupID)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func getEventFilter(filter *datastore.EventFilter) *query {
+	q := createdAtFilter(newQuery(), datastore.SearchParams{
+		CreatedAtStart: filter.CreatedAtStart,
+		CreatedAtEnd:   filter.CreatedAtEnd,
+	})
+
+	fields := []struct{ name, value string }{
+		{"group_id", filter.GroupID},
+		{"app_id", filter.AppID},
+		{"source_id", filter.SourceID},
+	}
+
+	for _, f := range fields {
+		if !util.IsStringEmpty(f.value) {
+			q.eq(f.name, f.value)
+		}
+	}
+
+	if len(filter.EventTypes) > 0 {
+		q.in("event_type", filter.EventTypes)
+	}
+
+	return q
+}
+
+func createdAtFilter(q *query, searchParams datastore.SearchParams) *query {
+	return q.between("created_at", time.Unix(searchParams.CreatedAtStart, 0), time.Unix(searchParams.CreatedAtEnd, 0))
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventDeliveryRepo struct {
+	store *store
+}
+
+func NewEventDeliveryRepository(db *sql.DB) datastore.EventDeliveryRepository {
+	return &eventDeliveryRepo{
+		store: newStore(db, EventDeliveryTable),
+	}
+}
+
+func (db *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery) error {
+	eventDelivery.ID = primitive.NewObjectID()
+	if util.IsStringEmpty(eventDelivery.UID) {
+		eventDelivery.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, eventDelivery)
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context, id string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	err := db.store.findOne(ctx, newQuery().eq("uid", id), e)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, ids []string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := db.store.findMany(ctx, newQuery().in("uid", ids), 1, 0, 0, &deliveries)
+	return deliveries, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context, eventID string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := db.store.findMany(ctx, newQuery().eq("event_id", eventID), 1, 0, 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// FindEventDeliveriesBySubscriptionID returns up to limit deliveries of a
+// subscription with the given status, oldest first.
+func (db *eventDeliveryRepo) FindEventDeliveriesBySubscriptionID(ctx context.Context, groupID, subscriptionID string, status datastore.EventDeliveryStatus, limit int) ([]datastore.EventDelivery, error) {
+	q := newQuery().
+		eq("group_id", groupID).
+		eq("subscription_id", subscriptionID).
+		eq("status", string(status))
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.findMany(ctx, q, 1, int64(limit), 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (db *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context, status datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	q := createdAtFilter(newQuery().eq("status", string(status)), searchParams)
+
+	return db.store.count(ctx, q)
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context, e datastore.EventDelivery, status datastore.EventDeliveryStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := db.store.updateOne(ctx, newQuery().eq("uid", e.UID), update)
+	if err != nil {
+		log.WithError(err).Error("Failed to update event delivery status")
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, ids []string, status datastore.EventDeliveryStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	return db.store.updateMany(ctx, newQuery().in("uid", ids).active(), update)
+}
+
+func (db *eventDeliveryRepo) UpdateEventDelivery(ctx context.Context, e datastore.EventDelivery) error {
+	update := bson.M{
+		"status":      e.Status,
+		"description": e.Description,
+		"metadata":    e.Metadata,
+		"updated_at":  primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := db.store.updateOne(ctx, newQuery().eq("uid", e.UID), update)
+	if err != nil {
+		log.WithError(err).Errorf("error updating an event delivery %s - %s\n", e.UID, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	var eventDeliveries []datastore.EventDelivery
+	paginationData, err := db.store.cursorPaged(ctx, getFilter(groupID, appID, eventID, status, searchParams), pageable, &eventDeliveries)
+	if err != nil {
+		return eventDeliveries, datastore.PaginationData{}, err
+	}
+
+	return eventDeliveries, paginationData, nil
+}
+
+func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	return db.store.count(ctx, getFilter(groupID, appID, eventID, status, searchParams))
+}
+
+func getFilter(groupID string, appID string, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) *query {
+	q := createdAtFilter(newQuery(), searchParams)
+
+	if !util.IsStringEmpty(appID) {
+		q.eq("app_id", appID)
+	}
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(eventID) {
+		q.eq("event_id", eventID)
+	}
+
+	if len(status) > 0 {
+		statuses := make([]string, 0, len(status))
+		for _, s := range status {
+			statuses = append(statuses, string(s))
+		}
+		q.in("status", statuses)
+	}
+
+	return q
+}
+
+func (db *eventDeliveryRepo) DeleteGroupEventDeliveries(ctx context.Context, filter *datastore.EventDeliveryFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		between("created_at", time.Unix(filter.CreatedAtStart, 0), time.Unix(filter.CreatedAtEnd, 0))
+
+	return db.store.deleteMany(ctx, q, hardDelete)
+}
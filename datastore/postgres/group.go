@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// groupNameIndex is the unique index on the names of an organisation's
+// groups.
+const groupNameIndex = "groups_name_key"
+
+type groupRepo struct {
+	db    *sql.DB
+	store *store
+}
+
+func NewGroupRepo(db *sql.DB) datastore.GroupRepository {
+	return &groupRepo{
+		db:    db,
+		store: newStore(db, GroupTable),
+	}
+}
+
+func (db *groupRepo) LoadGroups(ctx context.Context, f *datastore.GroupFilter) ([]*datastore.Group, error) {
+	groups := make([]*datastore.Group, 0)
+
+	q := newQuery()
+	if f.OrgID != "" {
+		q.eq("organisation_id", f.OrgID)
+	}
+
+	f = f.WithNamesTrimmed()
+	if len(f.Names) > 0 {
+		q.in("name", f.Names)
+	}
+
+	err := db.store.findMany(ctx, q, 1, 0, 0, &groups)
+	return groups, err
+}
+
+func (db *groupRepo) CreateGroup(ctx context.Context, o *datastore.Group) error {
+	o.ID = primitive.NewObjectID()
+
+	err := db.store.insert(ctx, o)
+	if isUniqueViolation(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) UpdateGroup(ctx context.Context, o *datastore.Group) error {
+	o.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"name":                o.Name,
+		"logo_url":            o.LogoURL,
+		"updated_at":          o.UpdatedAt,
+		"config":              o.Config,
+		"rate_limit":          o.RateLimit,
+		"metadata":            o.Metadata,
+		"rate_limit_duration": o.RateLimitDuration,
+	}
+
+	err := db.store.updateOne(ctx, newQuery().eq("uid", o.UID), update)
+	if isUniqueViolation(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) FetchGroupByID(ctx context.Context, id string) (*datastore.Group, error) {
+	group := new(datastore.Group)
+
+	err := db.store.findOne(ctx, newQuery().eq("uid", id), group)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrGroupNotFound
+	}
+
+	return group, err
+}
+
+// FillGroupsStatistics sets the number of apps and events of each group,
+// deleted apps and events are counted like in the mongo datastore.
+func (db *groupRepo) FillGroupsStatistics(ctx context.Context, groups []*datastore.Group) error {
+	ids := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ids = append(ids, group.UID)
+	}
+
+	stmt := fmt.Sprintf(`SELECT g.uid,
+		(SELECT COUNT(*) FROM %s WHERE document->>'group_id' = g.uid),
+		(SELECT COUNT(*) FROM %s WHERE document->>'group_id' = g.uid)
+	FROM UNNEST($1::TEXT[]) AS g(uid)`, AppTable, EventTable)
+
+	rows, err := db.db.QueryContext(ctx, stmt, pq.Array(ids))
+	if err != nil {
+		log.WithError(err).Error("failed to run group statistics aggregation")
+		return err
+	}
+	defer rows.Close()
+
+	statsMap := map[string]*datastore.GroupStatistics{}
+	for rows.Next() {
+		s := &datastore.GroupStatistics{}
+		if err = rows.Scan(&s.GroupID, &s.TotalApps, &s.MessagesSent); err != nil {
+			return err
+		}
+		statsMap[s.GroupID] = s
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range groups {
+		groups[i].Statistics = statsMap[groups[i].UID]
+	}
+
+	return nil
+}
+
+func (db *groupRepo) DeleteGroup(ctx context.Context, uid string) error {
+	return db.store.deleteMany(ctx, newQuery().eq("uid", uid), false)
+}
+
+func (db *groupRepo) FetchGroupsByIDs(ctx context.Context, ids []string) ([]datastore.Group, error) {
+	groups := make([]datastore.Group, 0)
+
+	err := db.store.findMany(ctx, newQuery().in("uid", ids), 1, 0, 0, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
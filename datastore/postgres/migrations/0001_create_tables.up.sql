@@ -0,0 +1,140 @@
+-- Every table keeps its model as a JSONB document encoded like the BSON
+-- documents of the mongo datastore, the fields that are filtered on are
+-- indexed by expression.
+
+-- convoy_datetime reads an extended JSON date, it is immutable so that it
+-- can be used in indexes.
+CREATE OR REPLACE FUNCTION convoy_datetime(value JSONB) RETURNS TIMESTAMPTZ AS $$
+	SELECT CASE jsonb_typeof(value->'$date')
+		WHEN 'string' THEN (value->>'$date')::TIMESTAMPTZ
+		WHEN 'object' THEN to_timestamp((value->'$date'->>'$numberLong')::NUMERIC / 1000)
+	END
+$$ LANGUAGE SQL IMMUTABLE;
+
+CREATE TABLE configurations (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX configurations_uid_key ON configurations ((document->>'uid'));
+
+CREATE TABLE organisations (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX organisations_uid_key ON organisations ((document->>'uid'));
+CREATE INDEX organisations_created_at_idx ON organisations ((document->>'document_status'), convoy_datetime(document->'created_at'));
+
+CREATE TABLE organisation_members (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX organisation_members_uid_key ON organisation_members ((document->>'uid'));
+CREATE UNIQUE INDEX organisation_members_user_key ON organisation_members ((document->>'organisation_id'), (document->>'user_id'))
+	WHERE document->>'document_status' = 'Active';
+CREATE INDEX organisation_members_user_id_idx ON organisation_members ((document->>'user_id'));
+
+CREATE TABLE organisation_invites (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX organisation_invites_uid_key ON organisation_invites ((document->>'uid'));
+CREATE UNIQUE INDEX organisation_invites_token_key ON organisation_invites ((document->>'token'));
+CREATE UNIQUE INDEX organisation_invites_invitee_email_key ON organisation_invites ((document->>'organisation_id'), (document->>'invitee_email'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE users (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX users_uid_key ON users ((document->>'uid'));
+CREATE UNIQUE INDEX users_email_key ON users ((document->>'email'))
+	WHERE document->>'document_status' = 'Active';
+CREATE INDEX users_reset_password_token_idx ON users ((document->>'reset_password_token'));
+
+CREATE TABLE api_keys (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX api_keys_uid_key ON api_keys ((document->>'uid'));
+CREATE INDEX api_keys_mask_id_idx ON api_keys ((document->>'mask_id'));
+CREATE INDEX api_keys_hash_idx ON api_keys ((document->>'hash'));
+
+CREATE TABLE groups (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX groups_uid_key ON groups ((document->>'uid'));
+CREATE UNIQUE INDEX groups_name_key ON groups ((document->>'organisation_id'), (document->>'name'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE applications (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX applications_uid_key ON applications ((document->>'uid'));
+CREATE INDEX applications_group_id_idx ON applications ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+CREATE INDEX applications_endpoints_idx ON applications USING GIN ((document->'endpoints') jsonb_path_ops);
+
+CREATE TABLE events (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX events_uid_key ON events ((document->>'uid'));
+CREATE INDEX events_group_id_idx ON events ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+CREATE INDEX events_app_id_idx ON events ((document->>'app_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+CREATE INDEX events_source_id_idx ON events ((document->>'source_id'), (document->>'idempotency_key'), convoy_datetime(document->'created_at'))
+	WHERE document ? 'idempotency_key';
+
+CREATE TABLE event_deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX event_deliveries_uid_key ON event_deliveries ((document->>'uid'));
+CREATE INDEX event_deliveries_event_id_idx ON event_deliveries ((document->>'event_id'), (document->>'document_status'));
+CREATE INDEX event_deliveries_group_id_idx ON event_deliveries ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+CREATE INDEX event_deliveries_subscription_id_idx ON event_deliveries ((document->>'group_id'), (document->>'subscription_id'), (document->>'status'), convoy_datetime(document->'created_at'));
+CREATE INDEX event_deliveries_status_idx ON event_deliveries ((document->>'status'), convoy_datetime(document->'created_at'));
+
+CREATE TABLE sources (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX sources_uid_key ON sources ((document->>'uid'));
+CREATE UNIQUE INDEX sources_mask_id_key ON sources ((document->>'mask_id'));
+CREATE INDEX sources_group_id_idx ON sources ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+
+CREATE TABLE subscriptions (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX subscriptions_uid_key ON subscriptions ((document->>'uid'));
+CREATE INDEX subscriptions_group_id_idx ON subscriptions ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+CREATE INDEX subscriptions_filter_config_idx ON subscriptions USING GIN ((document->'filter_config') jsonb_path_ops);
+
+CREATE TABLE replay_jobs (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX replay_jobs_uid_key ON replay_jobs ((document->>'uid'));
+CREATE INDEX replay_jobs_group_id_idx ON replay_jobs ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+
+CREATE TABLE test_attempts (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX test_attempts_uid_key ON test_attempts ((document->>'uid'));
+CREATE INDEX test_attempts_endpoint_id_idx ON test_attempts ((document->>'group_id'), (document->>'app_id'), (document->>'endpoint_id'), convoy_datetime(document->'created_at'));
@@ -0,0 +1,24 @@
+CREATE TABLE delivery_attempts (
+	id BIGSERIAL PRIMARY KEY,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX delivery_attempts_uid_key ON delivery_attempts ((document->>'uid'));
+CREATE INDEX delivery_attempts_msg_id_idx ON delivery_attempts ((document->>'msg_id'), (document->>'document_status'), convoy_datetime(document->'created_at'), (document->'_id'->>'$oid') COLLATE "C");
+CREATE INDEX delivery_attempts_endpoint_id_idx ON delivery_attempts ((document->>'group_id'), (document->>'endpoint_id'), (document->>'document_status'), convoy_datetime(document->'created_at'), (document->'_id'->>'$oid') COLLATE "C");
+CREATE INDEX delivery_attempts_group_id_idx ON delivery_attempts ((document->>'group_id'), (document->>'document_status'), convoy_datetime(document->'created_at'));
+
+-- the attempts embedded in the event deliveries are moved to the new
+-- table, they take the group and application of their event delivery.
+INSERT INTO delivery_attempts (document)
+SELECT attempt.value || jsonb_build_object(
+		'group_id', d.document->'group_id',
+		'app_id', d.document->'app_id',
+		'msg_id', d.document->'uid',
+		'document_status', 'Active')
+FROM event_deliveries d
+CROSS JOIN LATERAL jsonb_array_elements(d.document->'attempts') WITH ORDINALITY AS attempt(value, position)
+WHERE jsonb_typeof(d.document->'attempts') = 'array'
+ORDER BY d.id, attempt.position;
+
+UPDATE event_deliveries SET document = document - 'attempts' WHERE document ? 'attempts';
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgInviteRepo struct {
+	store *store
+}
+
+func NewOrgInviteRepo(db *sql.DB) datastore.OrganisationInviteRepository {
+	return &orgInviteRepo{
+		store: newStore(db, OrganisationInvitesTable),
+	}
+}
+
+func (db *orgInviteRepo) LoadOrganisationsInvitesPaged(ctx context.Context, orgID string, inviteStatus datastore.InviteStatus, pageable datastore.Pageable) ([]datastore.OrganisationInvite, datastore.PaginationData, error) {
+	q := newQuery()
+
+	if !util.IsStringEmpty(orgID) {
+		q.eq("organisation_id", orgID)
+	}
+
+	if !util.IsStringEmpty(inviteStatus.String()) {
+		q.eq("status", inviteStatus.String())
+	}
+
+	invites := make([]datastore.OrganisationInvite, 0)
+	paginationData, err := db.store.paged(ctx, q, pageable.Sort, pageable, &invites)
+	if err != nil {
+		return invites, datastore.PaginationData{}, err
+	}
+
+	return invites, paginationData, nil
+}
+
+func (db *orgInviteRepo) CreateOrganisationInvite(ctx context.Context, iv *datastore.OrganisationInvite) error {
+	iv.ID = primitive.NewObjectID()
+	return db.store.insert(ctx, iv)
+}
+
+func (db *orgInviteRepo) UpdateOrganisationInvite(ctx context.Context, iv *datastore.OrganisationInvite) error {
+	iv.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"role":            iv.Role,
+		"status":          iv.Status,
+		"updated_at":      iv.UpdatedAt,
+		"expires_at":      iv.ExpiresAt,
+		"document_status": iv.DocumentStatus,
+	}
+
+	return db.store.updateOne(ctx, newQuery().eq("uid", iv.UID), update)
+}
+
+func (db *orgInviteRepo) DeleteOrganisationInvite(ctx context.Context, uid string) error {
+	return db.store.updateOne(ctx, newQuery().eq("uid", uid), softDelete())
+}
+
+func (db *orgInviteRepo) FetchOrganisationInviteByID(ctx context.Context, id string) (*datastore.OrganisationInvite, error) {
+	return db.fetchOrganisationInvite(ctx, newQuery().eq("uid", id))
+}
+
+func (db *orgInviteRepo) FetchOrganisationInviteByToken(ctx context.Context, token string) (*datastore.OrganisationInvite, error) {
+	return db.fetchOrganisationInvite(ctx, newQuery().eq("token", token))
+}
+
+func (db *orgInviteRepo) fetchOrganisationInvite(ctx context.Context, q *query) (*datastore.OrganisationInvite, error) {
+	iv := &datastore.OrganisationInvite{}
+
+	err := db.store.findOne(ctx, q, iv)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrOrgInviteNotFound
+	}
+
+	return iv, err
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgMemberRepo struct {
+	store *store
+	users *store
+}
+
+func NewOrgMemberRepo(db *sql.DB) datastore.OrganisationMemberRepository {
+	return &orgMemberRepo{
+		store: newStore(db, OrganisationMembersTable),
+		users: newStore(db, UserTable),
+	}
+}
+
+func (o *orgMemberRepo) LoadOrganisationMembersPaged(ctx context.Context, organisationID string, pageable datastore.Pageable) ([]*datastore.OrganisationMember, datastore.PaginationData, error) {
+	q := newQuery()
+	if !util.IsStringEmpty(organisationID) {
+		q.eq("organisation_id", organisationID)
+	}
+
+	members := make([]*datastore.OrganisationMember, 0)
+	paginationData, err := o.store.paged(ctx, q, pageable.Sort, pageable, &members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(ctx, members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	return members, paginationData, nil
+}
+
+// LoadUserOrganisationsPaged returns the active organisations of the
+// user's memberships, it pages through the memberships like the mongo
+// datastore.
+func (o *orgMemberRepo) LoadUserOrganisationsPaged(ctx context.Context, userID string, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
+	skip := 0
+	if pageable.Page > 1 {
+		skip = pageable.PerPage * pageable.Page
+	}
+
+	q := newQuery().eq("user_id", userID).active()
+
+	stmt := fmt.Sprintf(`SELECT o.document FROM (
+		SELECT id, document FROM %s WHERE %s ORDER BY %s LIMIT %d OFFSET %d
+	) AS m JOIN %s AS o ON o.document->>'uid' = m.document->>'organisation_id'
+	WHERE o.%s ORDER BY m.id`, OrganisationMembersTable, q.where(), orderBy(pageable.Sort), pageable.PerPage, skip, OrganisationTable, activeCond)
+
+	organisations := make([]datastore.Organisation, 0)
+	err := o.store.query(ctx, stmt, q.args, &organisations)
+	if err != nil {
+		log.WithError(err).Error("failed to run user organisations aggregation")
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return organisations, datastore.PaginationData{}, nil
+}
+
+func (o *orgMemberRepo) CreateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.ID = primitive.NewObjectID()
+	return o.store.insert(ctx, member)
+}
+
+func (o *orgMemberRepo) UpdateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"role":       member.Role,
+		"updated_at": member.UpdatedAt,
+	}
+
+	return o.store.updateOne(ctx, newQuery().eq("uid", member.UID), update)
+}
+
+func (o *orgMemberRepo) DeleteOrganisationMember(ctx context.Context, uid, orgID string) error {
+	q := newQuery().eq("uid", uid).eq("organisation_id", orgID)
+
+	return o.store.updateOne(ctx, q, softDelete())
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByID(ctx context.Context, uid, orgID string) (*datastore.OrganisationMember, error) {
+	return o.fetchOrganisationMember(ctx, newQuery().eq("uid", uid).eq("organisation_id", orgID))
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByUserID(ctx context.Context, userID, orgID string) (*datastore.OrganisationMember, error) {
+	return o.fetchOrganisationMember(ctx, newQuery().eq("user_id", userID).eq("organisation_id", orgID))
+}
+
+func (o *orgMemberRepo) fetchOrganisationMember(ctx context.Context, q *query) (*datastore.OrganisationMember, error) {
+	member := new(datastore.OrganisationMember)
+
+	err := o.store.findOne(ctx, q, member)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, datastore.ErrOrgMemberNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(ctx, []*datastore.OrganisationMember{member})
+	return member, err
+}
+
+func (o *orgMemberRepo) fillOrgMemberUserMetadata(ctx context.Context, members []*datastore.OrganisationMember) error {
+	userIDs := make([]string, 0, len(members))
+	for i := range members {
+		userIDs = append(userIDs, members[i].UserID)
+	}
+
+	users := make([]datastore.User, 0)
+	err := o.users.findMany(ctx, newQuery().in("uid", userIDs), 1, 0, 0, &users)
+	if err != nil {
+		log.WithError(err).Error("failed to run user metadata for organisation members aggregation")
+		return err
+	}
+
+	metaMap := map[string]*datastore.UserMetadata{}
+	for _, u := range users {
+		metaMap[u.UID] = &datastore.UserMetadata{
+			UserID:    u.UID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+		}
+	}
+
+	for i := range members {
+		members[i].UserMetadata = metaMap[members[i].UserID]
+	}
+
+	return nil
+}
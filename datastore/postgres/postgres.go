@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	_ "github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ConfigTable              = "configurations"
+	GroupTable               = "groups"
+	OrganisationTable        = "organisations"
+	OrganisationInvitesTable = "organisation_invites"
+	OrganisationMembersTable = "organisation_members"
+	AppTable                 = "applications"
+	EventTable               = "events"
+	EventDeliveryTable       = "event_deliveries"
+	SourceTable              = "sources"
+	UserTable                = "users"
+	SubscriptionTable        = "subscriptions"
+	ReplayJobTable           = "replay_jobs"
+	TestAttemptTable         = "test_attempts"
+	DeliveryAttemptTable     = "delivery_attempts"
+	APIKeyTable              = "api_keys"
+)
+
+// migrationsLockID is the advisory lock held while migrations run so that
+// instances starting together don't apply them twice.
+const migrationsLockID = 5390183
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type Client struct {
+	db                *sql.DB
+	apiKeyRepo        datastore.APIKeyRepository
+	groupRepo         datastore.GroupRepository
+	eventRepo         datastore.EventRepository
+	applicationRepo   datastore.ApplicationRepository
+	subscriptionRepo  datastore.SubscriptionRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	sourceRepo        datastore.SourceRepository
+	orgRepo           datastore.OrganisationRepository
+	orgMemberRepo     datastore.OrganisationMemberRepository
+	orgInviteRepo     datastore.OrganisationInviteRepository
+	userRepo          datastore.UserRepository
+	configRepo        datastore.ConfigurationRepository
+	replayJobRepo     datastore.ReplayJobRepository
+	testAttemptRepo   datastore.TestAttemptRepository
+	attemptRepo       datastore.DeliveryAttemptRepository
+}
+
+func New(cfg config.Configuration) (*Client, error) {
+	db, err := sql.Open("postgres", cfg.Database.Dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := Migrate(context.Background(), db); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %v", err)
+	}
+
+	c := &Client{
+		db:                db,
+		apiKeyRepo:        NewApiKeyRepo(db),
+		groupRepo:         NewGroupRepo(db),
+		applicationRepo:   NewApplicationRepo(db),
+		subscriptionRepo:  NewSubscriptionRepo(db),
+		eventRepo:         NewEventRepository(db),
+		eventDeliveryRepo: NewEventDeliveryRepository(db),
+		sourceRepo:        NewSourceRepo(db),
+		orgRepo:           NewOrgRepo(db),
+		orgMemberRepo:     NewOrgMemberRepo(db),
+		orgInviteRepo:     NewOrgInviteRepo(db),
+		userRepo:          NewUserRepo(db),
+		configRepo:        NewConfigRepo(db),
+		replayJobRepo:     NewReplayJobRepo(db),
+		testAttemptRepo:   NewTestAttemptRepo(db),
+		attemptRepo:       NewDeliveryAttemptRepo(db),
+	}
+
+	return c, nil
+}
+
+// Migrate applies the embedded migrations that weren't applied yet in
+// the order of their file names.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationsLockID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationsLockID)
+		if err != nil {
+			log.WithError(err).Error("failed to release migrations lock")
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	files, err := fs.Glob(migrations, "migrations/*.up.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		version := strings.TrimSuffix(strings.TrimPrefix(file, "migrations/"), ".up.sql")
+
+		var applied bool
+		err = conn.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied)
+		if err != nil {
+			return err
+		}
+
+		if applied {
+			continue
+		}
+
+		stmts, err := migrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, string(stmts)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %s: %v", version, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+
+		log.Infof("applied migration %s", version)
+	}
+
+	return nil
+}
+
+func (c *Client) Disconnect(ctx context.Context) error {
+	return c.db.Close()
+}
+
+func (c *Client) GetName() string {
+	return "postgres"
+}
+
+func (c *Client) Client() interface{} {
+	return c.db
+}
+
+func (c *Client) APIRepo() datastore.APIKeyRepository {
+	return c.apiKeyRepo
+}
+
+func (c *Client) GroupRepo() datastore.GroupRepository {
+	return c.groupRepo
+}
+
+func (c *Client) AppRepo() datastore.ApplicationRepository {
+	return c.applicationRepo
+}
+
+func (c *Client) EventRepo() datastore.EventRepository {
+	return c.eventRepo
+}
+
+func (c *Client) EventDeliveryRepo() datastore.EventDeliveryRepository {
+	return c.eventDeliveryRepo
+}
+
+func (c *Client) SubRepo() datastore.SubscriptionRepository {
+	return c.subscriptionRepo
+}
+
+func (c *Client) SourceRepo() datastore.SourceRepository {
+	return c.sourceRepo
+}
+
+func (c *Client) OrganisationRepo() datastore.OrganisationRepository {
+	return c.orgRepo
+}
+
+func (c *Client) OrganisationMemberRepo() datastore.OrganisationMemberRepository {
+	return c.orgMemberRepo
+}
+
+func (c *Client) OrganisationInviteRepo() datastore.OrganisationInviteRepository {
+	return c.orgInviteRepo
+}
+
+func (c *Client) UserRepo() datastore.UserRepository {
+	return c.userRepo
+}
+
+func (c *Client) ConfigurationRepo() datastore.ConfigurationRepository {
+	return c.configRepo
+}
+
+func (c *Client) ReplayJobRepo() datastore.ReplayJobRepository {
+	return c.replayJobRepo
+}
+
+func (c *Client) TestAttemptRepo() datastore.TestAttemptRepository {
+	return c.testAttemptRepo
+}
+
+func (c *Client) DeliveryAttemptRepo() datastore.DeliveryAttemptRepository {
+	return c.attemptRepo
+}
@@ -0,0 +1,40 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+)
+
+func getDSN() string {
+	return os.Getenv("TEST_POSTGRES_DSN")
+}
+
+func getConfig() config.Configuration {
+	return config.Configuration{
+		Database: config.DatabaseConfiguration{
+			Type: config.PostgresDatabaseProvider,
+			Dsn:  getDSN(),
+		},
+	}
+}
+
+func getDB(t *testing.T) (*sql.DB, func()) {
+	db, err := New(getConfig())
+	require.NoError(t, err)
+
+	client := db.Client().(*sql.DB)
+
+	return client, func() {
+		_, err := client.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public;")
+		require.NoError(t, err)
+		require.NoError(t, db.Disconnect(context.Background()))
+	}
+}
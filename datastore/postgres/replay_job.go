@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type replayJobRepo struct {
+	store *store
+}
+
+func NewReplayJobRepo(db *sql.DB) datastore.ReplayJobRepository {
+	return &replayJobRepo{
+		store: newStore(db, ReplayJobTable),
+	}
+}
+
+func (r *replayJobRepo) CreateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	job.ID = primitive.NewObjectID()
+	return r.store.insert(ctx, job)
+}
+
+func (r *replayJobRepo) UpdateReplayJob(ctx context.Context, job *datastore.ReplayJob) error {
+	job.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	q := newQuery().eq("uid", job.UID).eq("group_id", job.GroupID).active()
+
+	update := bson.M{
+		"status":           job.Status,
+		"total_events":     job.TotalEvents,
+		"processed_events": job.ProcessedEvents,
+		"failed_events":    job.FailedEvents,
+		"error":            job.Error,
+		"started_at":       job.StartedAt,
+		"completed_at":     job.CompletedAt,
+		"updated_at":       job.UpdatedAt,
+	}
+
+	return r.store.updateOne(ctx, q, update)
+}
+
+func (r *replayJobRepo) FindReplayJobByID(ctx context.Context, groupID string, id string) (*datastore.ReplayJob, error) {
+	job := &datastore.ReplayJob{}
+
+	err := r.store.findOne(ctx, newQuery().eq("uid", id).eq("group_id", groupID), job)
+	if errors.Is(err, sql.ErrNoRows) {
+		return job, datastore.ErrReplayJobNotFound
+	}
+
+	return job, err
+}
+
+func (r *replayJobRepo) LoadReplayJobsPaged(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.ReplayJob, datastore.PaginationData, error) {
+	var jobs []datastore.ReplayJob
+	paginationData, err := r.store.paged(ctx, newQuery().eq("group_id", groupID), -1, pageable, &jobs)
+	if err != nil {
+		return jobs, datastore.PaginationData{}, err
+	}
+
+	return jobs, paginationData, nil
+}
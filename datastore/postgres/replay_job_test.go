@@ -0,0 +1,85 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindReplayJobByID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+	replayJobRepo := NewReplayJobRepo(db)
+
+	job := generateReplayJob(uuid.NewString())
+
+	_, err := replayJobRepo.FindReplayJobByID(context.Background(), job.GroupID, job.UID)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, datastore.ErrReplayJobNotFound))
+
+	require.NoError(t, replayJobRepo.CreateReplayJob(context.Background(), job))
+
+	newJob, err := replayJobRepo.FindReplayJobByID(context.Background(), job.GroupID, job.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, job.UID, newJob.UID)
+	require.Equal(t, job.Filter.EventTypes, newJob.Filter.EventTypes)
+}
+
+func Test_UpdateReplayJob(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+	replayJobRepo := NewReplayJobRepo(db)
+
+	job := generateReplayJob(uuid.NewString())
+	require.NoError(t, replayJobRepo.CreateReplayJob(context.Background(), job))
+
+	job.Status = datastore.RunningReplayJobStatus
+	job.ProcessedEvents = 50
+	require.NoError(t, replayJobRepo.UpdateReplayJob(context.Background(), job))
+
+	newJob, err := replayJobRepo.FindReplayJobByID(context.Background(), job.GroupID, job.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, datastore.RunningReplayJobStatus, newJob.Status)
+	require.Equal(t, int64(50), newJob.ProcessedEvents)
+}
+
+func Test_LoadReplayJobsPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+	replayJobRepo := NewReplayJobRepo(db)
+
+	groupID := uuid.NewString()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, replayJobRepo.CreateReplayJob(context.Background(), generateReplayJob(groupID)))
+	}
+	require.NoError(t, replayJobRepo.CreateReplayJob(context.Background(), generateReplayJob(uuid.NewString())))
+
+	jobs, pageable, err := replayJobRepo.LoadReplayJobsPaged(context.Background(), groupID, datastore.Pageable{Page: 1, PerPage: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, len(jobs))
+	require.Equal(t, int64(3), pageable.Total)
+}
+
+func generateReplayJob(groupID string) *datastore.ReplayJob {
+	return &datastore.ReplayJob{
+		UID:     uuid.NewString(),
+		GroupID: groupID,
+		Filter: &datastore.EventFilter{
+			GroupID:    groupID,
+			EventTypes: []string{"payment.created"},
+		},
+		Status:         datastore.PendingReplayJobStatus,
+		RateLimit:      datastore.DefaultReplayJobRateLimit,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+}
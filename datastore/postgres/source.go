@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type sourceRepo struct {
+	store *store
+}
+
+func NewSourceRepo(db *sql.DB) datastore.SourceRepository {
+	return &sourceRepo{
+		store: newStore(db, SourceTable),
+	}
+}
+
+func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source) error {
+	source.ID = primitive.NewObjectID()
+	return s.store.insert(ctx, source)
+}
+
+func (s *sourceRepo) UpdateSource(ctx context.Context, groupId string, source *datastore.Source) error {
+	q := newQuery().eq("uid", source.UID).eq("group_id", groupId).active()
+
+	update := bson.M{
+		"name":                source.Name,
+		"type":                source.Type,
+		"is_disabled":         source.IsDisabled,
+		"verifier":            source.Verifier,
+		"updated_at":          primitive.NewDateTimeFromTime(time.Now()),
+		"provider_config":     source.ProviderConfig,
+		"forward_headers":     source.ForwardHeaders,
+		"ip_allowlist":        source.IPAllowlist,
+		"event_type":          source.EventType,
+		"response":            source.Response,
+		"max_body_size":       source.MaxBodySize,
+		"deduplication":       source.Deduplication,
+		"priority":            source.Priority,
+		"rate_limit":          source.RateLimit,
+		"rate_limit_duration": source.RateLimitDuration,
+		"pub_sub":             source.PubSub,
+		"db_change_stream":    source.DBChangeStream,
+		"rest_api":            source.RestApi,
+		"last_handshake_at":   source.LastHandshakeAt,
+	}
+
+	return s.store.updateOne(ctx, q, update)
+}
+
+func (s *sourceRepo) FindSourceByID(ctx context.Context, groupId string, id string) (*datastore.Source, error) {
+	source := &datastore.Source{}
+
+	err := s.store.findOne(ctx, newQuery().eq("uid", id).eq("group_id", groupId), source)
+	if errors.Is(err, sql.ErrNoRows) {
+		return source, datastore.ErrSourceNotFound
+	}
+
+	return source, err
+}
+
+func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskId string) (*datastore.Source, error) {
+	source := &datastore.Source{}
+
+	err := s.store.findOne(ctx, newQuery().eq("mask_id", maskId), source)
+	if errors.Is(err, sql.ErrNoRows) {
+		return source, datastore.ErrSourceNotFound
+	}
+
+	return source, err
+}
+
+func (s *sourceRepo) DeleteSourceByID(ctx context.Context, groupId string, id string) error {
+	return s.store.updateOne(ctx, newQuery().eq("uid", id).eq("group_id", groupId), softDelete())
+}
+
+func (s *sourceRepo) UpdateSourceStatus(ctx context.Context, groupId string, id string, status *datastore.SourceStatus) error {
+	return s.store.updateOne(ctx, newQuery().eq("uid", id).eq("group_id", groupId), bson.M{"status": status})
+}
+
+func (s *sourceRepo) IncrementRejectedRequests(ctx context.Context, groupId string, id string) error {
+	return s.store.inc(ctx, newQuery().eq("uid", id).eq("group_id", groupId), "rejected_requests", 1)
+}
+
+func (s *sourceRepo) UpdateHandshakeNotifiedAt(ctx context.Context, groupId string, id string, notifiedAt time.Time) error {
+	update := bson.M{"handshake_notified_at": primitive.NewDateTimeFromTime(notifiedAt)}
+	return s.store.updateOne(ctx, newQuery().eq("uid", id).eq("group_id", groupId), update)
+}
+
+func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, groupID string, f *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
+	q := newQuery()
+
+	fields := []struct{ name, value string }{
+		{"group_id", groupID},
+		{"type", f.Type},
+		{"provider", f.Provider},
+	}
+
+	for _, field := range fields {
+		if !util.IsStringEmpty(field.value) {
+			q.eq(field.name, field.value)
+		}
+	}
+
+	var sources []datastore.Source
+	paginationData, err := s.store.paged(ctx, q, -1, pageable, &sources)
+	if err != nil {
+		return sources, datastore.PaginationData{}, err
+	}
+
+	return sources, paginationData, nil
+}
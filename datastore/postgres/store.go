@@ -0,0 +1,436 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// store reads and writes the documents of a table, documents are stored
+// as relaxed extended JSON so that they round trip through the bson tags
+// of the models exactly like the mongo datastore.
+type store struct {
+	db    dbtx
+	table string
+}
+
+// dbtx is implemented by both *sql.DB and *sql.Tx.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func newStore(db dbtx, table string) *store {
+	return &store{db: db, table: table}
+}
+
+// withTx returns the store of the table running its statements in tx.
+func (s *store) withTx(tx *sql.Tx) *store {
+	return &store{db: tx, table: s.table}
+}
+
+func marshalDocument(v interface{}) ([]byte, error) {
+	return bson.MarshalExtJSON(v, false, false)
+}
+
+func unmarshalDocument(data []byte, v interface{}) error {
+	return bson.UnmarshalExtJSON(data, false, v)
+}
+
+func (s *store) insert(ctx context.Context, v interface{}) error {
+	doc, err := marshalDocument(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (document) VALUES ($1)", s.table), doc)
+	return err
+}
+
+// findOne decodes the first active document matching q into out, it
+// returns sql.ErrNoRows when there is none.
+func (s *store) findOne(ctx context.Context, q *query, out interface{}) error {
+	if !datastore.IsValidPointer(out) {
+		return datastore.ErrInvalidPtr
+	}
+
+	stmt := fmt.Sprintf("SELECT document FROM %s WHERE %s ORDER BY id LIMIT 1", s.table, q.active().where())
+
+	var doc []byte
+	err := s.db.QueryRowContext(ctx, stmt, q.args...).Scan(&doc)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalDocument(doc, out)
+}
+
+// findMany decodes the active documents matching q into out, a pointer to
+// a slice of models or of pointers to models.
+func (s *store) findMany(ctx context.Context, q *query, sort int, limit, skip int64, out interface{}) error {
+	stmt := fmt.Sprintf("SELECT document FROM %s WHERE %s ORDER BY %s", s.table, q.active().where(), orderBy(sort))
+	if limit > 0 {
+		stmt += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	if skip > 0 {
+		stmt += fmt.Sprintf(" OFFSET %d", skip)
+	}
+
+	return s.query(ctx, stmt, q.args, out)
+}
+
+// paged loads a page of the active documents matching q ordered by their
+// creation time, the pagination data is computed like the mongo pager's.
+func (s *store) paged(ctx context.Context, q *query, sort int, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	count, err := s.count(ctx, q)
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	page, perPage := pageable.PageAndLimit()
+	err = s.findMany(ctx, q, sort, perPage, (page-1)*perPage, out)
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	return datastore.NewPaginationData(count, page, perPage), nil
+}
+
+// cursorPaged loads the page of the active documents matching q pageable
+// refers to, ordered by their creation time and id so that it can be paged
+// with cursors.
+func (s *store) cursorPaged(ctx context.Context, q *query, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	cp, err := pageable.CursorPage()
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	var total int64
+	if cp.CountTotal {
+		total, err = s.count(ctx, q)
+		if err != nil {
+			return datastore.PaginationData{}, err
+		}
+	}
+
+	dir, op := "DESC", "<"
+	if cp.Sort > 0 {
+		dir, op = "ASC", ">"
+	}
+
+	if cp.After != nil {
+		q.add(fmt.Sprintf("(convoy_datetime(document->'created_at'), %s) %s (%s, %s)",
+			objectIDExpr, op, q.arg(cp.After.CreatedAt.Time()), q.arg(cp.After.ID.Hex())))
+	}
+
+	stmt := fmt.Sprintf("SELECT document FROM %s WHERE %s ORDER BY convoy_datetime(document->'created_at') %s, %s %s LIMIT %d",
+		s.table, q.active().where(), dir, objectIDExpr, dir, cp.Limit)
+	if cp.Skip > 0 {
+		stmt += fmt.Sprintf(" OFFSET %d", cp.Skip)
+	}
+
+	if err = s.query(ctx, stmt, q.args, out); err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	return cp.Paginate(out, total)
+}
+
+// count returns the number of active documents matching q.
+func (s *store) count(ctx context.Context, q *query) (int64, error) {
+	stmt := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", s.table, q.active().where())
+
+	var count int64
+	err := s.db.QueryRowContext(ctx, stmt, q.args...).Scan(&count)
+	return count, err
+}
+
+// updateOne sets the fields of the first document matching q, dotted keys
+// set the fields of embedded documents.
+func (s *store) updateOne(ctx context.Context, q *query, update bson.M) error {
+	return s.update(ctx, q, update, false)
+}
+
+// updateMany sets the fields of every document matching q.
+func (s *store) updateMany(ctx context.Context, q *query, update bson.M) error {
+	return s.update(ctx, q, update, true)
+}
+
+func (s *store) update(ctx context.Context, q *query, update bson.M, many bool) error {
+	expr, err := setExpr(q, update)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, expr, many)
+}
+
+// inc increments a numeric field of the first document matching q.
+func (s *store) inc(ctx context.Context, q *query, field string, n int64) error {
+	expr := fmt.Sprintf("jsonb_set(document, '{%s}', to_jsonb(COALESCE((document->>'%s')::NUMERIC, 0) + %s))", field, field, q.arg(n))
+	return s.exec(ctx, q, expr, false)
+}
+
+// push appends v to an array field and sets the fields of update on the
+// first document matching q.
+func (s *store) push(ctx context.Context, q *query, field string, v interface{}, update bson.M) error {
+	item, err := marshalDocument(bson.M{"v": v})
+	if err != nil {
+		return err
+	}
+
+	expr, err := setExpr(q, update)
+	if err != nil {
+		return err
+	}
+
+	expr = fmt.Sprintf(`jsonb_set(%s, '{%s}', CASE WHEN jsonb_typeof(document->'%s') = 'array' THEN document->'%s' ELSE '[]'::JSONB END || jsonb_build_array(%s::JSONB->'v'))`,
+		expr, field, field, field, q.arg(item))
+
+	return s.exec(ctx, q, expr, false)
+}
+
+// deleteMany soft deletes the documents matching q, or removes them when
+// hardDelete is set.
+func (s *store) deleteMany(ctx context.Context, q *query, hardDelete bool) error {
+	if hardDelete {
+		stmt := fmt.Sprintf("DELETE FROM %s WHERE %s", s.table, q.where())
+		_, err := s.db.ExecContext(ctx, stmt, q.args...)
+		return err
+	}
+
+	return s.updateMany(ctx, q, softDelete())
+}
+
+func softDelete() bson.M {
+	return bson.M{
+		"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+		"document_status": datastore.DeletedDocumentStatus,
+	}
+}
+
+func (s *store) exec(ctx context.Context, q *query, expr string, many bool) error {
+	stmt := fmt.Sprintf("UPDATE %s SET document = %s WHERE %s", s.table, expr, q.where())
+	if !many {
+		stmt = fmt.Sprintf("UPDATE %s SET document = %s WHERE id = (SELECT id FROM %s WHERE %s ORDER BY id LIMIT 1)", s.table, expr, s.table, q.where())
+	}
+
+	_, err := s.db.ExecContext(ctx, stmt, q.args...)
+	return err
+}
+
+// query decodes the documents returned by stmt into out.
+func (s *store) query(ctx context.Context, stmt string, args []interface{}, out interface{}) error {
+	if !datastore.IsValidPointer(out) {
+		return datastore.ErrInvalidPtr
+	}
+
+	slice := reflect.ValueOf(out).Elem()
+	if slice.Kind() != reflect.Slice {
+		return datastore.ErrInvalidPtr
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	results := reflect.MakeSlice(slice.Type(), 0, 0)
+	for rows.Next() {
+		var doc []byte
+		if err = rows.Scan(&doc); err != nil {
+			return err
+		}
+
+		elem := reflect.New(elemType)
+		if err = unmarshalDocument(doc, elem.Interface()); err != nil {
+			return err
+		}
+
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		results = reflect.Append(results, elem)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	slice.Set(results)
+	return nil
+}
+
+// setExpr returns the expression of the document with the fields of update
+// set, keys with a single dot set a field of an embedded document.
+func setExpr(q *query, update bson.M) (string, error) {
+	fields := bson.M{}
+	embedded := map[string]bson.M{}
+	for k, v := range update {
+		parts := strings.Split(k, ".")
+		switch len(parts) {
+		case 1:
+			fields[k] = v
+		case 2:
+			if embedded[parts[0]] == nil {
+				embedded[parts[0]] = bson.M{}
+			}
+			embedded[parts[0]][parts[1]] = v
+		default:
+			return "", fmt.Errorf("unsupported update key %q", k)
+		}
+	}
+
+	expr := "document"
+	if len(fields) > 0 {
+		doc, err := marshalDocument(fields)
+		if err != nil {
+			return "", err
+		}
+		expr = fmt.Sprintf("(%s || %s::JSONB)", expr, q.arg(doc))
+	}
+
+	for parent, values := range embedded {
+		doc, err := marshalDocument(values)
+		if err != nil {
+			return "", err
+		}
+
+		expr = fmt.Sprintf(`jsonb_set(%s, '{%s}', CASE WHEN jsonb_typeof(document->'%s') = 'object' THEN document->'%s' ELSE '{}'::JSONB END || %s::JSONB)`,
+			expr, parent, parent, parent, q.arg(doc))
+	}
+
+	return expr, nil
+}
+
+// orderBy orders documents by their creation time, ascending when sort is
+// positive and descending otherwise like the mongo datastore's default.
+// objectIDExpr is the hex of a document's id, it is compared bytewise so
+// that it is ordered like the ids.
+const objectIDExpr = `(document->'_id'->>'$oid') COLLATE "C"`
+
+func orderBy(sort int) string {
+	dir := "DESC"
+	if sort > 0 {
+		dir = "ASC"
+	}
+
+	return fmt.Sprintf("convoy_datetime(document->'created_at') %s, id %s", dir, dir)
+}
+
+// isUniqueViolation reports whether err violates the unique index named
+// constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// query builds the conditions of a statement on the fields of documents,
+// the values are passed as parameters.
+type query struct {
+	conds []string
+	args  []interface{}
+}
+
+func newQuery() *query {
+	return &query{}
+}
+
+func (q *query) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *query) add(cond string) *query {
+	q.conds = append(q.conds, cond)
+	return q
+}
+
+// field returns the text of a document field, dots separate the fields of
+// embedded documents.
+func field(name string) string {
+	if strings.Contains(name, ".") {
+		return fmt.Sprintf("document#>>'{%s}'", strings.ReplaceAll(name, ".", ","))
+	}
+
+	return fmt.Sprintf("document->>'%s'", name)
+}
+
+func (q *query) eq(name string, value string) *query {
+	return q.add(fmt.Sprintf("%s = %s", field(name), q.arg(value)))
+}
+
+func (q *query) ne(name string, value string) *query {
+	return q.add(fmt.Sprintf("%s IS DISTINCT FROM %s", field(name), q.arg(value)))
+}
+
+func (q *query) in(name string, values []string) *query {
+	return q.add(fmt.Sprintf("%s = ANY(%s)", field(name), q.arg(pq.Array(values))))
+}
+
+// match matches a field against a case insensitive regular expression.
+func (q *query) match(name string, pattern string) *query {
+	return q.add(fmt.Sprintf("%s ~* %s", field(name), q.arg(pattern)))
+}
+
+// contains matches documents whose field contains v, like the matching of
+// array elements in mongo.
+func (q *query) contains(name string, v interface{}) (*query, error) {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return q, err
+	}
+
+	return q.add(fmt.Sprintf("document->'%s' @> %s::JSONB", name, q.arg(string(doc)))), nil
+}
+
+func (q *query) since(name string, t time.Time) *query {
+	return q.add(fmt.Sprintf("convoy_datetime(document->'%s') >= %s", name, q.arg(t)))
+}
+
+func (q *query) between(name string, start, end time.Time) *query {
+	return q.add(fmt.Sprintf("convoy_datetime(document->'%s') BETWEEN %s AND %s", name, q.arg(start), q.arg(end)))
+}
+
+func (q *query) active() *query {
+	for _, cond := range q.conds {
+		if cond == activeCond {
+			return q
+		}
+	}
+
+	return q.add(activeCond)
+}
+
+var activeCond = fmt.Sprintf("document->>'document_status' = '%s'", datastore.ActiveDocumentStatus)
+
+func (q *query) where() string {
+	if len(q.conds) == 0 {
+		return "TRUE"
+	}
+
+	return strings.Join(q.conds, " AND ")
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_query(t *testing.T) {
+	q := newQuery().eq("group_id", "group-1").in("status", []string{"Success", "Failure"}).active().active()
+
+	require.Equal(t, "document->>'group_id' = $1 AND document->>'status' = ANY($2) AND document->>'document_status' = 'Active'", q.where())
+	require.Len(t, q.args, 2)
+
+	require.Equal(t, "TRUE", newQuery().where())
+	require.Equal(t, "document#>>'{filter_config,event_types}'", field("filter_config.event_types"))
+}
+
+func Test_setExpr(t *testing.T) {
+	q := newQuery().eq("uid", "sub-1")
+
+	expr, err := setExpr(q, bson.M{
+		"name":               "subscription",
+		"alert_config.count": 10,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "jsonb_set((document || $2::JSONB), '{alert_config}', CASE WHEN jsonb_typeof(document->'alert_config') = 'object' THEN document->'alert_config' ELSE '{}'::JSONB END || $3::JSONB)", expr)
+	require.Equal(t, `{"name":"subscription"}`, string(q.args[1].([]byte)))
+	require.Equal(t, `{"count":10}`, string(q.args[2].([]byte)))
+
+	_, err = setExpr(newQuery(), bson.M{"retry_config.type.name": "linear"})
+	require.Error(t, err)
+}
+
+func Test_document(t *testing.T) {
+	group := &datastore.Group{
+		ID:             primitive.NewObjectID(),
+		UID:            "group-1",
+		Name:           "group",
+		CreatedAt:      primitive.NewDateTimeFromTime(primitive.DateTime(1656000000000).Time()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	doc, err := marshalDocument(group)
+	require.NoError(t, err)
+	require.Contains(t, string(doc), `"created_at":{"$date":"2022-06-23T16:00:00Z"}`)
+
+	decoded := &datastore.Group{}
+	require.NoError(t, unmarshalDocument(doc, decoded))
+	require.Equal(t, group, decoded)
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type subscriptionRepo struct {
+	store *store
+}
+
+func NewSubscriptionRepo(db *sql.DB) datastore.SubscriptionRepository {
+	return &subscriptionRepo{
+		store: newStore(db, SubscriptionTable),
+	}
+}
+
+func (s *subscriptionRepo) CreateSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	subscription.ID = primitive.NewObjectID()
+	return s.store.insert(ctx, subscription)
+}
+
+func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	subscription.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	q := newQuery().eq("uid", subscription.UID).eq("group_id", groupId).active()
+
+	update := bson.M{
+		"name":        subscription.Name,
+		"source_id":   subscription.SourceID,
+		"endpoint_id": subscription.EndpointID,
+
+		"filter_config.event_types": subscription.FilterConfig.EventTypes,
+		"alert_config.count":        subscription.AlertConfig.Count,
+		"alert_config.threshold":    subscription.AlertConfig.Threshold,
+
+		"retry_config.type":        string(subscription.RetryConfig.Type),
+		"retry_config.duration":    subscription.RetryConfig.Duration,
+		"retry_config.retry_count": subscription.RetryConfig.RetryCount,
+
+		"forward_raw_body": subscription.ForwardRawBody,
+	}
+
+	return s.store.updateOne(ctx, q, update)
+}
+
+func (s *subscriptionRepo) LoadSubscriptionsPaged(ctx context.Context, groupId string, pageable datastore.Pageable) ([]datastore.Subscription, datastore.PaginationData, error) {
+	var subscriptions []datastore.Subscription
+	paginationData, err := s.store.paged(ctx, newQuery().eq("group_id", groupId), -1, pageable, &subscriptions)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return subscriptions, paginationData, nil
+}
+
+func (s *subscriptionRepo) DeleteSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	return s.store.updateOne(ctx, newQuery().eq("uid", subscription.UID).eq("group_id", groupId), softDelete())
+}
+
+func (s *subscriptionRepo) FindSubscriptionByID(ctx context.Context, groupId string, uid string) (*datastore.Subscription, error) {
+	subscription := &datastore.Subscription{}
+
+	err := s.store.findOne(ctx, newQuery().eq("uid", uid).eq("group_id", groupId), subscription)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrSubscriptionNotFound
+	}
+
+	return subscription, err
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByEventType(ctx context.Context, groupId string, appId string, eventType datastore.EventType) ([]datastore.Subscription, error) {
+	q, err := newQuery().eq("group_id", groupId).eq("app_id", appId).
+		contains("filter_config", map[string][]string{"event_types": {string(eventType)}})
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]datastore.Subscription, 0)
+	err = s.store.findMany(ctx, q, 1, 0, 0, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByAppID(ctx context.Context, groupId string, appID string) ([]datastore.Subscription, error) {
+	subscriptions := make([]datastore.Subscription, 0)
+	err := s.store.findMany(ctx, newQuery().eq("app_id", appID).eq("group_id", groupId), 1, 0, 0, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) FindSubscriptionsBySourceIDs(ctx context.Context, groupId string, sourceId string) ([]datastore.Subscription, error) {
+	subscriptions := make([]datastore.Subscription, 0)
+	err := s.store.findMany(ctx, newQuery().eq("group_id", groupId).eq("source_id", sourceId), 1, 0, 0, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) UpdateSubscriptionStatus(ctx context.Context, groupId string, subscriptionId string, status datastore.SubscriptionStatus) error {
+	q := newQuery().eq("uid", subscriptionId).eq("group_id", groupId).active()
+
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	return s.store.updateOne(ctx, q, update)
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type testAttemptRepo struct {
+	store *store
+}
+
+func NewTestAttemptRepo(db *sql.DB) datastore.TestAttemptRepository {
+	return &testAttemptRepo{
+		store: newStore(db, TestAttemptTable),
+	}
+}
+
+func (t *testAttemptRepo) CreateTestAttempt(ctx context.Context, attempt *datastore.TestAttempt) error {
+	attempt.ID = primitive.NewObjectID()
+	return t.store.insert(ctx, attempt)
+}
+
+func (t *testAttemptRepo) LoadTestAttemptsPaged(ctx context.Context, groupID string, appID string, endpointID string, pageable datastore.Pageable) ([]datastore.TestAttempt, datastore.PaginationData, error) {
+	q := newQuery().eq("group_id", groupID).eq("app_id", appID).eq("endpoint_id", endpointID)
+
+	var attempts []datastore.TestAttempt
+	paginationData, err := t.store.paged(ctx, q, -1, pageable, &attempts)
+	if err != nil {
+		return attempts, datastore.PaginationData{}, err
+	}
+
+	return attempts, paginationData, nil
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// userEmailIndex is the unique index on the emails of active users.
+const userEmailIndex = "users_email_key"
+
+type userRepo struct {
+	store *store
+}
+
+func NewUserRepo(db *sql.DB) datastore.UserRepository {
+	return &userRepo{
+		store: newStore(db, UserTable),
+	}
+}
+
+func (u *userRepo) CreateUser(ctx context.Context, user *datastore.User) error {
+	user.ID = primitive.NewObjectID()
+	user.ResetPasswordToken = uuid.NewString()
+
+	err := u.store.insert(ctx, user)
+	if isUniqueViolation(err, userEmailIndex) {
+		return datastore.ErrDuplicateEmail
+	}
+
+	return err
+}
+
+func (u *userRepo) FindUserByEmail(ctx context.Context, email string) (*datastore.User, error) {
+	return u.findUser(ctx, newQuery().eq("email", email))
+}
+
+func (u *userRepo) FindUserByID(ctx context.Context, id string) (*datastore.User, error) {
+	return u.findUser(ctx, newQuery().eq("uid", id))
+}
+
+func (u *userRepo) FindUserByToken(ctx context.Context, token string) (*datastore.User, error) {
+	return u.findUser(ctx, newQuery().eq("reset_password_token", token))
+}
+
+func (u *userRepo) findUser(ctx context.Context, q *query) (*datastore.User, error) {
+	user := &datastore.User{}
+
+	err := u.store.findOne(ctx, q, user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, datastore.ErrUserNotFound
+	}
+
+	return user, err
+}
+
+func (u *userRepo) LoadUsersPaged(ctx context.Context, pageable datastore.Pageable) ([]datastore.User, datastore.PaginationData, error) {
+	var users []datastore.User
+	paginationData, err := u.store.paged(ctx, newQuery(), -1, pageable, &users)
+	if err != nil {
+		return users, datastore.PaginationData{}, err
+	}
+
+	return users, paginationData, nil
+}
+
+func (u *userRepo) UpdateUser(ctx context.Context, user *datastore.User) error {
+	update := bson.M{
+		"first_name":                user.FirstName,
+		"last_name":                 user.LastName,
+		"email":                     user.Email,
+		"password":                  user.Password,
+		"updated_at":                primitive.NewDateTimeFromTime(time.Now()),
+		"reset_password_token":      user.ResetPasswordToken,
+		"reset_password_expires_at": user.ResetPasswordExpiresAt,
+	}
+
+	err := u.store.updateOne(ctx, newQuery().eq("uid", user.UID), update)
+	if isUniqueViolation(err, userEmailIndex) {
+		return datastore.ErrDuplicateEmail
+	}
+
+	return err
+}
@@ -2,8 +2,33 @@ package datastore
 
 import (
 	"context"
+	"time"
 )
 
+// Database is implemented by each datastore backend, it gives access to
+// the repositories of the backend.
+type Database interface {
+	Disconnect(context.Context) error
+	GetName() string
+	Client() interface{}
+
+	APIRepo() APIKeyRepository
+	GroupRepo() GroupRepository
+	AppRepo() ApplicationRepository
+	EventRepo() EventRepository
+	EventDeliveryRepo() EventDeliveryRepository
+	SubRepo() SubscriptionRepository
+	SourceRepo() SourceRepository
+	OrganisationRepo() OrganisationRepository
+	OrganisationMemberRepo() OrganisationMemberRepository
+	OrganisationInviteRepo() OrganisationInviteRepository
+	UserRepo() UserRepository
+	ConfigurationRepo() ConfigurationRepository
+	ReplayJobRepo() ReplayJobRepository
+	TestAttemptRepo() TestAttemptRepository
+	DeliveryAttemptRepo() DeliveryAttemptRepository
+}
+
 type APIKeyRepository interface {
 	CreateAPIKey(context.Context, *APIKey) error
 	UpdateAPIKey(context.Context, *APIKey) error
@@ -23,10 +48,11 @@ type EventDeliveryRepository interface {
 	UpdateStatusOfEventDelivery(context.Context, EventDelivery, EventDeliveryStatus) error
 	UpdateStatusOfEventDeliveries(context.Context, []string, EventDeliveryStatus) error
 
-	UpdateEventDeliveryWithAttempt(context.Context, EventDelivery, DeliveryAttempt) error
+	UpdateEventDelivery(context.Context, EventDelivery) error
 	CountEventDeliveries(context.Context, string, string, string, []EventDeliveryStatus, SearchParams) (int64, error)
 	DeleteGroupEventDeliveries(ctx context.Context, filter *EventDeliveryFilter, hardDelete bool) error
 	LoadEventDeliveriesPaged(context.Context, string, string, string, []EventDeliveryStatus, SearchParams, Pageable) ([]EventDelivery, PaginationData, error)
+	FindEventDeliveriesBySubscriptionID(ctx context.Context, groupID, subscriptionID string, status EventDeliveryStatus, limit int) ([]EventDelivery, error)
 }
 
 type EventRepository interface {
@@ -36,7 +62,12 @@ type EventRepository interface {
 	FindEventsByIDs(context.Context, []string) ([]Event, error)
 	CountGroupMessages(ctx context.Context, groupID string) (int64, error)
 	LoadEventsPaged(context.Context, string, string, SearchParams, Pageable) ([]Event, PaginationData, error)
+	LoadEventsByFilter(context.Context, *EventFilter, Pageable) ([]Event, error)
+	CountEvents(context.Context, *EventFilter) (int64, error)
 	DeleteGroupEvents(context.Context, *EventFilter, bool) error
+	FindEventByIdempotencyKey(ctx context.Context, sourceID string, key string, since time.Time) (*Event, error)
+	IncrementDuplicateCount(ctx context.Context, id string) error
+	UpdateEventPayload(ctx context.Context, event *Event) error
 }
 
 type GroupRepository interface {
@@ -88,6 +119,7 @@ type ApplicationRepository interface {
 	SearchApplicationsByGroupId(context.Context, string, SearchParams) ([]Application, error)
 	FindApplicationEndpointByID(context.Context, string, string) (*Endpoint, error)
 	CreateApplicationEndpoint(context.Context, string, string, *Endpoint) error
+	FindApplicationByEndpointVerificationToken(context.Context, string) (*Application, error)
 }
 
 type SubscriptionRepository interface {
@@ -105,12 +137,34 @@ type SubscriptionRepository interface {
 type SourceRepository interface {
 	CreateSource(context.Context, *Source) error
 	UpdateSource(ctx context.Context, groupID string, source *Source) error
+	UpdateSourceStatus(ctx context.Context, groupID string, id string, status *SourceStatus) error
 	FindSourceByID(ctx context.Context, groupID string, id string) (*Source, error)
 	FindSourceByMaskID(ctx context.Context, maskID string) (*Source, error)
 	DeleteSourceByID(ctx context.Context, groupID string, id string) error
+	IncrementRejectedRequests(ctx context.Context, groupID string, id string) error
+	UpdateHandshakeNotifiedAt(ctx context.Context, groupID string, id string, notifiedAt time.Time) error
 	LoadSourcesPaged(ctx context.Context, groupID string, filter *SourceFilter, pageable Pageable) ([]Source, PaginationData, error)
 }
 
+type ReplayJobRepository interface {
+	CreateReplayJob(context.Context, *ReplayJob) error
+	UpdateReplayJob(context.Context, *ReplayJob) error
+	FindReplayJobByID(ctx context.Context, groupID string, id string) (*ReplayJob, error)
+	LoadReplayJobsPaged(ctx context.Context, groupID string, pageable Pageable) ([]ReplayJob, PaginationData, error)
+}
+
+type DeliveryAttemptRepository interface {
+	CreateDeliveryAttempt(context.Context, *DeliveryAttempt) error
+	FindDeliveryAttemptByID(ctx context.Context, eventDeliveryID string, id string) (*DeliveryAttempt, error)
+	LoadDeliveryAttemptsPaged(context.Context, *DeliveryAttemptFilter, Pageable) ([]DeliveryAttempt, PaginationData, error)
+	DeleteGroupDeliveryAttempts(ctx context.Context, filter *DeliveryAttemptFilter, hardDelete bool) error
+}
+
+type TestAttemptRepository interface {
+	CreateTestAttempt(context.Context, *TestAttempt) error
+	LoadTestAttemptsPaged(ctx context.Context, groupID string, appID string, endpointID string, pageable Pageable) ([]TestAttempt, PaginationData, error)
+}
+
 type UserRepository interface {
 	CreateUser(context.Context, *User) error
 	UpdateUser(ctx context.Context, user *User) error
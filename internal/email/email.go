@@ -24,7 +24,7 @@ const (
 	TemplateEndpointUpdate     TemplateName = "endpoint.update"
 	TemplateOrganisationInvite TemplateName = "organisation.invite"
 	TemplateResetPassword      TemplateName = "reset.password"
-	TemplateTwitterSource      TemplateName = "twitter.source"
+	TemplateSourceHandshake    TemplateName = "source.handshake"
 )
 
 func (t TemplateName) String() string {
@@ -0,0 +1,174 @@
+package changestream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ChangeStream watches the changes of a MongoDB collection, resuming
+// after the last processed change.
+type ChangeStream struct {
+	client      *mongo.Client
+	collection  *mongo.Collection
+	pipeline    []bson.M
+	resumeToken interface{}
+}
+
+// NewBroker returns the change stream of a db_change_stream source, it
+// resumes from the token in the source's status.
+func NewBroker(source *datastore.Source, consumer string) (pubsub.Broker, error) {
+	cfg := source.DBChangeStream
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+
+	pipeline, err := parsePipeline(cfg.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var resumeToken interface{}
+	if source.Status != nil && !util.IsStringEmpty(source.Status.ResumeToken) {
+		token := bson.M{}
+		err = bson.UnmarshalExtJSON([]byte(source.Status.ResumeToken), false, &token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resume token: %v", err)
+		}
+		resumeToken = token
+	}
+
+	client, err := mongo.NewClient(options.Client().ApplyURI(cfg.URI).SetAppName(consumer))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = client.Connect(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return &ChangeStream{
+		client:      client,
+		collection:  client.Database(cfg.Database).Collection(cfg.Collection),
+		pipeline:    pipeline,
+		resumeToken: resumeToken,
+	}, nil
+}
+
+func (c *ChangeStream) Consume(ctx context.Context, h pubsub.Handler) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if c.resumeToken != nil {
+		opts.SetResumeAfter(c.resumeToken)
+	}
+
+	cs, err := c.collection.Watch(ctx, c.pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer cs.Close(context.Background())
+
+	for cs.Next(ctx) {
+		msg, err := changeMessage(c.collection.Name(), cs.Current, cs.ResumeToken())
+		if err != nil {
+			return err
+		}
+
+		// changes can't be skipped, so the stream stops at the first one
+		// that fails and resumes from it when restarted.
+		if err = h(ctx, msg); err != nil {
+			return err
+		}
+
+		c.resumeToken = cs.ResumeToken()
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return cs.Err()
+}
+
+// Lag is always zero, change streams don't tell how far behind they are.
+func (c *ChangeStream) Lag(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (c *ChangeStream) Close() error {
+	return c.client.Disconnect(context.Background())
+}
+
+// changeMessage sends a change event as relaxed extended JSON with the
+// <collection>.<operationType> event type.
+func changeMessage(collection string, change bson.Raw, resumeToken bson.Raw) (*pubsub.Message, error) {
+	var event struct {
+		OperationType string `bson:"operationType"`
+	}
+
+	if err := bson.Unmarshal(change, &event); err != nil {
+		return nil, err
+	}
+
+	data, err := bson.MarshalExtJSON(change, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := bson.MarshalExtJSON(resumeToken, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pubsub.Message{
+		ID:          string(token),
+		Data:        data,
+		Attributes:  map[string]string{"event_type": fmt.Sprintf("%s.%s", collection, event.OperationType)},
+		ResumeToken: string(token),
+	}, nil
+}
+
+func parsePipeline(pipeline string) ([]bson.M, error) {
+	stages := []bson.M{}
+	if util.IsStringEmpty(pipeline) {
+		return stages, nil
+	}
+
+	err := bson.UnmarshalExtJSON([]byte(pipeline), false, &stages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pipeline: %v", err)
+	}
+
+	return stages, nil
+}
+
+// Validate checks the change stream config of a source.
+func Validate(cfg *datastore.DBChangeStreamConfig) error {
+	if cfg == nil {
+		return errors.New("db change stream config is required for db change stream sources")
+	}
+
+	if err := options.Client().ApplyURI(cfg.URI).Validate(); err != nil {
+		return fmt.Errorf("invalid mongodb uri: %v", err)
+	}
+
+	if util.IsStringEmpty(cfg.Database) {
+		return errors.New("database is required")
+	}
+
+	if util.IsStringEmpty(cfg.Collection) {
+		return errors.New("collection is required")
+	}
+
+	if _, err := parsePipeline(cfg.Pipeline); err != nil {
+		return err
+	}
+
+	return nil
+}
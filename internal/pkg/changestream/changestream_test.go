@@ -0,0 +1,80 @@
+package changestream
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     *datastore.DBChangeStreamConfig
+		wantErr string
+	}{
+		"valid": {
+			cfg: &datastore.DBChangeStreamConfig{
+				URI:        "mongodb://localhost:27017",
+				Database:   "shop",
+				Collection: "users",
+				Pipeline:   `[{"$match": {"operationType": "insert"}}]`,
+			},
+		},
+		"missing_config": {
+			wantErr: "db change stream config is required for db change stream sources",
+		},
+		"invalid_uri": {
+			cfg:     &datastore.DBChangeStreamConfig{URI: "localhost", Database: "shop", Collection: "users"},
+			wantErr: "invalid mongodb uri",
+		},
+		"missing_database": {
+			cfg:     &datastore.DBChangeStreamConfig{URI: "mongodb://localhost:27017", Collection: "users"},
+			wantErr: "database is required",
+		},
+		"missing_collection": {
+			cfg:     &datastore.DBChangeStreamConfig{URI: "mongodb://localhost:27017", Database: "shop"},
+			wantErr: "collection is required",
+		},
+		"invalid_pipeline": {
+			cfg: &datastore.DBChangeStreamConfig{
+				URI:        "mongodb://localhost:27017",
+				Database:   "shop",
+				Collection: "users",
+				Pipeline:   `{"$match": `,
+			},
+			wantErr: "invalid pipeline",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.cfg)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_changeMessage(t *testing.T) {
+	change, err := bson.Marshal(bson.D{
+		{Key: "operationType", Value: "insert"},
+		{Key: "fullDocument", Value: bson.D{{Key: "name", Value: "daniel"}}},
+	})
+	require.NoError(t, err)
+
+	token, err := bson.Marshal(bson.D{{Key: "_data", Value: "8263"}})
+	require.NoError(t, err)
+
+	msg, err := changeMessage("users", change, token)
+	require.NoError(t, err)
+
+	require.Equal(t, "users.insert", msg.Attributes["event_type"])
+	require.Equal(t, `{"_data":"8263"}`, msg.ResumeToken)
+	require.JSONEq(t, `{"operationType":"insert","fullDocument":{"name":"daniel"}}`, string(msg.Data))
+}
@@ -0,0 +1,285 @@
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	objectstore "github.com/frain-dev/convoy/datastore/object-store"
+)
+
+// configTTL is how long the storage policy is used before it is loaded
+// again, so that changes to it are picked up without loading it for
+// every event.
+const configTTL = time.Minute
+
+var ErrNoObjectStore = errors.New("no storage policy is configured to load offloaded payloads")
+
+// Store writes event payloads above the offload threshold of the storage
+// policy to its object store once, the event and its deliveries keep the
+// reference of the payload, and loads them back when they are needed.
+//
+// A reference is the location of the object store and the key of the
+// payload, <storage type>:<bucket or directory>#<key>, so payloads are
+// still loaded after the storage policy is changed. Payloads offloaded to
+// another bucket are loaded with the credentials of the storage policy.
+type Store struct {
+	configRepo datastore.ConfigurationRepository
+	newClient  func(*datastore.StoragePolicyConfiguration) (objectstore.ObjectStore, error)
+
+	mu        sync.Mutex
+	policy    *datastore.StoragePolicyConfiguration
+	client    objectstore.ObjectStore
+	expiresAt time.Time
+}
+
+func NewStore(configRepo datastore.ConfigurationRepository) *Store {
+	return &Store{configRepo: configRepo, newClient: newClient}
+}
+
+// Offload writes the data and the raw body of event to the object store
+// when they are above the offload threshold and replaces them with their
+// references.
+func (s *Store) Offload(ctx context.Context, event *datastore.Event) error {
+	if len(event.Data) == 0 && len(event.Raw) == 0 {
+		return nil
+	}
+
+	policy, client, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if client == nil || policy.PayloadOffloadThreshold <= 0 {
+		return nil
+	}
+
+	if event.DataRef == "" && int64(len(event.Data)) > policy.PayloadOffloadThreshold {
+		key := fmt.Sprintf("payloads/%s/%s.json", event.GroupID, event.UID)
+		if err = client.Put(key, event.Data); err != nil {
+			return err
+		}
+
+		event.Data, event.DataRef = nil, newRef(policy, key)
+	}
+
+	if event.RawRef == "" && int64(len(event.Raw)) > policy.PayloadOffloadThreshold {
+		key := fmt.Sprintf("payloads/%s/%s.raw", event.GroupID, event.UID)
+		if err = client.Put(key, event.Raw); err != nil {
+			return err
+		}
+
+		event.Raw, event.RawRef = nil, newRef(policy, key)
+	}
+
+	return nil
+}
+
+// Load returns the payload stored under ref.
+func (s *Store) Load(ctx context.Context, ref string) (json.RawMessage, error) {
+	client, key, err := s.clientOf(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Get(key)
+}
+
+// Delete removes the payloads stored under refs.
+func (s *Store) Delete(ctx context.Context, refs ...string) error {
+	for _, ref := range refs {
+		client, key, err := s.clientOf(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		if err = client.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadEvent sets the data and the raw body of event from the object store
+// when they were offloaded.
+func (s *Store) LoadEvent(ctx context.Context, event *datastore.Event) error {
+	if event.DataRef != "" {
+		data, err := s.Load(ctx, event.DataRef)
+		if err != nil {
+			return err
+		}
+
+		event.Data = data
+	}
+
+	if event.RawRef != "" {
+		raw, err := s.Load(ctx, event.RawRef)
+		if err != nil {
+			return err
+		}
+
+		event.Raw = raw
+	}
+
+	return nil
+}
+
+// LoadEventDelivery sets the data and the raw body of the metadata of
+// eventDelivery from the object store when they were offloaded.
+func (s *Store) LoadEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery) error {
+	if eventDelivery.Metadata == nil {
+		return nil
+	}
+
+	if eventDelivery.Metadata.DataRef != "" {
+		data, err := s.Load(ctx, eventDelivery.Metadata.DataRef)
+		if err != nil {
+			return err
+		}
+
+		eventDelivery.Metadata.Data = data
+	}
+
+	if eventDelivery.Metadata.RawRef != "" {
+		raw, err := s.Load(ctx, eventDelivery.Metadata.RawRef)
+		if err != nil {
+			return err
+		}
+
+		eventDelivery.Metadata.Raw = raw
+	}
+
+	return nil
+}
+
+// newRef returns the reference of key in the object store of policy.
+func newRef(policy *datastore.StoragePolicyConfiguration, key string) string {
+	return fmt.Sprintf("%s:%s#%s", policy.Type, location(policy), key)
+}
+
+// location returns the bucket or the directory payloads are written to
+// by the object store of policy.
+func location(policy *datastore.StoragePolicyConfiguration) string {
+	switch {
+	case policy.Type == datastore.S3 && policy.S3 != nil:
+		return policy.S3.Bucket
+	case policy.Type == datastore.OnPrem && policy.OnPrem != nil:
+		return policy.OnPrem.Path
+	default:
+		return ""
+	}
+}
+
+// clientOf returns the client of the object store ref is stored in and
+// the key of ref. Refs without a location were written before locations
+// were recorded and are read from the current object store.
+func (s *Store) clientOf(ctx context.Context, ref string) (objectstore.ObjectStore, string, error) {
+	policy, client, err := s.load(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	i := strings.LastIndex(ref, "#")
+	if i < 0 {
+		if client == nil {
+			return nil, "", ErrNoObjectStore
+		}
+
+		return client, ref, nil
+	}
+
+	key := ref[i+1:]
+	storageType, loc := ref[:i], ""
+	if j := strings.Index(storageType, ":"); j >= 0 {
+		storageType, loc = storageType[:j], storageType[j+1:]
+	}
+
+	if client != nil && storageType == string(policy.Type) && loc == location(policy) {
+		return client, key, nil
+	}
+
+	var p *datastore.StoragePolicyConfiguration
+	switch datastore.StorageType(storageType) {
+	case datastore.OnPrem:
+		p = &datastore.StoragePolicyConfiguration{Type: datastore.OnPrem, OnPrem: &datastore.OnPremStorage{Path: loc}}
+	case datastore.S3:
+		if policy == nil || policy.Type != datastore.S3 || policy.S3 == nil {
+			return nil, "", fmt.Errorf("%w: the payload is stored in s3 bucket %s", ErrNoObjectStore, loc)
+		}
+
+		s3 := *policy.S3
+		s3.Bucket = loc
+		p = &datastore.StoragePolicyConfiguration{Type: datastore.S3, S3: &s3}
+	default:
+		return nil, "", fmt.Errorf("invalid payload reference %s", ref)
+	}
+
+	client, err = s.newClient(p)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, key, nil
+}
+
+// load returns the storage policy and its object store client, the client
+// is nil when no storage policy is configured.
+func (s *Store) load(ctx context.Context) (*datastore.StoragePolicyConfiguration, objectstore.ObjectStore, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Now().Before(s.expiresAt) {
+		return s.policy, s.client, nil
+	}
+
+	cfg, err := s.configRepo.LoadConfiguration(ctx)
+	if err != nil && !errors.Is(err, datastore.ErrConfigNotFound) {
+		return nil, nil, err
+	}
+
+	s.policy, s.client = nil, nil
+	if err == nil && cfg.StoragePolicy != nil {
+		client, err := s.newClient(cfg.StoragePolicy)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		s.policy, s.client = cfg.StoragePolicy, client
+	}
+
+	s.expiresAt = time.Now().Add(configTTL)
+	return s.policy, s.client, nil
+}
+
+func newClient(policy *datastore.StoragePolicyConfiguration) (objectstore.ObjectStore, error) {
+	switch policy.Type {
+	case datastore.S3:
+		if policy.S3 == nil {
+			return nil, errors.New("s3 storage policy has no s3 configuration")
+		}
+
+		return objectstore.NewS3Client(objectstore.ObjectStoreOptions{
+			Bucket:       policy.S3.Bucket,
+			AccessKey:    policy.S3.AccessKey,
+			SecretKey:    policy.S3.SecretKey,
+			SessionToken: policy.S3.SessionToken,
+			Region:       policy.S3.Region,
+		})
+	case datastore.OnPrem:
+		if policy.OnPrem == nil {
+			return nil, errors.New("on prem storage policy has no on prem configuration")
+		}
+
+		return objectstore.NewOnPremClient(objectstore.ObjectStoreOptions{
+			OnPremStorageDir: policy.OnPrem.Path,
+		})
+	default:
+		return nil, errors.New("invalid storage policy")
+	}
+}
@@ -0,0 +1,109 @@
+package claimcheck
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	objectstore "github.com/frain-dev/convoy/datastore/object-store"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func onPremPolicy(dir string) *datastore.Configuration {
+	return &datastore.Configuration{
+		StoragePolicy: &datastore.StoragePolicyConfiguration{
+			Type:                    datastore.OnPrem,
+			OnPrem:                  &datastore.OnPremStorage{Path: dir},
+			PayloadOffloadThreshold: 16,
+		},
+	}
+}
+
+func TestStore_Offload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Times(1).Return(onPremPolicy(dir), nil)
+
+	store := NewStore(configRepo)
+
+	small := &datastore.Event{UID: "small", GroupID: "group", Data: json.RawMessage(`{"a":1}`)}
+	require.NoError(t, store.Offload(context.Background(), small))
+	require.Empty(t, small.DataRef)
+	require.Equal(t, json.RawMessage(`{"a":1}`), small.Data)
+
+	data := json.RawMessage(`{"name":"a payload above the threshold"}`)
+	raw := []byte("name=a payload above the threshold")
+	large := &datastore.Event{UID: "large", GroupID: "group", Data: data, Raw: raw}
+	require.NoError(t, store.Offload(context.Background(), large))
+	require.Equal(t, "on_prem:"+dir+"#payloads/group/large.json", large.DataRef)
+	require.Equal(t, "on_prem:"+dir+"#payloads/group/large.raw", large.RawRef)
+	require.Empty(t, large.Data)
+	require.Empty(t, large.Raw)
+
+	require.NoError(t, store.LoadEvent(context.Background(), large))
+	require.Equal(t, data, large.Data)
+	require.Equal(t, raw, large.Raw)
+
+	delivery := &datastore.EventDelivery{Metadata: &datastore.Metadata{DataRef: large.DataRef, RawRef: large.RawRef}}
+	require.NoError(t, store.LoadEventDelivery(context.Background(), delivery))
+	require.Equal(t, data, delivery.Metadata.Data)
+	require.Equal(t, raw, delivery.Metadata.Raw)
+
+	_, err := store.Load(context.Background(), "payloads/group/missing.json")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+	require.NoError(t, store.Delete(context.Background(), large.DataRef, large.RawRef))
+
+	_, err = store.Load(context.Background(), large.DataRef)
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+	// deleting a payload again isn't an error.
+	require.NoError(t, store.Delete(context.Background(), large.DataRef))
+}
+
+func TestStore_LoadAfterStoragePolicyChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Times(1).Return(onPremPolicy(dir), nil)
+
+	data := json.RawMessage(`{"name":"a payload above the threshold"}`)
+	event := &datastore.Event{UID: "event", GroupID: "group", Data: data}
+	require.NoError(t, NewStore(configRepo).Offload(context.Background(), event))
+	require.NotEmpty(t, event.DataRef)
+
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Times(1).Return(onPremPolicy(t.TempDir()), nil)
+
+	store := NewStore(configRepo)
+	require.NoError(t, store.LoadEvent(context.Background(), event))
+	require.Equal(t, data, event.Data)
+}
+
+func TestStore_OffloadWithoutStoragePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Times(1).Return(nil, datastore.ErrConfigNotFound)
+
+	store := NewStore(configRepo)
+
+	event := &datastore.Event{UID: "event", GroupID: "group", Data: json.RawMessage(`{"name":"a payload"}`)}
+	require.NoError(t, store.Offload(context.Background(), event))
+	require.Empty(t, event.DataRef)
+	require.NotEmpty(t, event.Data)
+
+	// events that weren't offloaded don't need the object store.
+	require.NoError(t, store.LoadEvent(context.Background(), event))
+
+	_, err := store.Load(context.Background(), "payloads/group/event.json")
+	require.ErrorIs(t, err, ErrNoObjectStore)
+}
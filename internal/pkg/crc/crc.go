@@ -1,10 +1,14 @@
 package crc
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,8 +17,51 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrMissingChallenge   = errors.New("challenge is missing from the request")
+	ErrInvalidVerifyToken = errors.New("invalid verify token")
+)
+
+// Crc answers the challenge requests providers send to confirm they own
+// a source's URL, which must not be ingested as events.
 type Crc interface {
-	HandleRequest(w http.ResponseWriter, r *http.Request, source *datastore.Source, sourceRepo datastore.SourceRepository) error
+	// IsChallenge reports whether r, with its body, is a challenge request.
+	IsChallenge(r *http.Request, payload []byte) bool
+
+	// HandleRequest answers the challenge and records the handshake on
+	// the source.
+	HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error
+}
+
+// recordHandshake saves the time of the source's last successful handshake.
+func recordHandshake(ctx context.Context, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+	source.LastHandshakeAt = primitive.NewDateTimeFromTime(time.Now())
+	return sourceRepo.UpdateSource(ctx, source.GroupID, source)
+}
+
+func hasQuery(r *http.Request, key string) bool {
+	_, ok := r.URL.Query()[key]
+	return ok
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(data)
+	return err
+}
+
+func writeText(w http.ResponseWriter, text string) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write([]byte(text))
+	return err
 }
 
 type TwitterCrc struct {
@@ -29,7 +76,11 @@ func NewTwitterCrc(secret string) *TwitterCrc {
 	return &TwitterCrc{secret: secret}
 }
 
-func (tc *TwitterCrc) HandleRequest(w http.ResponseWriter, r *http.Request, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+func (tc *TwitterCrc) IsChallenge(r *http.Request, payload []byte) bool {
+	return r.Method == http.MethodGet && hasQuery(r, "crc_token")
+}
+
+func (tc *TwitterCrc) HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
 	crcToken := r.URL.Query().Get("crc_token")
 
 	h := hmac.New(sha256.New, []byte(tc.secret))
@@ -39,23 +90,192 @@ func (tc *TwitterCrc) HandleRequest(w http.ResponseWriter, r *http.Request, sour
 	re := fmt.Sprintf("sha256=%s", computedMac)
 	tr := &TwitterCrcResponse{ResponseToken: re}
 
-	data, err := json.Marshal(tr)
+	if source.ProviderConfig != nil && source.ProviderConfig.Twitter != nil {
+		source.ProviderConfig.Twitter.CrcVerifiedAt = primitive.NewDateTimeFromTime(time.Now())
+	}
+
+	err := recordHandshake(r.Context(), source, sourceRepo)
 	if err != nil {
 		return err
 	}
 
-	source.ProviderConfig.Twitter.CrcVerifiedAt = primitive.NewDateTimeFromTime(time.Now())
-	err = sourceRepo.UpdateSource(r.Context(), source.GroupID, source)
+	return writeJSON(w, tr)
+}
+
+// ZoomCrc answers Zoom's endpoint.url_validation events.
+type ZoomCrc struct {
+	secret string
+}
+
+type zoomChallenge struct {
+	Event   string `json:"event"`
+	Payload struct {
+		PlainToken string `json:"plainToken"`
+	} `json:"payload"`
+}
+
+type ZoomCrcResponse struct {
+	PlainToken     string `json:"plainToken"`
+	EncryptedToken string `json:"encryptedToken"`
+}
+
+func NewZoomCrc(secret string) *ZoomCrc {
+	return &ZoomCrc{secret: secret}
+}
+
+func (zc *ZoomCrc) IsChallenge(r *http.Request, payload []byte) bool {
+	var c zoomChallenge
+	if r.Method != http.MethodPost || json.Unmarshal(payload, &c) != nil {
+		return false
+	}
+
+	return c.Event == "endpoint.url_validation"
+}
+
+func (zc *ZoomCrc) HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+	var c zoomChallenge
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return err
+	}
+
+	if len(c.Payload.PlainToken) == 0 {
+		return ErrMissingChallenge
+	}
+
+	h := hmac.New(sha256.New, []byte(zc.secret))
+	h.Write([]byte(c.Payload.PlainToken))
+
+	err := recordHandshake(r.Context(), source, sourceRepo)
 	if err != nil {
 		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, err = w.Write(data)
+	return writeJSON(w, &ZoomCrcResponse{
+		PlainToken:     c.Payload.PlainToken,
+		EncryptedToken: hex.EncodeToString(h.Sum(nil)),
+	})
+}
+
+// DropboxCrc echoes the challenge query parameter of Dropbox's GET requests.
+type DropboxCrc struct{}
+
+func NewDropboxCrc() *DropboxCrc {
+	return &DropboxCrc{}
+}
+
+func (dc *DropboxCrc) IsChallenge(r *http.Request, payload []byte) bool {
+	return r.Method == http.MethodGet && hasQuery(r, "challenge")
+}
+
+func (dc *DropboxCrc) HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+	challenge := r.URL.Query().Get("challenge")
+	if len(challenge) == 0 {
+		return ErrMissingChallenge
+	}
+
+	err := recordHandshake(r.Context(), source, sourceRepo)
+	if err != nil {
+		return err
+	}
+
+	return writeText(w, challenge)
+}
+
+// MSGraphCrc echoes the validationToken query parameter Microsoft Graph
+// sends when a subscription is created.
+type MSGraphCrc struct{}
+
+func NewMSGraphCrc() *MSGraphCrc {
+	return &MSGraphCrc{}
+}
+
+func (mc *MSGraphCrc) IsChallenge(r *http.Request, payload []byte) bool {
+	return hasQuery(r, "validationToken")
+}
+
+func (mc *MSGraphCrc) HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+	token := r.URL.Query().Get("validationToken")
+	if len(token) == 0 {
+		return ErrMissingChallenge
+	}
+
+	err := recordHandshake(r.Context(), source, sourceRepo)
+	if err != nil {
+		return err
+	}
+
+	return writeText(w, token)
+}
+
+// SlackCrc answers Slack's url_verification events.
+type SlackCrc struct{}
+
+type slackChallenge struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+}
+
+func NewSlackCrc() *SlackCrc {
+	return &SlackCrc{}
+}
+
+func (sc *SlackCrc) IsChallenge(r *http.Request, payload []byte) bool {
+	var c slackChallenge
+	if r.Method != http.MethodPost || json.Unmarshal(payload, &c) != nil {
+		return false
+	}
+
+	return c.Type == "url_verification"
+}
+
+func (sc *SlackCrc) HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+	var c slackChallenge
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return err
+	}
+
+	if len(c.Challenge) == 0 {
+		return ErrMissingChallenge
+	}
+
+	err := recordHandshake(r.Context(), source, sourceRepo)
+	if err != nil {
+		return err
+	}
+
+	return writeText(w, c.Challenge)
+}
+
+// MetaCrc answers Meta's hub.mode=subscribe requests after checking the
+// verify token configured on the source.
+type MetaCrc struct{}
+
+func NewMetaCrc() *MetaCrc {
+	return &MetaCrc{}
+}
+
+func (mc *MetaCrc) IsChallenge(r *http.Request, payload []byte) bool {
+	return r.Method == http.MethodGet && r.URL.Query().Get("hub.mode") == "subscribe"
+}
+
+func (mc *MetaCrc) HandleRequest(w http.ResponseWriter, r *http.Request, payload []byte, source *datastore.Source, sourceRepo datastore.SourceRepository) error {
+	query := r.URL.Query()
+
+	challenge := query.Get("hub.challenge")
+	if len(challenge) == 0 {
+		return ErrMissingChallenge
+	}
+
+	if source.ProviderConfig == nil || source.ProviderConfig.Meta == nil ||
+		len(source.ProviderConfig.Meta.VerifyToken) == 0 ||
+		subtle.ConstantTimeCompare([]byte(query.Get("hub.verify_token")), []byte(source.ProviderConfig.Meta.VerifyToken)) != 1 {
+		return ErrInvalidVerifyToken
+	}
+
+	err := recordHandshake(r.Context(), source, sourceRepo)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return writeText(w, challenge)
 }
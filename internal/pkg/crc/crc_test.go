@@ -1,9 +1,9 @@
 package crc
 
 import (
-	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -12,60 +12,169 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func Test_TwitterCrc_HandleRequest(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	sourceRepo := mocks.NewMockSourceRepository(ctrl)
-
+func Test_Crc_HandleRequest(t *testing.T) {
 	tests := map[string]struct {
-		secret    string
-		requestFn func(t *testing.T) (*httptest.ResponseRecorder, *http.Request)
-		source    *datastore.Source
-		dbFn      func(so *mocks.MockSourceRepository)
-		wantToken string
+		crc             Crc
+		method          string
+		url             string
+		payload         string
+		source          *datastore.Source
+		wantChallenge   bool
+		wantErr         error
+		wantContentType string
+		wantBody        string
 	}{
-		"valid_token": {
-			secret: "Convoy",
-			requestFn: func(t *testing.T) (*httptest.ResponseRecorder, *http.Request) {
-				req, err := http.NewRequest("GET", "URL?crc_token=uzwcfYtzr9", nil)
-				require.NoError(t, err)
-
-				w := httptest.NewRecorder()
-				return w, req
-			},
+		"twitter": {
+			crc:             NewTwitterCrc("Convoy"),
+			method:          http.MethodGet,
+			url:             "URL?crc_token=uzwcfYtzr9",
+			wantChallenge:   true,
+			wantContentType: "application/json",
+			wantBody:        `{"response_token":"sha256=HXvxTdsfShG6k2zC9NVANwFquJBdOugRYHax2vNiiOo="}`,
+		},
+		"twitter_event": {
+			crc:    NewTwitterCrc("Convoy"),
+			method: http.MethodPost,
+			url:    "URL",
+		},
+		"zoom": {
+			crc:             NewZoomCrc("Convoy"),
+			method:          http.MethodPost,
+			url:             "URL",
+			payload:         `{"event":"endpoint.url_validation","payload":{"plainToken":"qgg8vlvZRS6UYooatFL8Aw"}}`,
+			wantChallenge:   true,
+			wantContentType: "application/json",
+			wantBody:        `{"plainToken":"qgg8vlvZRS6UYooatFL8Aw","encryptedToken":"b00d4b8ef0c0e62547c8458bcf37667b9e1f761635cbd3928cf06f96cd021021"}`,
+		},
+		"zoom_event": {
+			crc:     NewZoomCrc("Convoy"),
+			method:  http.MethodPost,
+			url:     "URL",
+			payload: `{"event":"meeting.started"}`,
+		},
+		"zoom_without_token": {
+			crc:           NewZoomCrc("Convoy"),
+			method:        http.MethodPost,
+			url:           "URL",
+			payload:       `{"event":"endpoint.url_validation","payload":{}}`,
+			wantChallenge: true,
+			wantErr:       ErrMissingChallenge,
+		},
+		"dropbox": {
+			crc:             NewDropboxCrc(),
+			method:          http.MethodGet,
+			url:             "URL?challenge=a1b2c3",
+			wantChallenge:   true,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "a1b2c3",
+		},
+		"msgraph": {
+			crc:             NewMSGraphCrc(),
+			method:          http.MethodPost,
+			url:             "URL?validationToken=Validation%3A+Testing+client+application",
+			wantChallenge:   true,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "Validation: Testing client application",
+		},
+		"slack": {
+			crc:             NewSlackCrc(),
+			method:          http.MethodPost,
+			url:             "URL",
+			payload:         `{"token":"Jhj5dZrVaK7ZwHHjRyZWjbDl","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P","type":"url_verification"}`,
+			wantChallenge:   true,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P",
+		},
+		"slack_event": {
+			crc:     NewSlackCrc(),
+			method:  http.MethodPost,
+			url:     "URL",
+			payload: `{"type":"event_callback"}`,
+		},
+		"meta": {
+			crc:    NewMetaCrc(),
+			method: http.MethodGet,
+			url:    "URL?hub.mode=subscribe&hub.challenge=1158201444&hub.verify_token=meta-token",
 			source: &datastore.Source{
-				UID:     "123",
-				GroupID: "abc",
-				ProviderConfig: &datastore.ProviderConfig{
-					Twitter: &datastore.TwitterProviderConfig{},
-				},
+				ProviderConfig: &datastore.ProviderConfig{Meta: &datastore.MetaProviderConfig{VerifyToken: "meta-token"}},
 			},
-			dbFn: func(so *mocks.MockSourceRepository) {
-				so.EXPECT().UpdateSource(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			wantChallenge:   true,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody:        "1158201444",
+		},
+		"meta_invalid_verify_token": {
+			crc:    NewMetaCrc(),
+			method: http.MethodGet,
+			url:    "URL?hub.mode=subscribe&hub.challenge=1158201444&hub.verify_token=wrong-token",
+			source: &datastore.Source{
+				ProviderConfig: &datastore.ProviderConfig{Meta: &datastore.MetaProviderConfig{VerifyToken: "meta-token"}},
 			},
-			wantToken: "sha256=HXvxTdsfShG6k2zC9NVANwFquJBdOugRYHax2vNiiOo=",
+			wantChallenge: true,
+			wantErr:       ErrInvalidVerifyToken,
 		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			c := NewTwitterCrc(tc.secret)
-			w, r := tc.requestFn(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-			if tc.dbFn != nil {
-				tc.dbFn(sourceRepo)
-			}
+			sourceRepo := mocks.NewMockSourceRepository(ctrl)
 
-			err := c.HandleRequest(w, r, tc.source, sourceRepo)
+			r, err := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.payload))
 			require.NoError(t, err)
+			w := httptest.NewRecorder()
 
-			var response TwitterCrcResponse
-			err = json.NewDecoder(w.Body).Decode(&response)
-			require.NoError(t, err)
+			payload := []byte(tc.payload)
+			require.Equal(t, tc.wantChallenge, tc.crc.IsChallenge(r, payload))
+			if !tc.wantChallenge {
+				return
+			}
+
+			source := tc.source
+			if source == nil {
+				source = &datastore.Source{}
+			}
+			source.UID, source.GroupID = "123", "abc"
+
+			if tc.wantErr == nil {
+				sourceRepo.EXPECT().UpdateSource(gomock.Any(), "abc", source).Return(nil)
+			}
 
+			err = tc.crc.HandleRequest(w, r, payload, source, sourceRepo)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotZero(t, source.LastHandshakeAt)
 			require.Equal(t, http.StatusOK, w.Code)
-			require.Equal(t, tc.wantToken, response.ResponseToken)
+			require.Equal(t, tc.wantContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, tc.wantBody, w.Body.String())
 		})
 	}
 }
+
+func Test_TwitterCrc_RecordsCrcVerifiedAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	sourceRepo.EXPECT().UpdateSource(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	source := &datastore.Source{
+		UID:     "123",
+		GroupID: "abc",
+		ProviderConfig: &datastore.ProviderConfig{
+			Twitter: &datastore.TwitterProviderConfig{},
+		},
+	}
+
+	r, err := http.NewRequest(http.MethodGet, "URL?crc_token=uzwcfYtzr9", nil)
+	require.NoError(t, err)
+
+	err = NewTwitterCrc("Convoy").HandleRequest(httptest.NewRecorder(), r, nil, source, sourceRepo)
+	require.NoError(t, err)
+	require.NotZero(t, source.ProviderConfig.Twitter.CrcVerifiedAt)
+}
@@ -0,0 +1,231 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/frain-dev/convoy/config"
+)
+
+// prefix marks encrypted values, values without it are plaintext secrets
+// stored before encryption was enabled.
+const prefix = "enc:v1:"
+
+const dataKeySize = 32
+
+var (
+	ErrNoKeyProvider = errors.New("no encryption key provider is configured")
+	ErrKeyNotFound   = errors.New("encryption key not found")
+	ErrMalformed     = errors.New("malformed encrypted value")
+)
+
+// KeyProvider wraps the data keys values are encrypted with under its key
+// encryption keys, it keeps the keys replaced by a rotation so the values
+// encrypted before it can still be decrypted.
+type KeyProvider interface {
+	// PrimaryKeyID returns the id of the key new data keys are wrapped with.
+	PrimaryKeyID() string
+	WrapKey(dataKey []byte) (keyID string, wrapped []byte, err error)
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// Cipher encrypts values with a data key of their own, the data key is
+// wrapped by the key provider and stored with the value:
+//
+//	enc:v1:<key id>:<wrapped data key>:<nonce and ciphertext>
+type Cipher struct {
+	provider KeyProvider
+}
+
+// New returns the cipher of the configured key provider, values aren't
+// encrypted when no provider is configured.
+func New(cfg config.EncryptionConfiguration) (*Cipher, error) {
+	switch cfg.Type {
+	case config.LocalEncryptionProvider:
+		provider, err := NewLocalKeyProvider(cfg.Local.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCipher(provider), nil
+	case "":
+		return NewCipher(nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported encryption provider: %s", cfg.Type)
+	}
+}
+
+// NewCipher returns a cipher wrapping its data keys with provider, a nil
+// provider leaves values in plaintext.
+func NewCipher(provider KeyProvider) *Cipher {
+	return &Cipher{provider: provider}
+}
+
+// Enabled reports whether the cipher has a key provider to encrypt with.
+func (c *Cipher) Enabled() bool {
+	return c.provider != nil
+}
+
+// IsEncrypted reports whether value was encrypted by a cipher.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Encrypt encrypts value with the primary key of the provider, empty and
+// already encrypted values are returned unchanged, as is every value when
+// the cipher has no provider.
+func (c *Cipher) Encrypt(value string) (string, error) {
+	if value == "" || IsEncrypted(value) || !c.Enabled() {
+		return value, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+
+	sealed, err := seal(dataKey, []byte(value), nil)
+	if err != nil {
+		return "", err
+	}
+
+	keyID, wrapped, err := c.provider.WrapKey(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(keyID, ":") {
+		return "", fmt.Errorf("invalid encryption key id: %s", keyID)
+	}
+
+	enc := base64.RawURLEncoding
+	return prefix + keyID + ":" + enc.EncodeToString(wrapped) + ":" + enc.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of value, values that weren't encrypted
+// are returned unchanged.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	if !c.Enabled() {
+		return "", ErrNoKeyProvider
+	}
+
+	keyID, wrapped, sealed, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := c.provider.UnwrapKey(keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataKey, sealed, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rotate encrypts value again with the primary key of the provider when
+// it is in plaintext or was encrypted with another key, it reports whether
+// value was changed.
+func (c *Cipher) Rotate(value string) (string, bool, error) {
+	if value == "" {
+		return value, false, nil
+	}
+
+	if !c.Enabled() {
+		return "", false, ErrNoKeyProvider
+	}
+
+	if IsEncrypted(value) {
+		keyID, _, _, err := parse(value)
+		if err != nil {
+			return "", false, err
+		}
+
+		if keyID == c.provider.PrimaryKeyID() {
+			return value, false, nil
+		}
+	}
+
+	plaintext, err := c.Decrypt(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	rotated, err := c.Encrypt(plaintext)
+	if err != nil {
+		return "", false, err
+	}
+
+	return rotated, true, nil
+}
+
+func parse(value string) (keyID string, wrapped []byte, sealed []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, nil, ErrMalformed
+	}
+
+	enc := base64.RawURLEncoding
+	if wrapped, err = enc.DecodeString(parts[1]); err != nil {
+		return "", nil, nil, ErrMalformed
+	}
+
+	if sealed, err = enc.DecodeString(parts[2]); err != nil {
+		return "", nil, nil, ErrMalformed
+	}
+
+	return parts[0], wrapped, sealed, nil
+}
+
+// seal encrypts plaintext with AES-GCM under key, the nonce is prepended
+// to the ciphertext.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
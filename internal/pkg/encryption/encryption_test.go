@@ -0,0 +1,138 @@
+package encryption
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T) (*Cipher, string) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	_, err := GenerateLocalKey(path)
+	require.NoError(t, err)
+
+	c, err := New(config.EncryptionConfiguration{
+		Type:  config.LocalEncryptionProvider,
+		Local: config.LocalEncryptionConfiguration{KeyFile: path},
+	})
+	require.NoError(t, err)
+
+	return c, path
+}
+
+func TestCipher_EncryptDecrypt(t *testing.T) {
+	c, _ := newTestCipher(t)
+
+	value, err := c.Encrypt("1234")
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(value))
+	require.NotContains(t, value, "1234")
+
+	// encrypting twice gives different values.
+	other, err := c.Encrypt("1234")
+	require.NoError(t, err)
+	require.NotEqual(t, value, other)
+
+	// encrypted values aren't encrypted again.
+	again, err := c.Encrypt(value)
+	require.NoError(t, err)
+	require.Equal(t, value, again)
+
+	plaintext, err := c.Decrypt(value)
+	require.NoError(t, err)
+	require.Equal(t, "1234", plaintext)
+
+	// values stored before encryption was enabled are read as is.
+	plaintext, err = c.Decrypt("1234")
+	require.NoError(t, err)
+	require.Equal(t, "1234", plaintext)
+
+	empty, err := c.Encrypt("")
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	_, err = c.Decrypt(value[:len(value)-4] + "AAAA")
+	require.Error(t, err)
+
+	_, err = c.Decrypt(prefix + "key:abc")
+	require.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestCipher_WithoutProvider(t *testing.T) {
+	c, err := New(config.EncryptionConfiguration{})
+	require.NoError(t, err)
+	require.False(t, c.Enabled())
+
+	value, err := c.Encrypt("1234")
+	require.NoError(t, err)
+	require.Equal(t, "1234", value)
+
+	encrypted, _ := newTestCipher(t)
+	value, err = encrypted.Encrypt("1234")
+	require.NoError(t, err)
+
+	_, err = c.Decrypt(value)
+	require.ErrorIs(t, err, ErrNoKeyProvider)
+
+	_, _, err = c.Rotate("1234")
+	require.ErrorIs(t, err, ErrNoKeyProvider)
+}
+
+func TestCipher_Rotate(t *testing.T) {
+	c, path := newTestCipher(t)
+
+	old, err := c.Encrypt("1234")
+	require.NoError(t, err)
+
+	// values encrypted with the primary key are left as they are.
+	value, rotated, err := c.Rotate(old)
+	require.NoError(t, err)
+	require.False(t, rotated)
+	require.Equal(t, old, value)
+
+	key, err := GenerateLocalKey(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, key.Version)
+
+	// a process that loaded the key file before the rotation can decrypt
+	// values encrypted with the new key.
+	rotatedCipher, err := New(config.EncryptionConfiguration{
+		Type:  config.LocalEncryptionProvider,
+		Local: config.LocalEncryptionConfiguration{KeyFile: path},
+	})
+	require.NoError(t, err)
+
+	value, rotated, err = rotatedCipher.Rotate(old)
+	require.NoError(t, err)
+	require.True(t, rotated)
+	require.True(t, strings.HasPrefix(value, prefix+key.ID+":"))
+
+	plaintext, err := c.Decrypt(value)
+	require.NoError(t, err)
+	require.Equal(t, "1234", plaintext)
+
+	// plaintext values are encrypted.
+	value, rotated, err = rotatedCipher.Rotate("5678")
+	require.NoError(t, err)
+	require.True(t, rotated)
+	require.True(t, IsEncrypted(value))
+}
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	_, err := NewLocalKeyProvider(filepath.Join(t.TempDir(), "keys.json"))
+	require.Error(t, err)
+
+	_, path := newTestCipher(t)
+	_, err = GenerateLocalKey(path)
+	require.NoError(t, err)
+
+	p, err := NewLocalKeyProvider(path)
+	require.NoError(t, err)
+	require.Len(t, p.keys, 2)
+	require.Equal(t, 2, p.primary.Version)
+	require.Equal(t, p.primary.ID, p.PrimaryKeyID())
+}
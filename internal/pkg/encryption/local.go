@@ -0,0 +1,200 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Key is a key encryption key of a local key file.
+type Key struct {
+	ID        string    `json:"id"`
+	Version   int       `json:"version"`
+	Material  []byte    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type keyFile struct {
+	Keys []Key `json:"keys"`
+}
+
+// LocalKeyProvider wraps data keys with the keys of a local key file, the
+// key with the highest version is the primary key.
+type LocalKeyProvider struct {
+	path string
+
+	mu      sync.RWMutex
+	keys    map[string]Key
+	primary Key
+}
+
+var _ KeyProvider = &LocalKeyProvider{}
+
+func NewLocalKeyProvider(path string) (*LocalKeyProvider, error) {
+	p := &LocalKeyProvider{path: path}
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *LocalKeyProvider) PrimaryKeyID() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.primary.ID
+}
+
+func (p *LocalKeyProvider) WrapKey(dataKey []byte) (string, []byte, error) {
+	p.mu.RLock()
+	primary := p.primary
+	p.mu.RUnlock()
+
+	wrapped, err := seal(primary.Material, dataKey, []byte(primary.ID))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return primary.ID, wrapped, nil
+}
+
+func (p *LocalKeyProvider) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.key(keyID)
+	if !ok {
+		// the key may have been added to the file by a rotation since it
+		// was loaded.
+		if err := p.load(); err != nil {
+			return nil, err
+		}
+
+		if key, ok = p.key(keyID); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, keyID)
+		}
+	}
+
+	return open(key.Material, wrapped, []byte(key.ID))
+}
+
+func (p *LocalKeyProvider) key(id string) (Key, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	key, ok := p.keys[id]
+	return key, ok
+}
+
+func (p *LocalKeyProvider) load() error {
+	f, err := readKeyFile(p.path)
+	if err != nil {
+		return err
+	}
+
+	if len(f.Keys) == 0 {
+		return fmt.Errorf("key file %s has no keys", p.path)
+	}
+
+	keys := make(map[string]Key, len(f.Keys))
+	var primary Key
+	for _, key := range f.Keys {
+		if len(key.Material) != dataKeySize {
+			return fmt.Errorf("key %s of key file %s must be %d bytes", key.ID, p.path, dataKeySize)
+		}
+
+		keys[key.ID] = key
+		if key.Version > primary.Version {
+			primary = key
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys, p.primary = keys, primary
+	return nil
+}
+
+// GenerateLocalKey adds a key to the key file at path with the next
+// version, which makes it the primary key. The file is created when it
+// doesn't exist.
+func GenerateLocalKey(path string) (*Key, error) {
+	f, err := readKeyFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key := Key{
+		ID:        uuid.NewString(),
+		Version:   1,
+		Material:  make([]byte, dataKeySize),
+		CreatedAt: time.Now(),
+	}
+
+	for _, k := range f.Keys {
+		if k.Version >= key.Version {
+			key.Version = k.Version + 1
+		}
+	}
+
+	if _, err = io.ReadFull(rand.Reader, key.Material); err != nil {
+		return nil, err
+	}
+
+	f.Keys = append(f.Keys, key)
+	if err = writeKeyFile(path, f); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+func readKeyFile(path string) (*keyFile, error) {
+	f := &keyFile{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return f, err
+	}
+
+	if err = json.Unmarshal(data, f); err != nil {
+		return f, fmt.Errorf("failed to read key file %s: %v", path, err)
+	}
+
+	return f, nil
+}
+
+// writeKeyFile replaces the key file at path, the file is renamed into
+// place so readers never see it partially written.
+func writeKeyFile(path string, f *keyFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	// TempFile creates the file readable by its owner only.
+	return os.Rename(tmp.Name(), path)
+}
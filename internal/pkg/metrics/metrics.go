@@ -15,8 +15,9 @@ import (
 
 var reg *prometheus.Registry
 var requestDuration *prometheus.HistogramVec
+var ingestRequests *prometheus.CounterVec
 
-var re, rd sync.Once
+var re, rd, ir sync.Once
 
 func Reg() *prometheus.Registry {
 	re.Do(func() {
@@ -28,8 +29,8 @@ func Reg() *prometheus.Registry {
 
 // Reset is only intended for use in tests
 func Reset() {
-	requestDuration, reg = nil, nil
-	re, rd = sync.Once{}, sync.Once{}
+	requestDuration, ingestRequests, reg = nil, nil, nil
+	re, rd, ir = sync.Once{}, sync.Once{}, sync.Once{}
 	prometheus.DefaultRegisterer = prometheus.NewRegistry()
 }
 
@@ -45,6 +46,21 @@ func RequestDuration() *prometheus.HistogramVec {
 	return requestDuration
 }
 
+// IngestRequests counts the requests sent to each source by whether they
+// were accepted, dropped as duplicates, rate limited or rejected by the
+// source's ip allowlist.
+func IngestRequests() *prometheus.CounterVec {
+	ir.Do(func() {
+		ingestRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "ingest",
+			Name:      "requests_total",
+			Help:      "Number of requests sent to sources by result.",
+		}, []string{"source_id", "result"})
+	})
+
+	return ingestRequests
+}
+
 func RegisterQueueMetrics(q queue.Queuer) {
 	Reg().MustRegister(
 		metrics.NewQueueMetricsCollector(q.(*redisqueue.RedisQueue).Inspector()),
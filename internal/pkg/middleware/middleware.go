@@ -42,21 +42,20 @@ import (
 type contextKey string
 
 const (
-	groupCtx            contextKey = "group"
-	appCtx              contextKey = "app"
-	orgCtx              contextKey = "organisation"
-	orgMemberCtx        contextKey = "organisation_member"
-	endpointCtx         contextKey = "endpoint"
-	eventCtx            contextKey = "event"
-	eventDeliveryCtx    contextKey = "eventDelivery"
-	authLoginCtx        contextKey = "authLogin"
-	authUserCtx         contextKey = "authUser"
-	userCtx             contextKey = "user"
-	pageableCtx         contextKey = "pageable"
-	pageDataCtx         contextKey = "pageData"
-	deliveryAttemptsCtx contextKey = "deliveryAttempts"
-	hostCtx             contextKey = "host"
-	appIdCtx            contextKey = "appId"
+	groupCtx         contextKey = "group"
+	appCtx           contextKey = "app"
+	orgCtx           contextKey = "organisation"
+	orgMemberCtx     contextKey = "organisation_member"
+	endpointCtx      contextKey = "endpoint"
+	eventCtx         contextKey = "event"
+	eventDeliveryCtx contextKey = "eventDelivery"
+	authLoginCtx     contextKey = "authLogin"
+	authUserCtx      contextKey = "authUser"
+	userCtx          contextKey = "user"
+	pageableCtx      contextKey = "pageable"
+	pageDataCtx      contextKey = "pageData"
+	hostCtx          contextKey = "host"
+	appIdCtx         contextKey = "appId"
 )
 
 type Middleware struct {
@@ -552,26 +551,6 @@ func (m *Middleware) RequireEventDelivery() func(next http.Handler) http.Handler
 	}
 }
 
-func (m *Middleware) RequireDeliveryAttempt() func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-
-			id := chi.URLParam(r, "deliveryAttemptID")
-			attempts := GetDeliveryAttemptsFromContext(r.Context())
-
-			attempt, err := findMessageDeliveryAttempt(attempts, id)
-			if err != nil {
-				_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
-				return
-			}
-
-			r = r.WithContext(setDeliveryAttemptInContext(r.Context(), attempt))
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
 func (m *Middleware) findEndpoint(endpoints *[]datastore.Endpoint, id string) (*datastore.Endpoint, error) {
 	for _, endpoint := range *endpoints {
 		if endpoint.UID == id && endpoint.DeletedAt == 0 {
@@ -865,10 +844,41 @@ func (m *Middleware) Pagination(next http.Handler) http.Handler {
 		if page, err = strconv.Atoi(rawPage); err != nil {
 			page = 0
 		}
+		nextCursor := r.URL.Query().Get("next_cursor")
+		prevCursor := r.URL.Query().Get("prev_cursor")
+		for _, cursor := range []string{nextCursor, prevCursor} {
+			if len(cursor) == 0 {
+				continue
+			}
+
+			if _, err = datastore.ParseCursor(cursor); err != nil {
+				_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+				return
+			}
+		}
+
+		if len(nextCursor) > 0 && len(prevCursor) > 0 {
+			_ = render.Render(w, r, util.NewErrorResponse("only one of next_cursor and prev_cursor can be set", http.StatusBadRequest))
+			return
+		}
+
+		// totals are counted for page numbers unless disabled, counting
+		// defeats the point of cursors so they are only counted on request.
+		includeTotal := len(nextCursor) == 0 && len(prevCursor) == 0
+		if rawIncludeTotal := r.URL.Query().Get("include_total"); len(rawIncludeTotal) > 0 {
+			if includeTotal, err = strconv.ParseBool(rawIncludeTotal); err != nil {
+				_ = render.Render(w, r, util.NewErrorResponse("include_total must be a boolean", http.StatusBadRequest))
+				return
+			}
+		}
+
 		pageable := datastore.Pageable{
-			Page:    page,
-			PerPage: perPage,
-			Sort:    sort,
+			Page:       page,
+			PerPage:    perPage,
+			Sort:       sort,
+			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
+			SkipTotal:  !includeTotal,
 		}
 		r = r.WithContext(setPageableInContext(r.Context(), pageable))
 		next.ServeHTTP(w, r)
@@ -1214,24 +1224,6 @@ func GetPaginationDataFromContext(ctx context.Context) *datastore.PaginationData
 	return ctx.Value(pageDataCtx).(*datastore.PaginationData)
 }
 
-func setDeliveryAttemptInContext(ctx context.Context,
-	attempt *datastore.DeliveryAttempt) context.Context {
-	return context.WithValue(ctx, deliveryAttemptsCtx, attempt)
-}
-
-func GetDeliveryAttemptFromContext(ctx context.Context) *datastore.DeliveryAttempt {
-	return ctx.Value(deliveryAttemptsCtx).(*datastore.DeliveryAttempt)
-}
-
-func SetDeliveryAttemptsInContext(ctx context.Context,
-	attempts *[]datastore.DeliveryAttempt) context.Context {
-	return context.WithValue(ctx, deliveryAttemptsCtx, attempts)
-}
-
-func GetDeliveryAttemptsFromContext(ctx context.Context) *[]datastore.DeliveryAttempt {
-	return ctx.Value(deliveryAttemptsCtx).(*[]datastore.DeliveryAttempt)
-}
-
 func setAuthUserInContext(ctx context.Context, a *auth.AuthenticatedUser) context.Context {
 	return context.WithValue(ctx, authUserCtx, a)
 }
@@ -1273,12 +1265,3 @@ func getAppIDFromContext(ctx context.Context) string {
 
 	return appID
 }
-
-func findMessageDeliveryAttempt(attempts *[]datastore.DeliveryAttempt, id string) (*datastore.DeliveryAttempt, error) {
-	for _, a := range *attempts {
-		if a.UID == id {
-			return &a, nil
-		}
-	}
-	return nil, datastore.ErrEventDeliveryAttemptNotFound
-}
@@ -0,0 +1,303 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ErrLocked       = errors.New("migrations are locked by another instance")
+	ErrIrreversible = errors.New("migration can't be rolled back")
+	ErrUnknown      = errors.New("applied migration is unknown to this version of convoy")
+	ErrSkipped      = errors.New("migration skipped")
+)
+
+// skipError is returned by a migration that can't run yet, it matches
+// ErrSkipped and unwraps to the reason.
+type skipError struct {
+	reason error
+}
+
+// Skip returns the error a migration's Up returns to stay pending without
+// failing the run, reason tells why it can't run yet.
+func Skip(reason error) error {
+	return &skipError{reason: reason}
+}
+
+func (e *skipError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrSkipped, e.reason)
+}
+
+func (e *skipError) Is(target error) bool {
+	return target == ErrSkipped
+}
+
+func (e *skipError) Unwrap() error {
+	return e.reason
+}
+
+// Migration is a numbered change to the documents or the indexes of the
+// database. Up must be safe to run again when it is interrupted before it
+// is recorded, Down is nil when the migration can't be rolled back.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context) error
+	Down        func(ctx context.Context) error
+}
+
+// Record is the entry a store keeps for an applied migration.
+type Record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Lock describes the instance holding the migrations lock.
+type Lock struct {
+	Owner    string    `bson:"owner"`
+	LockedAt time.Time `bson:"locked_at"`
+}
+
+// Store records the applied migrations and holds the lock that keeps
+// instances from migrating at the same time.
+type Store interface {
+	// Lock takes the migrations lock for owner, it returns ErrLocked and
+	// the current holder when it is already taken.
+	Lock(ctx context.Context, owner string) (*Lock, error)
+	Unlock(ctx context.Context) error
+
+	Applied(ctx context.Context) ([]Record, error)
+	Insert(ctx context.Context, record Record) error
+	Delete(ctx context.Context, version int) error
+}
+
+// Status is the state of a registered migration.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	store      Store
+	owner      string
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator of the migrations, owner identifies the
+// instance in the lock. It returns an error when versions are repeated or
+// not positive.
+func NewMigrator(store Store, owner string, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q has an invalid version %d", m.Description, m.Version)
+		}
+
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("migration version %d is registered twice", m.Version)
+		}
+
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d has no up function", m.Version)
+		}
+	}
+
+	return &Migrator{store: store, owner: owner, migrations: sorted}, nil
+}
+
+// Status returns the registered migrations in order with whether they
+// were applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		s := Status{Migration: mg}
+		if r, ok := applied[mg.Version]; ok {
+			s.Applied, s.AppliedAt = true, r.AppliedAt
+		}
+
+		statuses = append(statuses, s)
+	}
+
+	return statuses, nil
+}
+
+// Up applies the pending migrations in order and returns them, with
+// dryRun set it only returns the migrations it would apply. Migrations
+// that skip stay pending and the following ones still run.
+func (m *Migrator) Up(ctx context.Context, dryRun bool) ([]Migration, error) {
+	if dryRun {
+		return m.pending(ctx)
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		pending, err := m.pending(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, mg := range pending {
+			log.Infof("applying migration %d: %s", mg.Version, mg.Description)
+
+			err = mg.Up(ctx)
+			if errors.Is(err, ErrSkipped) {
+				log.WithError(err).Warnf("skipped migration %d, it stays pending", mg.Version)
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("migration %d: %w", mg.Version, err)
+			}
+
+			err = m.store.Insert(ctx, Record{Version: mg.Version, Description: mg.Description, AppliedAt: time.Now()})
+			if err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", mg.Version, err)
+			}
+
+			done = append(done, mg)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rolls back the last steps applied migrations in reverse order and
+// returns them, with dryRun set it only returns the migrations it would
+// roll back. Nothing is rolled back when one of them is irreversible.
+func (m *Migrator) Down(ctx context.Context, steps int, dryRun bool) ([]Migration, error) {
+	if dryRun {
+		return m.rollbacks(ctx, steps)
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func() error {
+		rollbacks, err := m.rollbacks(ctx, steps)
+		if err != nil {
+			return err
+		}
+
+		for _, mg := range rollbacks {
+			log.Infof("rolling back migration %d: %s", mg.Version, mg.Description)
+
+			if err = mg.Down(ctx); err != nil {
+				return fmt.Errorf("migration %d: %w", mg.Version, err)
+			}
+
+			if err = m.store.Delete(ctx, mg.Version); err != nil {
+				return fmt.Errorf("failed to remove the record of migration %d: %w", mg.Version, err)
+			}
+
+			done = append(done, mg)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Unlock releases the migrations lock held by any instance, it is used
+// when the instance holding it stopped before releasing it.
+func (m *Migrator) Unlock(ctx context.Context) error {
+	return m.store.Unlock(ctx)
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	lock, err := m.store.Lock(ctx, m.owner)
+	if errors.Is(err, ErrLocked) && lock != nil {
+		return fmt.Errorf("%w: held by %s since %s, release it with convoy migrate unlock if that instance stopped",
+			ErrLocked, lock.Owner, lock.LockedAt.Format(time.RFC3339))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := m.store.Unlock(context.Background()); err != nil {
+			log.WithError(err).Error("failed to release the migrations lock")
+		}
+	}()
+
+	return fn()
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]Record, error) {
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]Record, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, mg := range m.migrations {
+		if _, ok := applied[mg.Version]; !ok {
+			pending = append(pending, mg)
+		}
+	}
+
+	return pending, nil
+}
+
+func (m *Migrator) rollbacks(ctx context.Context, steps int) ([]Migration, error) {
+	records, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Version > records[j].Version })
+	if steps < len(records) {
+		records = records[:steps]
+	}
+
+	registered := make(map[int]Migration, len(m.migrations))
+	for _, mg := range m.migrations {
+		registered[mg.Version] = mg
+	}
+
+	rollbacks := make([]Migration, 0, len(records))
+	for _, r := range records {
+		mg, ok := registered[r.Version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknown, r.Version)
+		}
+
+		if mg.Down == nil {
+			return nil, fmt.Errorf("%w: %d", ErrIrreversible, r.Version)
+		}
+
+		rollbacks = append(rollbacks, mg)
+	}
+
+	return rollbacks, nil
+}
@@ -0,0 +1,246 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	lock    *Lock
+	records map[int]Record
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{records: map[int]Record{}}
+}
+
+func (s *fakeStore) Lock(ctx context.Context, owner string) (*Lock, error) {
+	if s.lock != nil {
+		return s.lock, ErrLocked
+	}
+
+	s.lock = &Lock{Owner: owner, LockedAt: time.Now()}
+	return s.lock, nil
+}
+
+func (s *fakeStore) Unlock(ctx context.Context) error {
+	s.lock = nil
+	return nil
+}
+
+func (s *fakeStore) Applied(ctx context.Context) ([]Record, error) {
+	records := make([]Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+
+	return records, nil
+}
+
+func (s *fakeStore) Insert(ctx context.Context, record Record) error {
+	s.records[record.Version] = record
+	return nil
+}
+
+func (s *fakeStore) Delete(ctx context.Context, version int) error {
+	delete(s.records, version)
+	return nil
+}
+
+// recorder returns migrations that append their version to ran when they
+// are applied and its negation when they are rolled back.
+func recorder(ran *[]int, versions ...int) []Migration {
+	migrations := make([]Migration, 0, len(versions))
+	for _, v := range versions {
+		v := v
+		migrations = append(migrations, Migration{
+			Version:     v,
+			Description: "migration",
+			Up:          func(ctx context.Context) error { *ran = append(*ran, v); return nil },
+			Down:        func(ctx context.Context) error { *ran = append(*ran, -v); return nil },
+		})
+	}
+
+	return migrations
+}
+
+func TestNewMigrator(t *testing.T) {
+	var ran []int
+
+	_, err := NewMigrator(newFakeStore(), "test", recorder(&ran, 1, 2, 1))
+	require.Error(t, err)
+
+	_, err = NewMigrator(newFakeStore(), "test", recorder(&ran, 0))
+	require.Error(t, err)
+
+	_, err = NewMigrator(newFakeStore(), "test", []Migration{{Version: 1}})
+	require.Error(t, err)
+}
+
+func TestMigrator_Up(t *testing.T) {
+	var ran []int
+	store := newFakeStore()
+
+	m, err := NewMigrator(store, "test", recorder(&ran, 2, 1, 3))
+	require.NoError(t, err)
+
+	applied, err := m.Up(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, applied, 3)
+	require.Empty(t, ran)
+	require.Empty(t, store.records)
+
+	applied, err = m.Up(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, applied, 3)
+	require.Equal(t, []int{1, 2, 3}, ran)
+	require.Len(t, store.records, 3)
+	require.Nil(t, store.lock)
+
+	// the applied migrations aren't run again.
+	applied, err = m.Up(context.Background(), false)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Equal(t, []int{1, 2, 3}, ran)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	for _, s := range statuses {
+		require.True(t, s.Applied)
+	}
+}
+
+func TestMigrator_Up_StopsOnError(t *testing.T) {
+	var ran []int
+	store := newFakeStore()
+
+	migrations := recorder(&ran, 1, 2, 3)
+	migrations[1].Up = func(ctx context.Context) error { return errors.New("failed") }
+
+	m, err := NewMigrator(store, "test", migrations)
+	require.NoError(t, err)
+
+	applied, err := m.Up(context.Background(), false)
+	require.Error(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, []int{1}, ran)
+	require.Len(t, store.records, 1)
+	require.Nil(t, store.lock)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.True(t, statuses[0].Applied)
+	require.False(t, statuses[1].Applied)
+	require.False(t, statuses[2].Applied)
+}
+
+func TestMigrator_Up_SkipsMigration(t *testing.T) {
+	var ran []int
+	store := newFakeStore()
+
+	// the migration can't run without a key provider yet.
+	errNoKeyProvider := errors.New("no key provider")
+	migrations := recorder(&ran, 1, 2, 3)
+	migrations[1].Up = func(ctx context.Context) error { return Skip(errNoKeyProvider) }
+
+	m, err := NewMigrator(store, "test", migrations)
+	require.NoError(t, err)
+
+	applied, err := m.Up(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	require.Equal(t, []int{1, 3}, ran)
+	require.Len(t, store.records, 2)
+	require.Nil(t, store.lock)
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+	require.True(t, statuses[0].Applied)
+	require.False(t, statuses[1].Applied)
+	require.True(t, statuses[2].Applied)
+
+	// the skipped migration runs once it can.
+	migrations[1].Up = func(ctx context.Context) error { ran = append(ran, 2); return nil }
+	m, err = NewMigrator(store, "test", migrations)
+	require.NoError(t, err)
+
+	applied, err = m.Up(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, []int{1, 3, 2}, ran)
+	require.Len(t, store.records, 3)
+}
+
+func TestMigrator_Up_Locked(t *testing.T) {
+	var ran []int
+	store := newFakeStore()
+	store.lock = &Lock{Owner: "other", LockedAt: time.Now()}
+
+	m, err := NewMigrator(store, "test", recorder(&ran, 1))
+	require.NoError(t, err)
+
+	_, err = m.Up(context.Background(), false)
+	require.ErrorIs(t, err, ErrLocked)
+	require.Empty(t, ran)
+	require.Equal(t, "other", store.lock.Owner)
+}
+
+func TestMigrator_Unlock(t *testing.T) {
+	var ran []int
+	store := newFakeStore()
+	store.lock = &Lock{Owner: "other", LockedAt: time.Now()}
+
+	m, err := NewMigrator(store, "test", recorder(&ran, 1))
+	require.NoError(t, err)
+
+	require.NoError(t, m.Unlock(context.Background()))
+
+	_, err = m.Up(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, ran)
+	require.Nil(t, store.lock)
+}
+
+func TestMigrator_Down(t *testing.T) {
+	var ran []int
+	store := newFakeStore()
+
+	migrations := recorder(&ran, 1, 2, 3)
+	m, err := NewMigrator(store, "test", migrations)
+	require.NoError(t, err)
+
+	_, err = m.Up(context.Background(), false)
+	require.NoError(t, err)
+	ran = nil
+
+	rollbacks, err := m.Down(context.Background(), 2, true)
+	require.NoError(t, err)
+	require.Len(t, rollbacks, 2)
+	require.Empty(t, ran)
+
+	rollbacks, err = m.Down(context.Background(), 2, false)
+	require.NoError(t, err)
+	require.Len(t, rollbacks, 2)
+	require.Equal(t, []int{-3, -2}, ran)
+	require.Len(t, store.records, 1)
+
+	// irreversible migrations stop the rollback before anything runs.
+	migrations[0].Down = nil
+	m, err = NewMigrator(store, "test", migrations)
+	require.NoError(t, err)
+
+	_, err = m.Down(context.Background(), 1, false)
+	require.ErrorIs(t, err, ErrIrreversible)
+	require.Len(t, store.records, 1)
+
+	// migrations applied by a newer version can't be rolled back.
+	m, err = NewMigrator(store, "test", recorder(&ran, 2))
+	require.NoError(t, err)
+
+	_, err = m.Down(context.Background(), 1, false)
+	require.ErrorIs(t, err, ErrUnknown)
+}
@@ -0,0 +1,68 @@
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+)
+
+// Lease gives one instance the exclusive right to consume a source, so
+// sources whose brokers don't share messages between consumers, like
+// change streams, aren't consumed by every replica.
+type Lease interface {
+	// Acquire takes the lease of key for holder, or renews it when holder
+	// holds it already, and reports whether holder holds it for ttl.
+	Acquire(ctx context.Context, key string, holder string, ttl time.Duration) (bool, error)
+
+	// Release gives up the lease of key if holder holds it.
+	Release(ctx context.Context, key string, holder string) error
+}
+
+// leaseHolder records the instance holding a lease.
+type leaseHolder struct {
+	Holder string
+}
+
+type cacheLease struct {
+	cache cache.Cache
+}
+
+// NewCacheLease returns a lease kept in c, instances only exclude each
+// other when they share c.
+func NewCacheLease(c cache.Cache) Lease {
+	return &cacheLease{cache: c}
+}
+
+func (l *cacheLease) Acquire(ctx context.Context, key string, holder string, ttl time.Duration) (bool, error) {
+	acquired, err := l.cache.SetNX(ctx, key, &leaseHolder{Holder: holder}, ttl)
+	if err != nil || acquired {
+		return acquired, err
+	}
+
+	var current leaseHolder
+	err = l.cache.Get(ctx, key, &current)
+	if err != nil {
+		return false, err
+	}
+
+	if current.Holder != holder {
+		return false, nil
+	}
+
+	return true, l.cache.Set(ctx, key, &current, ttl)
+}
+
+func (l *cacheLease) Release(ctx context.Context, key string, holder string) error {
+	var current leaseHolder
+	err := l.cache.Get(ctx, key, &current)
+	if err != nil {
+		return err
+	}
+
+	if current.Holder != holder {
+		return nil
+	}
+
+	return l.cache.Delete(ctx, key)
+}
@@ -0,0 +1,94 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingBroker consumes nothing until ctx is done.
+type blockingBroker struct{}
+
+func (blockingBroker) Consume(ctx context.Context, h Handler) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (blockingBroker) Lag(ctx context.Context) (int64, error) { return 0, nil }
+
+func (blockingBroker) Close() error { return nil }
+
+func TestCacheLease(t *testing.T) {
+	ctx := context.Background()
+	lease := NewCacheLease(mcache.NewMemoryCache())
+
+	acquired, err := lease.Acquire(ctx, "key", "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the holder renews its lease, others can't take it.
+	acquired, err = lease.Acquire(ctx, "key", "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = lease.Acquire(ctx, "key", "b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	// only the holder releases the lease.
+	require.NoError(t, lease.Release(ctx, "key", "b"))
+	acquired, err = lease.Acquire(ctx, "key", "b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.NoError(t, lease.Release(ctx, "key", "a"))
+	acquired, err = lease.Acquire(ctx, "key", "b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
+
+func TestSupervisor_ConsumesLeasedSourcesOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := datastore.Source{UID: "123", GroupID: "abc", Type: datastore.DBChangeStream}
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", gomock.Any(), gomock.Any()).
+		Return([]datastore.Source{source}, datastore.PaginationData{TotalPage: 1}, nil).AnyTimes()
+
+	started := make(chan string, 2)
+	newBroker := func(name string) BrokerFunc {
+		return func(*datastore.Source, string) (Broker, error) {
+			started <- name
+			return blockingBroker{}, nil
+		}
+	}
+
+	lease := NewCacheLease(mcache.NewMemoryCache())
+	first := NewSupervisor(sourceRepo, nil, datastore.DBChangeStream, newBroker("first"), lease)
+	second := NewSupervisor(sourceRepo, nil, datastore.DBChangeStream, newBroker("second"), lease)
+
+	require.NoError(t, first.sync(ctx))
+	require.NoError(t, second.sync(ctx))
+	require.Len(t, first.consumers, 1)
+	require.Len(t, second.consumers, 0)
+	require.Equal(t, "first", <-started)
+
+	// the second supervisor takes over once the first one stops.
+	first.stopAll()
+	require.NoError(t, second.sync(ctx))
+	require.Len(t, second.consumers, 1)
+	require.Equal(t, "second", <-started)
+
+	second.stopAll()
+	require.Empty(t, started)
+}
@@ -0,0 +1,107 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// DefaultConsumerGroup is the consumer group sources join when
+	// none is configured.
+	DefaultConsumerGroup = "convoy"
+
+	// MaxWorkers bounds the consumers started for a single source.
+	MaxWorkers = 10
+)
+
+var ErrUnsupportedBroker = errors.New("unsupported pub sub type")
+
+// Message is a message read from a broker.
+type Message struct {
+	ID string
+
+	// Data is the JSON body of the message.
+	Data []byte
+
+	// Attributes are the message's other fields, they are treated like
+	// the headers of ingested requests.
+	Attributes map[string]string
+
+	// ResumeToken is set by brokers that resume from the last processed
+	// message, it's saved in the source status once the message is handled.
+	ResumeToken string
+}
+
+// Handler processes a message, the message is acknowledged only when
+// it returns no error.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Broker consumes the messages of a source from a message broker.
+type Broker interface {
+	// Consume reads messages and calls h for each of them until ctx is
+	// done or the broker fails.
+	Consume(ctx context.Context, h Handler) error
+
+	// Lag returns the number of messages the source hasn't consumed.
+	Lag(ctx context.Context) (int64, error)
+
+	Close() error
+}
+
+// BrokerFunc returns the broker a source consumes messages from,
+// consumer names the source's consumer within its group.
+type BrokerFunc func(source *datastore.Source, consumer string) (Broker, error)
+
+// NewBroker returns the broker a pub_sub source is configured with.
+func NewBroker(source *datastore.Source, consumer string) (Broker, error) {
+	cfg := source.PubSub
+	if cfg == nil {
+		return nil, errors.New("pub sub config is required")
+	}
+
+	switch cfg.Type {
+	case datastore.RedisPubSub:
+		return NewRedisStream(cfg.Redis, consumer)
+	default:
+		return nil, ErrUnsupportedBroker
+	}
+}
+
+// Validate checks the pub sub config of a source.
+func Validate(cfg *datastore.PubSubConfig) error {
+	if cfg == nil {
+		return errors.New("pub sub config is required for pub sub sources")
+	}
+
+	if cfg.Workers < 0 || cfg.Workers > MaxWorkers {
+		return fmt.Errorf("pub sub workers must be between 1 and %d", MaxWorkers)
+	}
+
+	switch cfg.Type {
+	case datastore.RedisPubSub:
+		if cfg.Redis == nil {
+			return errors.New("redis config is required for redis pub sub sources")
+		}
+
+		if _, err := redis.ParseURL(cfg.Redis.Dsn); err != nil {
+			return fmt.Errorf("invalid redis dsn: %v", err)
+		}
+
+		if util.IsStringEmpty(cfg.Redis.Stream) {
+			return errors.New("redis stream is required")
+		}
+
+		if cfg.Redis.ClaimIdleTime < 0 {
+			return errors.New("redis claim idle time cannot be negative")
+		}
+	default:
+		return ErrUnsupportedBroker
+	}
+
+	return nil
+}
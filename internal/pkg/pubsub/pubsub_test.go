@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     *datastore.PubSubConfig
+		wantErr string
+	}{
+		"valid": {
+			cfg: &datastore.PubSubConfig{
+				Type:    datastore.RedisPubSub,
+				Workers: 2,
+				Redis:   &datastore.RedisPubSubConfig{Dsn: "redis://localhost:6379", Stream: "events"},
+			},
+		},
+		"missing_config": {
+			wantErr: "pub sub config is required for pub sub sources",
+		},
+		"too_many_workers": {
+			cfg: &datastore.PubSubConfig{
+				Type:    datastore.RedisPubSub,
+				Workers: MaxWorkers + 1,
+				Redis:   &datastore.RedisPubSubConfig{Dsn: "redis://localhost:6379", Stream: "events"},
+			},
+			wantErr: "pub sub workers must be between 1 and 10",
+		},
+		"unsupported_type": {
+			cfg:     &datastore.PubSubConfig{Type: "kafka"},
+			wantErr: ErrUnsupportedBroker.Error(),
+		},
+		"missing_redis_config": {
+			cfg:     &datastore.PubSubConfig{Type: datastore.RedisPubSub},
+			wantErr: "redis config is required for redis pub sub sources",
+		},
+		"invalid_dsn": {
+			cfg: &datastore.PubSubConfig{
+				Type:  datastore.RedisPubSub,
+				Redis: &datastore.RedisPubSubConfig{Dsn: "localhost", Stream: "events"},
+			},
+			wantErr: "invalid redis dsn",
+		},
+		"missing_stream": {
+			cfg: &datastore.PubSubConfig{
+				Type:  datastore.RedisPubSub,
+				Redis: &datastore.RedisPubSubConfig{Dsn: "redis://localhost:6379"},
+			},
+			wantErr: "redis stream is required",
+		},
+		"negative_claim_idle_time": {
+			cfg: &datastore.PubSubConfig{
+				Type:  datastore.RedisPubSub,
+				Redis: &datastore.RedisPubSubConfig{Dsn: "redis://localhost:6379", Stream: "events", ClaimIdleTime: -1},
+			},
+			wantErr: "redis claim idle time cannot be negative",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.cfg)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/rdb"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	redisBatchSize        = 50
+	redisBlockTimeout     = 5 * time.Second
+	defaultClaimIdleTime  = 60 * time.Second
+	redisMaxLag           = 10000
+	redisDataField        = "data"
+	redisConsumerGroupErr = "BUSYGROUP"
+)
+
+// RedisStream consumes a Redis stream through a consumer group. Messages
+// left pending by consumers that died are reclaimed after the claim
+// idle time.
+type RedisStream struct {
+	client    *redis.Client
+	stream    string
+	group     string
+	consumer  string
+	claimIdle time.Duration
+}
+
+func NewRedisStream(cfg *datastore.RedisPubSubConfig, consumer string) (*RedisStream, error) {
+	if cfg == nil {
+		return nil, errors.New("redis config is required for redis pub sub sources")
+	}
+
+	r, err := rdb.NewClient(cfg.Dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	group := cfg.ConsumerGroup
+	if util.IsStringEmpty(group) {
+		group = DefaultConsumerGroup
+	}
+
+	claimIdle := defaultClaimIdleTime
+	if cfg.ClaimIdleTime > 0 {
+		claimIdle = time.Duration(cfg.ClaimIdleTime) * time.Second
+	}
+
+	return &RedisStream{
+		client:    r.Client(),
+		stream:    cfg.Stream,
+		group:     group,
+		consumer:  consumer,
+		claimIdle: claimIdle,
+	}, nil
+}
+
+func (r *RedisStream) Consume(ctx context.Context, h Handler) error {
+	err := r.client.XGroupCreateMkStream(ctx, r.stream, r.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), redisConsumerGroupErr) {
+		return err
+	}
+
+	lastClaim := time.Time{}
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if time.Since(lastClaim) >= r.claimIdle {
+			err = r.claimPending(ctx, h)
+			if err != nil {
+				return err
+			}
+			lastClaim = time.Now()
+		}
+
+		streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    r.group,
+			Consumer: r.consumer,
+			Streams:  []string{r.stream, ">"},
+			Count:    redisBatchSize,
+			Block:    redisBlockTimeout,
+		}).Result()
+
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		for _, s := range streams {
+			if err = r.process(ctx, h, s.Messages); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// claimPending takes over the messages other consumers of the group
+// haven't acknowledged within the claim idle time.
+func (r *RedisStream) claimPending(ctx context.Context, h Handler) error {
+	start := "0-0"
+	for {
+		messages, next, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   r.stream,
+			Group:    r.group,
+			Consumer: r.consumer,
+			MinIdle:  r.claimIdle,
+			Start:    start,
+			Count:    redisBatchSize,
+		}).Result()
+		if err != nil {
+			return err
+		}
+
+		if err = r.process(ctx, h, messages); err != nil {
+			return err
+		}
+
+		if next == "0-0" || len(messages) == 0 {
+			return nil
+		}
+		start = next
+	}
+}
+
+func (r *RedisStream) process(ctx context.Context, h Handler, messages []redis.XMessage) error {
+	for _, m := range messages {
+		msg, err := redisMessage(m)
+		if err == nil {
+			err = h(ctx, msg)
+		}
+
+		if err != nil {
+			// the message stays pending and is reclaimed later.
+			log.WithError(err).Errorf("failed to process message %s from stream %s", m.ID, r.stream)
+			continue
+		}
+
+		if err = r.client.XAck(ctx, r.stream, r.group, m.ID).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Lag is the number of pending messages of the group plus the messages
+// not yet delivered to it, the latter counted up to redisMaxLag.
+func (r *RedisStream) Lag(ctx context.Context) (int64, error) {
+	groups, err := r.client.XInfoGroups(ctx, r.stream).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, g := range groups {
+		if g.Name != r.group {
+			continue
+		}
+
+		undelivered, err := r.client.XRangeN(ctx, r.stream, "("+g.LastDeliveredID, "+", redisMaxLag).Result()
+		if err != nil {
+			return 0, err
+		}
+
+		return g.Pending + int64(len(undelivered)), nil
+	}
+
+	return 0, nil
+}
+
+func (r *RedisStream) Close() error {
+	return r.client.Close()
+}
+
+// redisMessage reads the body of a stream entry from its data field and
+// the other fields as attributes. Entries without a data field are sent
+// as a JSON object of their fields.
+func redisMessage(m redis.XMessage) (*Message, error) {
+	msg := &Message{ID: m.ID, Attributes: map[string]string{}}
+
+	for k, v := range m.Values {
+		s, ok := v.(string)
+		if !ok || k == redisDataField {
+			continue
+		}
+		msg.Attributes[k] = s
+	}
+
+	if data, ok := m.Values[redisDataField].(string); ok {
+		msg.Data = []byte(data)
+		return msg, nil
+	}
+
+	data, err := json.Marshal(m.Values)
+	if err != nil {
+		return nil, err
+	}
+	msg.Data = data
+
+	return msg, nil
+}
@@ -0,0 +1,429 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/verifier"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	syncInterval  = 30 * time.Second
+	retryInterval = 10 * time.Second
+
+	// leases are renewed well before they expire, so a source is only
+	// taken over when the instance consuming it is gone.
+	leaseTTL           = 30 * time.Second
+	leaseRenewInterval = 10 * time.Second
+)
+
+// Supervisor starts the consumers of every enabled source of a type and
+// restarts them when their source changes. When it has a lease, it only
+// consumes the sources it holds the lease of.
+type Supervisor struct {
+	sourceRepo datastore.SourceRepository
+	queue      queue.Queuer
+	sourceType datastore.SourceType
+	newBroker  BrokerFunc
+	lease      Lease
+	holder     string
+
+	mu        sync.Mutex
+	consumers map[string]*consumer
+
+	// statuses holds the status the workers of each source report.
+	statusMu sync.Mutex
+	statuses map[string]*datastore.SourceStatus
+}
+
+// consumer holds the running workers of a source.
+type consumer struct {
+	updatedAt primitive.DateTime
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewSupervisor returns a supervisor of the sources of sourceType, every
+// instance consumes every source when lease is nil.
+func NewSupervisor(sourceRepo datastore.SourceRepository, q queue.Queuer, sourceType datastore.SourceType, newBroker BrokerFunc, lease Lease) *Supervisor {
+	hostname, _ := os.Hostname()
+
+	return &Supervisor{
+		sourceRepo: sourceRepo,
+		queue:      q,
+		sourceType: sourceType,
+		newBroker:  newBroker,
+		lease:      lease,
+		holder:     fmt.Sprintf("%s-%s", hostname, uuid.NewString()),
+		consumers:  map[string]*consumer{},
+		statuses:   map[string]*datastore.SourceStatus{},
+	}
+}
+
+// Run syncs the running consumers with the sources until ctx
+// is done, then stops them.
+func (s *Supervisor) Run(ctx context.Context) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx); err != nil {
+			log.WithError(err).Errorf("failed to sync %s sources", s.sourceType)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.stopAll()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Supervisor) sync(ctx context.Context) error {
+	sources := map[string]datastore.Source{}
+
+	f := &datastore.SourceFilter{Type: string(s.sourceType)}
+	for p := (datastore.Pageable{Page: 1, PerPage: 100}); ; p.Page++ {
+		page, pagination, err := s.sourceRepo.LoadSourcesPaged(ctx, "", f, p)
+		if err != nil {
+			return err
+		}
+
+		for _, source := range page {
+			if !source.IsDisabled {
+				sources[source.UID] = source
+			}
+		}
+
+		if int64(p.Page) >= pagination.TotalPage {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, c := range s.consumers {
+		// the workers of a source stop by themselves when its lease is lost.
+		source, ok := sources[id]
+		if ok && source.UpdatedAt == c.updatedAt && c.ctx.Err() == nil {
+			continue
+		}
+
+		s.stop(id, c)
+	}
+
+	for id, source := range sources {
+		if _, ok := s.consumers[id]; ok {
+			continue
+		}
+
+		if !s.acquire(ctx, id) {
+			continue
+		}
+
+		s.consumers[id] = s.start(ctx, source)
+	}
+
+	return nil
+}
+
+func (s *Supervisor) start(ctx context.Context, source datastore.Source) *consumer {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &consumer{updatedAt: source.UpdatedAt, ctx: ctx, cancel: cancel}
+
+	if s.lease != nil {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			s.renew(ctx, source.UID, cancel)
+		}()
+	}
+
+	workers := 1
+	if source.PubSub != nil && source.PubSub.Workers > 0 {
+		workers = source.PubSub.Workers
+	}
+
+	hostname, _ := os.Hostname()
+	for i := 0; i < workers; i++ {
+		name := fmt.Sprintf("%s-%s-%d", hostname, source.UID, i)
+		reportLag := i == 0
+
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			s.consume(ctx, source, name, reportLag)
+		}()
+	}
+
+	return c
+}
+
+// consume runs a worker of the source, restarting it when the broker
+// fails. reportLag is set for the one worker reporting the source's lag.
+func (s *Supervisor) consume(ctx context.Context, source datastore.Source, name string, reportLag bool) {
+	for ctx.Err() == nil {
+		// restarts resume from the status saved by the previous run.
+		source.Status = s.status(source.UID, source.Status)
+
+		broker, err := s.newBroker(&source, name)
+		if err == nil {
+			if reportLag {
+				go s.reportLag(ctx, source, broker)
+			}
+
+			err = broker.Consume(ctx, func(ctx context.Context, msg *Message) error {
+				return s.handle(ctx, &source, msg)
+			})
+			_ = broker.Close()
+		}
+
+		if err != nil {
+			log.WithError(err).Errorf("consumer of source %s failed", source.UID)
+			s.reportError(&source, err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// handle writes the event of a message to the event creation queue.
+// Messages that aren't JSON are reported and dropped.
+func (s *Supervisor) handle(ctx context.Context, source *datastore.Source, msg *Message) error {
+	event, err := NewEvent(source, msg)
+	if err != nil {
+		s.reportError(source, fmt.Errorf("message %s: %v", msg.ID, err))
+		return nil
+	}
+
+	eventByte, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      event.UID,
+		Payload: eventByte,
+		Delay:   0,
+	}
+
+	err = s.queue.Write(convoy.CreateEventProcessor, event.Priority.CreateEventQueue(), job)
+	if err != nil {
+		return err
+	}
+
+	if !util.IsStringEmpty(msg.ResumeToken) {
+		s.updateStatus(source, func(status *datastore.SourceStatus) {
+			status.ResumeToken = msg.ResumeToken
+		})
+	}
+
+	return nil
+}
+
+func (s *Supervisor) reportLag(ctx context.Context, source datastore.Source, broker Broker) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		lag, err := broker.Lag(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.WithError(err).Errorf("failed to get lag of source %s", source.UID)
+			}
+			return
+		}
+
+		s.updateStatus(&source, func(status *datastore.SourceStatus) {
+			status.Lag = lag
+		})
+	}
+}
+
+func (s *Supervisor) reportError(source *datastore.Source, err error) {
+	s.updateStatus(source, func(status *datastore.SourceStatus) {
+		status.LastError = err.Error()
+		status.LastErrorAt = primitive.NewDateTimeFromTime(time.Now())
+	})
+}
+
+// updateStatus applies fn to the status of a source and saves it, it also
+// runs while the source's consumers stop so it doesn't use their context.
+func (s *Supervisor) updateStatus(source *datastore.Source, fn func(status *datastore.SourceStatus)) {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	status, ok := s.statuses[source.UID]
+	if !ok {
+		status = &datastore.SourceStatus{}
+		if source.Status != nil {
+			*status = *source.Status
+		}
+		s.statuses[source.UID] = status
+	}
+
+	fn(status)
+	status.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	err := s.sourceRepo.UpdateSourceStatus(context.Background(), source.GroupID, source.UID, status)
+	if err != nil {
+		log.WithError(err).Errorf("failed to update status of source %s", source.UID)
+	}
+}
+
+// status returns a copy of the status the workers of a source reported,
+// or fallback when they haven't reported any.
+func (s *Supervisor) status(id string, fallback *datastore.SourceStatus) *datastore.SourceStatus {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	status, ok := s.statuses[id]
+	if !ok {
+		return fallback
+	}
+
+	st := *status
+	return &st
+}
+
+func (s *Supervisor) stopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, c := range s.consumers {
+		s.stop(id, c)
+	}
+}
+
+// acquire reports whether the instance holds the lease of a source.
+func (s *Supervisor) acquire(ctx context.Context, id string) bool {
+	if s.lease == nil {
+		return true
+	}
+
+	acquired, err := s.lease.Acquire(ctx, s.leaseKey(id), s.holder, leaseTTL)
+	if err != nil {
+		log.WithError(err).Errorf("failed to acquire the lease of source %s", id)
+		return false
+	}
+
+	return acquired
+}
+
+// renew renews the lease of a source until ctx is done, the workers of the
+// source are stopped when the lease can't be renewed.
+func (s *Supervisor) renew(ctx context.Context, id string, cancel context.CancelFunc) {
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewed, err := s.lease.Acquire(ctx, s.leaseKey(id), s.holder, leaseTTL)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			log.WithError(err).Errorf("failed to renew the lease of source %s", id)
+		} else if !renewed {
+			log.Warnf("lost the lease of source %s", id)
+		}
+
+		if err != nil || !renewed {
+			cancel()
+			return
+		}
+	}
+}
+
+func (s *Supervisor) leaseKey(id string) string {
+	return convoy.SourceLeasesCacheKey.Get(id).String()
+}
+
+// stop waits for the workers of a source to return and releases its
+// lease, s.mu must be held.
+func (s *Supervisor) stop(id string, c *consumer) {
+	c.cancel()
+	c.wg.Wait()
+	delete(s.consumers, id)
+
+	if s.lease != nil {
+		err := s.lease.Release(context.Background(), s.leaseKey(id), s.holder)
+		if err != nil {
+			log.WithError(err).Errorf("failed to release the lease of source %s", id)
+		}
+	}
+
+	s.statusMu.Lock()
+	delete(s.statuses, id)
+	s.statusMu.Unlock()
+}
+
+// NewEvent builds the event of a message the way ingested requests are,
+// with the message attributes as headers.
+func NewEvent(source *datastore.Source, msg *Message) (*datastore.Event, error) {
+	if !json.Valid(msg.Data) {
+		return nil, fmt.Errorf("message body is not valid json")
+	}
+
+	header := http.Header{}
+	for k, v := range msg.Attributes {
+		header.Set(k, v)
+	}
+
+	eventType := header.Get("event_type")
+	if util.IsStringEmpty(eventType) && source.EventType != nil {
+		fn, err := verifier.NewEventType(source.EventType)
+		if err != nil {
+			return nil, err
+		}
+		eventType = fn(&http.Request{Header: header}, msg.Data)
+	}
+
+	if util.IsStringEmpty(eventType) {
+		eventType = source.MaskID
+	}
+
+	return &datastore.Event{
+		UID:            uuid.New().String(),
+		EventType:      datastore.EventType(eventType),
+		SourceID:       source.UID,
+		GroupID:        source.GroupID,
+		Data:           msg.Data,
+		Headers:        httpheader.HTTPHeader(header).Forward(source.ForwardHeaders),
+		Priority:       source.Priority,
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}, nil
+}
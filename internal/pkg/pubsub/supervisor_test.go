@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewEvent(t *testing.T) {
+	tests := map[string]struct {
+		source        *datastore.Source
+		msg           *Message
+		wantEventType datastore.EventType
+		wantErr       bool
+	}{
+		"event_type_attribute": {
+			source:        &datastore.Source{UID: "123", GroupID: "abc", MaskID: "mask"},
+			msg:           &Message{ID: "1-0", Data: []byte(`{"a":1}`), Attributes: map[string]string{"event_type": "user.created"}},
+			wantEventType: "user.created",
+		},
+		"source_event_type": {
+			source: &datastore.Source{
+				UID: "123", GroupID: "abc", MaskID: "mask",
+				EventType: &datastore.SourceEventType{JSONPath: "type"},
+			},
+			msg:           &Message{ID: "1-0", Data: []byte(`{"type":"invoice.paid"}`)},
+			wantEventType: "invoice.paid",
+		},
+		"mask_id": {
+			source:        &datastore.Source{UID: "123", GroupID: "abc", MaskID: "mask", Priority: datastore.HighEventPriority},
+			msg:           &Message{ID: "1-0", Data: []byte(`{"a":1}`)},
+			wantEventType: "mask",
+		},
+		"invalid_json": {
+			source:  &datastore.Source{UID: "123", GroupID: "abc", MaskID: "mask"},
+			msg:     &Message{ID: "1-0", Data: []byte(`a=1`)},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			event, err := NewEvent(tc.source, tc.msg)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantEventType, event.EventType)
+			require.Equal(t, tc.source.UID, event.SourceID)
+			require.Equal(t, tc.source.GroupID, event.GroupID)
+			require.Equal(t, tc.source.Priority, event.Priority)
+			require.JSONEq(t, string(tc.msg.Data), string(event.Data))
+		})
+	}
+}
+
+func Test_redisMessage(t *testing.T) {
+	msg, err := redisMessage(redis.XMessage{
+		ID:     "1-0",
+		Values: map[string]interface{}{"data": `{"a":1}`, "event_type": "user.created"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1}`, string(msg.Data))
+	require.Equal(t, map[string]string{"event_type": "user.created"}, msg.Attributes)
+
+	msg, err = redisMessage(redis.XMessage{
+		ID:     "2-0",
+		Values: map[string]interface{}{"a": "1", "b": "2"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":"1","b":"2"}`, string(msg.Data))
+}
@@ -0,0 +1,297 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/pkg/verifier"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DefaultInterval is the number of seconds between polls of sources
+	// that don't configure one.
+	DefaultInterval = 5 * 60
+
+	// MinInterval is the shortest interval between polls, the poll task
+	// is scheduled every minute.
+	MinInterval = 60
+
+	// maxPages bounds the pages fetched in a single poll, the next poll
+	// continues from the saved cursor.
+	maxPages = 10
+
+	requestTimeout  = 30 * time.Second
+	maxResponseSize = 10 * 1024 * 1024
+)
+
+// Poller polls the APIs of rest_api sources and sends their new items
+// as events.
+type Poller struct {
+	client     *http.Client
+	sourceRepo datastore.SourceRepository
+	eventRepo  datastore.EventRepository
+	queue      queue.Queuer
+}
+
+func NewPoller(sourceRepo datastore.SourceRepository, eventRepo datastore.EventRepository, q queue.Queuer) *Poller {
+	return &Poller{
+		client:     &http.Client{Timeout: requestTimeout},
+		sourceRepo: sourceRepo,
+		eventRepo:  eventRepo,
+		queue:      q,
+	}
+}
+
+// IsDue reports whether the source's interval has passed since it was
+// last polled.
+func IsDue(source *datastore.Source, now time.Time) bool {
+	if source.Status == nil || source.Status.LastPolledAt == 0 {
+		return true
+	}
+
+	interval := int64(DefaultInterval)
+	if source.RestApi != nil && source.RestApi.Interval > 0 {
+		interval = source.RestApi.Interval
+	}
+
+	return !now.Before(source.Status.LastPolledAt.Time().Add(time.Duration(interval) * time.Second))
+}
+
+// Poll pages through the source's API from its saved cursor, sends the
+// items that weren't sent before and saves the cursor after each page.
+func (p *Poller) Poll(ctx context.Context, source *datastore.Source) error {
+	cfg := source.RestApi
+	if err := Validate(cfg); err != nil {
+		return err
+	}
+
+	status := &datastore.SourceStatus{}
+	if source.Status != nil {
+		*status = *source.Status
+	}
+
+	var err error
+	seen := map[string]bool{}
+	for page := 0; page < maxPages; page++ {
+		var items []json.RawMessage
+		var next string
+
+		items, next, err = p.fetch(ctx, cfg, status.ResumeToken)
+		if err != nil {
+			break
+		}
+
+		for _, item := range items {
+			if err = p.send(ctx, source, item, seen); err != nil {
+				break
+			}
+		}
+
+		if err != nil || len(items) == 0 || util.IsStringEmpty(next) || next == status.ResumeToken {
+			break
+		}
+
+		status.ResumeToken = next
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	status.LastPolledAt, status.UpdatedAt = now, now
+	if err != nil {
+		status.LastError = err.Error()
+		status.LastErrorAt = now
+	} else {
+		status.LastError = ""
+		status.LastSuccessAt = now
+	}
+
+	if uErr := p.sourceRepo.UpdateSourceStatus(ctx, source.GroupID, source.UID, status); uErr != nil {
+		return uErr
+	}
+	source.Status = status
+
+	return err
+}
+
+// fetch returns the items of the page at cursor and the cursor of the
+// next page.
+func (p *Poller) fetch(ctx context.Context, cfg *datastore.RestApiConfig, cursor string) ([]json.RawMessage, string, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !util.IsStringEmpty(cursor) {
+		q := u.Query()
+		q.Set(cfg.CursorParam, cursor)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent())
+	for k, v := range cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	var decoded interface{}
+	if err = json.Unmarshal(body, &decoded); err != nil {
+		return nil, "", fmt.Errorf("invalid json response: %v", err)
+	}
+
+	list := jsonPath(decoded, cfg.ItemsPath)
+	values, ok := list.([]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("no items found at %q", cfg.ItemsPath)
+	}
+
+	items := make([]json.RawMessage, 0, len(values))
+	for _, v := range values {
+		item, err := json.Marshal(v)
+		if err != nil {
+			return nil, "", err
+		}
+		items = append(items, item)
+	}
+
+	var next string
+	if !util.IsStringEmpty(cfg.CursorPath) {
+		next = verifier.JSONEventType(strings.Split(cfg.CursorPath, ".")...)(req, body)
+	} else if len(items) > 0 {
+		next = itemID(cfg, items[len(items)-1])
+	}
+
+	return items, next, nil
+}
+
+// send writes the event of an item to the event creation queue unless an
+// event was already created for it within the deduplication window.
+func (p *Poller) send(ctx context.Context, source *datastore.Source, item json.RawMessage, seen map[string]bool) error {
+	id := itemID(source.RestApi, item)
+	if util.IsStringEmpty(id) {
+		return fmt.Errorf("item has no id at %q", source.RestApi.IDPath)
+	}
+
+	if seen[id] {
+		return nil
+	}
+	seen[id] = true
+
+	window := int64(config.MaxDeduplicationWindow)
+	if source.Deduplication != nil && source.Deduplication.Window > 0 {
+		window = source.Deduplication.Window
+	}
+
+	since := time.Now().Add(-time.Duration(window) * time.Second)
+	_, err := p.eventRepo.FindEventByIdempotencyKey(ctx, source.UID, id, since)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, datastore.ErrEventNotFound) {
+		return err
+	}
+
+	event, err := pubsub.NewEvent(source, &pubsub.Message{ID: id, Data: item})
+	if err != nil {
+		return err
+	}
+	event.IdempotencyKey = id
+
+	eventByte, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	job := &queue.Job{
+		ID:      event.UID,
+		Payload: eventByte,
+		Delay:   0,
+	}
+
+	return p.queue.Write(convoy.CreateEventProcessor, event.Priority.CreateEventQueue(), job)
+}
+
+func itemID(cfg *datastore.RestApiConfig, item json.RawMessage) string {
+	return verifier.JSONEventType(strings.Split(cfg.IDPath, ".")...)(nil, item)
+}
+
+// jsonPath returns the field of a decoded JSON body at the dot separated
+// path, the body itself when the path is empty.
+func jsonPath(body interface{}, path string) interface{} {
+	if util.IsStringEmpty(path) {
+		return body
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		fields, ok := body.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		body = fields[key]
+	}
+
+	return body
+}
+
+func defaultUserAgent() string {
+	return "Convoy/" + convoy.GetVersion()
+}
+
+// Validate checks the rest api config of a source.
+func Validate(cfg *datastore.RestApiConfig) error {
+	if cfg == nil {
+		return errors.New("rest api config is required for rest api sources")
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || util.IsStringEmpty(u.Host) {
+		return errors.New("rest api url must be a valid http or https url")
+	}
+
+	if cfg.Interval != 0 && cfg.Interval < MinInterval {
+		return fmt.Errorf("rest api interval cannot be less than %d seconds", MinInterval)
+	}
+
+	if util.IsStringEmpty(cfg.IDPath) {
+		return errors.New("rest api id path is required")
+	}
+
+	if util.IsStringEmpty(cfg.CursorParam) {
+		return errors.New("rest api cursor param is required")
+	}
+
+	return nil
+}
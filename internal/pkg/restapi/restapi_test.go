@@ -0,0 +1,177 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPoller_Poll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("starting_after") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1"},{"id":"2"}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2"},{"id":"3"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+
+	source := &datastore.Source{
+		UID:      "123",
+		GroupID:  "abc",
+		MaskID:   "mask",
+		Type:     datastore.RestApiSource,
+		Priority: datastore.HighEventPriority,
+		RestApi: &datastore.RestApiConfig{
+			URL:         server.URL,
+			Headers:     map[string]string{"Authorization": "Bearer token"},
+			ItemsPath:   "data",
+			IDPath:      "id",
+			CursorParam: "starting_after",
+		},
+		// the previous poll failed.
+		Status: &datastore.SourceStatus{LastError: "unexpected response status: 500 Internal Server Error"},
+	}
+
+	// item 1 was sent by a previous poll.
+	eventRepo.EXPECT().FindEventByIdempotencyKey(gomock.Any(), "123", "1", gomock.Any()).Return(&datastore.Event{}, nil)
+	eventRepo.EXPECT().FindEventByIdempotencyKey(gomock.Any(), "123", "2", gomock.Any()).Return(nil, datastore.ErrEventNotFound)
+	eventRepo.EXPECT().FindEventByIdempotencyKey(gomock.Any(), "123", "3", gomock.Any()).Return(nil, datastore.ErrEventNotFound)
+	q.EXPECT().Write(convoy.CreateEventProcessor, convoy.HighPriorityCreateEventQueue, gomock.Any()).Times(2).Return(nil)
+	sourceRepo.EXPECT().UpdateSourceStatus(gomock.Any(), "abc", "123", gomock.Any()).Return(nil)
+
+	err := NewPoller(sourceRepo, eventRepo, q).Poll(context.Background(), source)
+	require.NoError(t, err)
+
+	require.Equal(t, "3", source.Status.ResumeToken)
+	require.NotZero(t, source.Status.LastPolledAt)
+	require.Equal(t, source.Status.LastPolledAt, source.Status.LastSuccessAt)
+	require.Empty(t, source.Status.LastError)
+}
+
+func TestPoller_Poll_RecordsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	source := &datastore.Source{
+		UID:     "123",
+		GroupID: "abc",
+		RestApi: &datastore.RestApiConfig{
+			URL:         server.URL,
+			IDPath:      "id",
+			CursorParam: "since",
+		},
+		Status: &datastore.SourceStatus{ResumeToken: "10"},
+	}
+
+	sourceRepo.EXPECT().UpdateSourceStatus(gomock.Any(), "abc", "123", gomock.Any()).Return(nil)
+
+	err := NewPoller(sourceRepo, nil, nil).Poll(context.Background(), source)
+	require.EqualError(t, err, "unexpected response status: 401 Unauthorized")
+
+	require.Equal(t, "10", source.Status.ResumeToken)
+	require.Equal(t, "unexpected response status: 401 Unauthorized", source.Status.LastError)
+	require.Zero(t, source.Status.LastSuccessAt)
+}
+
+func TestIsDue(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		source *datastore.Source
+		want   bool
+	}{
+		"never_polled": {
+			source: &datastore.Source{RestApi: &datastore.RestApiConfig{Interval: 60}},
+			want:   true,
+		},
+		"interval_passed": {
+			source: &datastore.Source{
+				RestApi: &datastore.RestApiConfig{Interval: 60},
+				Status:  &datastore.SourceStatus{LastPolledAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute))},
+			},
+			want: true,
+		},
+		"interval_not_passed": {
+			source: &datastore.Source{
+				RestApi: &datastore.RestApiConfig{Interval: 120},
+				Status:  &datastore.SourceStatus{LastPolledAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute))},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, IsDue(tc.source, now))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     *datastore.RestApiConfig
+		wantErr string
+	}{
+		"valid": {
+			cfg: &datastore.RestApiConfig{URL: "https://api.example.com/items", IDPath: "id", CursorParam: "since"},
+		},
+		"missing_config": {
+			wantErr: "rest api config is required for rest api sources",
+		},
+		"invalid_url": {
+			cfg:     &datastore.RestApiConfig{URL: "ftp://example.com", IDPath: "id", CursorParam: "since"},
+			wantErr: "rest api url must be a valid http or https url",
+		},
+		"short_interval": {
+			cfg:     &datastore.RestApiConfig{URL: "https://api.example.com/items", Interval: 10, IDPath: "id", CursorParam: "since"},
+			wantErr: "rest api interval cannot be less than 60 seconds",
+		},
+		"missing_id_path": {
+			cfg:     &datastore.RestApiConfig{URL: "https://api.example.com/items", CursorParam: "since"},
+			wantErr: "rest api id path is required",
+		},
+		"missing_cursor_param": {
+			cfg:     &datastore.RestApiConfig{URL: "https://api.example.com/items", IDPath: "id"},
+			wantErr: "rest api cursor param is required",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.cfg)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
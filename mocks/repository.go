@@ -7,11 +7,287 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	datastore "github.com/frain-dev/convoy/datastore"
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockDatabase is a mock of Database interface.
+type MockDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseMockRecorder
+}
+
+// MockDatabaseMockRecorder is the mock recorder for MockDatabase.
+type MockDatabaseMockRecorder struct {
+	mock *MockDatabase
+}
+
+// NewMockDatabase creates a new mock instance.
+func NewMockDatabase(ctrl *gomock.Controller) *MockDatabase {
+	mock := &MockDatabase{ctrl: ctrl}
+	mock.recorder = &MockDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
+	return m.recorder
+}
+
+// APIRepo mocks base method.
+func (m *MockDatabase) APIRepo() datastore.APIKeyRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIRepo")
+	ret0, _ := ret[0].(datastore.APIKeyRepository)
+	return ret0
+}
+
+// APIRepo indicates an expected call of APIRepo.
+func (mr *MockDatabaseMockRecorder) APIRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIRepo", reflect.TypeOf((*MockDatabase)(nil).APIRepo))
+}
+
+// AppRepo mocks base method.
+func (m *MockDatabase) AppRepo() datastore.ApplicationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppRepo")
+	ret0, _ := ret[0].(datastore.ApplicationRepository)
+	return ret0
+}
+
+// AppRepo indicates an expected call of AppRepo.
+func (mr *MockDatabaseMockRecorder) AppRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppRepo", reflect.TypeOf((*MockDatabase)(nil).AppRepo))
+}
+
+// Client mocks base method.
+func (m *MockDatabase) Client() interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client")
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockDatabaseMockRecorder) Client() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockDatabase)(nil).Client))
+}
+
+// ConfigurationRepo mocks base method.
+func (m *MockDatabase) ConfigurationRepo() datastore.ConfigurationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigurationRepo")
+	ret0, _ := ret[0].(datastore.ConfigurationRepository)
+	return ret0
+}
+
+// ConfigurationRepo indicates an expected call of ConfigurationRepo.
+func (mr *MockDatabaseMockRecorder) ConfigurationRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigurationRepo", reflect.TypeOf((*MockDatabase)(nil).ConfigurationRepo))
+}
+
+// DeliveryAttemptRepo mocks base method.
+func (m *MockDatabase) DeliveryAttemptRepo() datastore.DeliveryAttemptRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryAttemptRepo")
+	ret0, _ := ret[0].(datastore.DeliveryAttemptRepository)
+	return ret0
+}
+
+// DeliveryAttemptRepo indicates an expected call of DeliveryAttemptRepo.
+func (mr *MockDatabaseMockRecorder) DeliveryAttemptRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryAttemptRepo", reflect.TypeOf((*MockDatabase)(nil).DeliveryAttemptRepo))
+}
+
+// Disconnect mocks base method.
+func (m *MockDatabase) Disconnect(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnect", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockDatabaseMockRecorder) Disconnect(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockDatabase)(nil).Disconnect), arg0)
+}
+
+// EventDeliveryRepo mocks base method.
+func (m *MockDatabase) EventDeliveryRepo() datastore.EventDeliveryRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventDeliveryRepo")
+	ret0, _ := ret[0].(datastore.EventDeliveryRepository)
+	return ret0
+}
+
+// EventDeliveryRepo indicates an expected call of EventDeliveryRepo.
+func (mr *MockDatabaseMockRecorder) EventDeliveryRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventDeliveryRepo", reflect.TypeOf((*MockDatabase)(nil).EventDeliveryRepo))
+}
+
+// EventRepo mocks base method.
+func (m *MockDatabase) EventRepo() datastore.EventRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventRepo")
+	ret0, _ := ret[0].(datastore.EventRepository)
+	return ret0
+}
+
+// EventRepo indicates an expected call of EventRepo.
+func (mr *MockDatabaseMockRecorder) EventRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventRepo", reflect.TypeOf((*MockDatabase)(nil).EventRepo))
+}
+
+// GetName mocks base method.
+func (m *MockDatabase) GetName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockDatabaseMockRecorder) GetName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockDatabase)(nil).GetName))
+}
+
+// GroupRepo mocks base method.
+func (m *MockDatabase) GroupRepo() datastore.GroupRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupRepo")
+	ret0, _ := ret[0].(datastore.GroupRepository)
+	return ret0
+}
+
+// GroupRepo indicates an expected call of GroupRepo.
+func (mr *MockDatabaseMockRecorder) GroupRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupRepo", reflect.TypeOf((*MockDatabase)(nil).GroupRepo))
+}
+
+// OrganisationInviteRepo mocks base method.
+func (m *MockDatabase) OrganisationInviteRepo() datastore.OrganisationInviteRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganisationInviteRepo")
+	ret0, _ := ret[0].(datastore.OrganisationInviteRepository)
+	return ret0
+}
+
+// OrganisationInviteRepo indicates an expected call of OrganisationInviteRepo.
+func (mr *MockDatabaseMockRecorder) OrganisationInviteRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganisationInviteRepo", reflect.TypeOf((*MockDatabase)(nil).OrganisationInviteRepo))
+}
+
+// OrganisationMemberRepo mocks base method.
+func (m *MockDatabase) OrganisationMemberRepo() datastore.OrganisationMemberRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganisationMemberRepo")
+	ret0, _ := ret[0].(datastore.OrganisationMemberRepository)
+	return ret0
+}
+
+// OrganisationMemberRepo indicates an expected call of OrganisationMemberRepo.
+func (mr *MockDatabaseMockRecorder) OrganisationMemberRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganisationMemberRepo", reflect.TypeOf((*MockDatabase)(nil).OrganisationMemberRepo))
+}
+
+// OrganisationRepo mocks base method.
+func (m *MockDatabase) OrganisationRepo() datastore.OrganisationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganisationRepo")
+	ret0, _ := ret[0].(datastore.OrganisationRepository)
+	return ret0
+}
+
+// OrganisationRepo indicates an expected call of OrganisationRepo.
+func (mr *MockDatabaseMockRecorder) OrganisationRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganisationRepo", reflect.TypeOf((*MockDatabase)(nil).OrganisationRepo))
+}
+
+// ReplayJobRepo mocks base method.
+func (m *MockDatabase) ReplayJobRepo() datastore.ReplayJobRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayJobRepo")
+	ret0, _ := ret[0].(datastore.ReplayJobRepository)
+	return ret0
+}
+
+// ReplayJobRepo indicates an expected call of ReplayJobRepo.
+func (mr *MockDatabaseMockRecorder) ReplayJobRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayJobRepo", reflect.TypeOf((*MockDatabase)(nil).ReplayJobRepo))
+}
+
+// SourceRepo mocks base method.
+func (m *MockDatabase) SourceRepo() datastore.SourceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SourceRepo")
+	ret0, _ := ret[0].(datastore.SourceRepository)
+	return ret0
+}
+
+// SourceRepo indicates an expected call of SourceRepo.
+func (mr *MockDatabaseMockRecorder) SourceRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourceRepo", reflect.TypeOf((*MockDatabase)(nil).SourceRepo))
+}
+
+// SubRepo mocks base method.
+func (m *MockDatabase) SubRepo() datastore.SubscriptionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubRepo")
+	ret0, _ := ret[0].(datastore.SubscriptionRepository)
+	return ret0
+}
+
+// SubRepo indicates an expected call of SubRepo.
+func (mr *MockDatabaseMockRecorder) SubRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubRepo", reflect.TypeOf((*MockDatabase)(nil).SubRepo))
+}
+
+// TestAttemptRepo mocks base method.
+func (m *MockDatabase) TestAttemptRepo() datastore.TestAttemptRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestAttemptRepo")
+	ret0, _ := ret[0].(datastore.TestAttemptRepository)
+	return ret0
+}
+
+// TestAttemptRepo indicates an expected call of TestAttemptRepo.
+func (mr *MockDatabaseMockRecorder) TestAttemptRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestAttemptRepo", reflect.TypeOf((*MockDatabase)(nil).TestAttemptRepo))
+}
+
+// UserRepo mocks base method.
+func (m *MockDatabase) UserRepo() datastore.UserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRepo")
+	ret0, _ := ret[0].(datastore.UserRepository)
+	return ret0
+}
+
+// UserRepo indicates an expected call of UserRepo.
+func (mr *MockDatabaseMockRecorder) UserRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRepo", reflect.TypeOf((*MockDatabase)(nil).UserRepo))
+}
+
 // MockAPIKeyRepository is a mock of APIKeyRepository interface.
 type MockAPIKeyRepository struct {
 	ctrl     *gomock.Controller
@@ -249,6 +525,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesByIDs(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesByIDs", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesByIDs), arg0, arg1)
 }
 
+// FindEventDeliveriesBySubscriptionID mocks base method.
+func (m *MockEventDeliveryRepository) FindEventDeliveriesBySubscriptionID(ctx context.Context, groupID, subscriptionID string, status datastore.EventDeliveryStatus, limit int) ([]datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventDeliveriesBySubscriptionID", ctx, groupID, subscriptionID, status, limit)
+	ret0, _ := ret[0].([]datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventDeliveriesBySubscriptionID indicates an expected call of FindEventDeliveriesBySubscriptionID.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveriesBySubscriptionID(ctx, groupID, subscriptionID, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveriesBySubscriptionID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveriesBySubscriptionID), ctx, groupID, subscriptionID, status, limit)
+}
+
 // FindEventDeliveryByID mocks base method.
 func (m *MockEventDeliveryRepository) FindEventDeliveryByID(arg0 context.Context, arg1 string) (*datastore.EventDelivery, error) {
 	m.ctrl.T.Helper()
@@ -280,18 +571,18 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) LoadEventDeliveriesPaged(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventDeliveriesPaged", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadEventDeliveriesPaged), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
-// UpdateEventDeliveryWithAttempt mocks base method.
-func (m *MockEventDeliveryRepository) UpdateEventDeliveryWithAttempt(arg0 context.Context, arg1 datastore.EventDelivery, arg2 datastore.DeliveryAttempt) error {
+// UpdateEventDelivery mocks base method.
+func (m *MockEventDeliveryRepository) UpdateEventDelivery(arg0 context.Context, arg1 datastore.EventDelivery) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEventDeliveryWithAttempt", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateEventDelivery", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateEventDeliveryWithAttempt indicates an expected call of UpdateEventDeliveryWithAttempt.
-func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateEventDeliveryWithAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
+// UpdateEventDelivery indicates an expected call of UpdateEventDelivery.
+func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateEventDelivery(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventDeliveryWithAttempt", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateEventDeliveryWithAttempt), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateEventDelivery), arg0, arg1)
 }
 
 // UpdateStatusOfEventDeliveries mocks base method.
@@ -345,6 +636,21 @@ func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountEvents mocks base method.
+func (m *MockEventRepository) CountEvents(arg0 context.Context, arg1 *datastore.EventFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEvents indicates an expected call of CountEvents.
+func (mr *MockEventRepositoryMockRecorder) CountEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEvents", reflect.TypeOf((*MockEventRepository)(nil).CountEvents), arg0, arg1)
+}
+
 // CountGroupMessages mocks base method.
 func (m *MockEventRepository) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -403,6 +709,21 @@ func (mr *MockEventRepositoryMockRecorder) FindEventByID(ctx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventByID", reflect.TypeOf((*MockEventRepository)(nil).FindEventByID), ctx, id)
 }
 
+// FindEventByIdempotencyKey mocks base method.
+func (m *MockEventRepository) FindEventByIdempotencyKey(ctx context.Context, sourceID, key string, since time.Time) (*datastore.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventByIdempotencyKey", ctx, sourceID, key, since)
+	ret0, _ := ret[0].(*datastore.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventByIdempotencyKey indicates an expected call of FindEventByIdempotencyKey.
+func (mr *MockEventRepositoryMockRecorder) FindEventByIdempotencyKey(ctx, sourceID, key, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventByIdempotencyKey", reflect.TypeOf((*MockEventRepository)(nil).FindEventByIdempotencyKey), ctx, sourceID, key, since)
+}
+
 // FindEventsByIDs mocks base method.
 func (m *MockEventRepository) FindEventsByIDs(arg0 context.Context, arg1 []string) ([]datastore.Event, error) {
 	m.ctrl.T.Helper()
@@ -418,6 +739,20 @@ func (mr *MockEventRepositoryMockRecorder) FindEventsByIDs(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventsByIDs", reflect.TypeOf((*MockEventRepository)(nil).FindEventsByIDs), arg0, arg1)
 }
 
+// IncrementDuplicateCount mocks base method.
+func (m *MockEventRepository) IncrementDuplicateCount(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementDuplicateCount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementDuplicateCount indicates an expected call of IncrementDuplicateCount.
+func (mr *MockEventRepositoryMockRecorder) IncrementDuplicateCount(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementDuplicateCount", reflect.TypeOf((*MockEventRepository)(nil).IncrementDuplicateCount), ctx, id)
+}
+
 // LoadEventIntervals mocks base method.
 func (m *MockEventRepository) LoadEventIntervals(arg0 context.Context, arg1 string, arg2 datastore.SearchParams, arg3 datastore.Period, arg4 int) ([]datastore.EventInterval, error) {
 	m.ctrl.T.Helper()
@@ -433,6 +768,21 @@ func (mr *MockEventRepositoryMockRecorder) LoadEventIntervals(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventIntervals", reflect.TypeOf((*MockEventRepository)(nil).LoadEventIntervals), arg0, arg1, arg2, arg3, arg4)
 }
 
+// LoadEventsByFilter mocks base method.
+func (m *MockEventRepository) LoadEventsByFilter(arg0 context.Context, arg1 *datastore.EventFilter, arg2 datastore.Pageable) ([]datastore.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadEventsByFilter", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]datastore.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadEventsByFilter indicates an expected call of LoadEventsByFilter.
+func (mr *MockEventRepositoryMockRecorder) LoadEventsByFilter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventsByFilter", reflect.TypeOf((*MockEventRepository)(nil).LoadEventsByFilter), arg0, arg1, arg2)
+}
+
 // LoadEventsPaged mocks base method.
 func (m *MockEventRepository) LoadEventsPaged(arg0 context.Context, arg1, arg2 string, arg3 datastore.SearchParams, arg4 datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -449,6 +799,20 @@ func (mr *MockEventRepositoryMockRecorder) LoadEventsPaged(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventsPaged", reflect.TypeOf((*MockEventRepository)(nil).LoadEventsPaged), arg0, arg1, arg2, arg3, arg4)
 }
 
+// UpdateEventPayload mocks base method.
+func (m *MockEventRepository) UpdateEventPayload(ctx context.Context, event *datastore.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEventPayload", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEventPayload indicates an expected call of UpdateEventPayload.
+func (mr *MockEventRepositoryMockRecorder) UpdateEventPayload(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEventPayload", reflect.TypeOf((*MockEventRepository)(nil).UpdateEventPayload), ctx, event)
+}
+
 // MockGroupRepository is a mock of GroupRepository interface.
 type MockGroupRepository struct {
 	ctrl     *gomock.Controller
@@ -1001,6 +1365,21 @@ func (mr *MockApplicationRepositoryMockRecorder) DeleteGroupApps(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupApps", reflect.TypeOf((*MockApplicationRepository)(nil).DeleteGroupApps), arg0, arg1)
 }
 
+// FindApplicationByEndpointVerificationToken mocks base method.
+func (m *MockApplicationRepository) FindApplicationByEndpointVerificationToken(arg0 context.Context, arg1 string) (*datastore.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicationByEndpointVerificationToken", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicationByEndpointVerificationToken indicates an expected call of FindApplicationByEndpointVerificationToken.
+func (mr *MockApplicationRepositoryMockRecorder) FindApplicationByEndpointVerificationToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationByEndpointVerificationToken", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationByEndpointVerificationToken), arg0, arg1)
+}
+
 // FindApplicationByID mocks base method.
 func (m *MockApplicationRepository) FindApplicationByID(arg0 context.Context, arg1 string) (*datastore.Application, error) {
 	m.ctrl.T.Helper()
@@ -1328,6 +1707,20 @@ func (mr *MockSourceRepositoryMockRecorder) FindSourceByMaskID(ctx, maskID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSourceByMaskID", reflect.TypeOf((*MockSourceRepository)(nil).FindSourceByMaskID), ctx, maskID)
 }
 
+// IncrementRejectedRequests mocks base method.
+func (m *MockSourceRepository) IncrementRejectedRequests(ctx context.Context, groupID, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementRejectedRequests", ctx, groupID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementRejectedRequests indicates an expected call of IncrementRejectedRequests.
+func (mr *MockSourceRepositoryMockRecorder) IncrementRejectedRequests(ctx, groupID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementRejectedRequests", reflect.TypeOf((*MockSourceRepository)(nil).IncrementRejectedRequests), ctx, groupID, id)
+}
+
 // LoadSourcesPaged mocks base method.
 func (m *MockSourceRepository) LoadSourcesPaged(ctx context.Context, groupID string, filter *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -1344,6 +1737,20 @@ func (mr *MockSourceRepositoryMockRecorder) LoadSourcesPaged(ctx, groupID, filte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSourcesPaged", reflect.TypeOf((*MockSourceRepository)(nil).LoadSourcesPaged), ctx, groupID, filter, pageable)
 }
 
+// UpdateHandshakeNotifiedAt mocks base method.
+func (m *MockSourceRepository) UpdateHandshakeNotifiedAt(ctx context.Context, groupID, id string, notifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHandshakeNotifiedAt", ctx, groupID, id, notifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHandshakeNotifiedAt indicates an expected call of UpdateHandshakeNotifiedAt.
+func (mr *MockSourceRepositoryMockRecorder) UpdateHandshakeNotifiedAt(ctx, groupID, id, notifiedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHandshakeNotifiedAt", reflect.TypeOf((*MockSourceRepository)(nil).UpdateHandshakeNotifiedAt), ctx, groupID, id, notifiedAt)
+}
+
 // UpdateSource mocks base method.
 func (m *MockSourceRepository) UpdateSource(ctx context.Context, groupID string, source *datastore.Source) error {
 	m.ctrl.T.Helper()
@@ -1358,6 +1765,237 @@ func (mr *MockSourceRepositoryMockRecorder) UpdateSource(ctx, groupID, source in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSource", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSource), ctx, groupID, source)
 }
 
+// UpdateSourceStatus mocks base method.
+func (m *MockSourceRepository) UpdateSourceStatus(ctx context.Context, groupID, id string, status *datastore.SourceStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSourceStatus", ctx, groupID, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSourceStatus indicates an expected call of UpdateSourceStatus.
+func (mr *MockSourceRepositoryMockRecorder) UpdateSourceStatus(ctx, groupID, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSourceStatus", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSourceStatus), ctx, groupID, id, status)
+}
+
+// MockReplayJobRepository is a mock of ReplayJobRepository interface.
+type MockReplayJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReplayJobRepositoryMockRecorder
+}
+
+// MockReplayJobRepositoryMockRecorder is the mock recorder for MockReplayJobRepository.
+type MockReplayJobRepositoryMockRecorder struct {
+	mock *MockReplayJobRepository
+}
+
+// NewMockReplayJobRepository creates a new mock instance.
+func NewMockReplayJobRepository(ctrl *gomock.Controller) *MockReplayJobRepository {
+	mock := &MockReplayJobRepository{ctrl: ctrl}
+	mock.recorder = &MockReplayJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReplayJobRepository) EXPECT() *MockReplayJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateReplayJob mocks base method.
+func (m *MockReplayJobRepository) CreateReplayJob(arg0 context.Context, arg1 *datastore.ReplayJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReplayJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReplayJob indicates an expected call of CreateReplayJob.
+func (mr *MockReplayJobRepositoryMockRecorder) CreateReplayJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReplayJob", reflect.TypeOf((*MockReplayJobRepository)(nil).CreateReplayJob), arg0, arg1)
+}
+
+// FindReplayJobByID mocks base method.
+func (m *MockReplayJobRepository) FindReplayJobByID(ctx context.Context, groupID, id string) (*datastore.ReplayJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReplayJobByID", ctx, groupID, id)
+	ret0, _ := ret[0].(*datastore.ReplayJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReplayJobByID indicates an expected call of FindReplayJobByID.
+func (mr *MockReplayJobRepositoryMockRecorder) FindReplayJobByID(ctx, groupID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReplayJobByID", reflect.TypeOf((*MockReplayJobRepository)(nil).FindReplayJobByID), ctx, groupID, id)
+}
+
+// LoadReplayJobsPaged mocks base method.
+func (m *MockReplayJobRepository) LoadReplayJobsPaged(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.ReplayJob, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadReplayJobsPaged", ctx, groupID, pageable)
+	ret0, _ := ret[0].([]datastore.ReplayJob)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadReplayJobsPaged indicates an expected call of LoadReplayJobsPaged.
+func (mr *MockReplayJobRepositoryMockRecorder) LoadReplayJobsPaged(ctx, groupID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadReplayJobsPaged", reflect.TypeOf((*MockReplayJobRepository)(nil).LoadReplayJobsPaged), ctx, groupID, pageable)
+}
+
+// UpdateReplayJob mocks base method.
+func (m *MockReplayJobRepository) UpdateReplayJob(arg0 context.Context, arg1 *datastore.ReplayJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReplayJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReplayJob indicates an expected call of UpdateReplayJob.
+func (mr *MockReplayJobRepositoryMockRecorder) UpdateReplayJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReplayJob", reflect.TypeOf((*MockReplayJobRepository)(nil).UpdateReplayJob), arg0, arg1)
+}
+
+// MockDeliveryAttemptRepository is a mock of DeliveryAttemptRepository interface.
+type MockDeliveryAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryAttemptRepositoryMockRecorder
+}
+
+// MockDeliveryAttemptRepositoryMockRecorder is the mock recorder for MockDeliveryAttemptRepository.
+type MockDeliveryAttemptRepositoryMockRecorder struct {
+	mock *MockDeliveryAttemptRepository
+}
+
+// NewMockDeliveryAttemptRepository creates a new mock instance.
+func NewMockDeliveryAttemptRepository(ctrl *gomock.Controller) *MockDeliveryAttemptRepository {
+	mock := &MockDeliveryAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockDeliveryAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryAttemptRepository) EXPECT() *MockDeliveryAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDeliveryAttempt mocks base method.
+func (m *MockDeliveryAttemptRepository) CreateDeliveryAttempt(arg0 context.Context, arg1 *datastore.DeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveryAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveryAttempt indicates an expected call of CreateDeliveryAttempt.
+func (mr *MockDeliveryAttemptRepositoryMockRecorder) CreateDeliveryAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveryAttempt", reflect.TypeOf((*MockDeliveryAttemptRepository)(nil).CreateDeliveryAttempt), arg0, arg1)
+}
+
+// DeleteGroupDeliveryAttempts mocks base method.
+func (m *MockDeliveryAttemptRepository) DeleteGroupDeliveryAttempts(ctx context.Context, filter *datastore.DeliveryAttemptFilter, hardDelete bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupDeliveryAttempts", ctx, filter, hardDelete)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroupDeliveryAttempts indicates an expected call of DeleteGroupDeliveryAttempts.
+func (mr *MockDeliveryAttemptRepositoryMockRecorder) DeleteGroupDeliveryAttempts(ctx, filter, hardDelete interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupDeliveryAttempts", reflect.TypeOf((*MockDeliveryAttemptRepository)(nil).DeleteGroupDeliveryAttempts), ctx, filter, hardDelete)
+}
+
+// FindDeliveryAttemptByID mocks base method.
+func (m *MockDeliveryAttemptRepository) FindDeliveryAttemptByID(ctx context.Context, eventDeliveryID, id string) (*datastore.DeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveryAttemptByID", ctx, eventDeliveryID, id)
+	ret0, _ := ret[0].(*datastore.DeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveryAttemptByID indicates an expected call of FindDeliveryAttemptByID.
+func (mr *MockDeliveryAttemptRepositoryMockRecorder) FindDeliveryAttemptByID(ctx, eventDeliveryID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveryAttemptByID", reflect.TypeOf((*MockDeliveryAttemptRepository)(nil).FindDeliveryAttemptByID), ctx, eventDeliveryID, id)
+}
+
+// LoadDeliveryAttemptsPaged mocks base method.
+func (m *MockDeliveryAttemptRepository) LoadDeliveryAttemptsPaged(arg0 context.Context, arg1 *datastore.DeliveryAttemptFilter, arg2 datastore.Pageable) ([]datastore.DeliveryAttempt, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDeliveryAttemptsPaged", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]datastore.DeliveryAttempt)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadDeliveryAttemptsPaged indicates an expected call of LoadDeliveryAttemptsPaged.
+func (mr *MockDeliveryAttemptRepositoryMockRecorder) LoadDeliveryAttemptsPaged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDeliveryAttemptsPaged", reflect.TypeOf((*MockDeliveryAttemptRepository)(nil).LoadDeliveryAttemptsPaged), arg0, arg1, arg2)
+}
+
+// MockTestAttemptRepository is a mock of TestAttemptRepository interface.
+type MockTestAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTestAttemptRepositoryMockRecorder
+}
+
+// MockTestAttemptRepositoryMockRecorder is the mock recorder for MockTestAttemptRepository.
+type MockTestAttemptRepositoryMockRecorder struct {
+	mock *MockTestAttemptRepository
+}
+
+// NewMockTestAttemptRepository creates a new mock instance.
+func NewMockTestAttemptRepository(ctrl *gomock.Controller) *MockTestAttemptRepository {
+	mock := &MockTestAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockTestAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTestAttemptRepository) EXPECT() *MockTestAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTestAttempt mocks base method.
+func (m *MockTestAttemptRepository) CreateTestAttempt(arg0 context.Context, arg1 *datastore.TestAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTestAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTestAttempt indicates an expected call of CreateTestAttempt.
+func (mr *MockTestAttemptRepositoryMockRecorder) CreateTestAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTestAttempt", reflect.TypeOf((*MockTestAttemptRepository)(nil).CreateTestAttempt), arg0, arg1)
+}
+
+// LoadTestAttemptsPaged mocks base method.
+func (m *MockTestAttemptRepository) LoadTestAttemptsPaged(ctx context.Context, groupID, appID, endpointID string, pageable datastore.Pageable) ([]datastore.TestAttempt, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadTestAttemptsPaged", ctx, groupID, appID, endpointID, pageable)
+	ret0, _ := ret[0].([]datastore.TestAttempt)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadTestAttemptsPaged indicates an expected call of LoadTestAttemptsPaged.
+func (mr *MockTestAttemptRepositoryMockRecorder) LoadTestAttemptsPaged(ctx, groupID, appID, endpointID, pageable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTestAttemptsPaged", reflect.TypeOf((*MockTestAttemptRepository)(nil).LoadTestAttemptsPaged), ctx, groupID, appID, endpointID, pageable)
+}
+
 // MockUserRepository is a mock of UserRepository interface.
 type MockUserRepository struct {
 	ctrl     *gomock.Controller
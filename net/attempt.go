@@ -0,0 +1,59 @@
+package net
+
+import (
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeliveryAttemptAPIVersion is the api version recorded with delivery
+// attempts.
+const DeliveryAttemptAPIVersion = "2021-08-27"
+
+// NewDeliveryAttempt records the request sent to e and the response it
+// returned, resp may be nil or partially set when the request failed.
+func NewDeliveryAttempt(e *datastore.Endpoint, resp *Response, attemptStatus bool) datastore.DeliveryAttempt {
+	attempt := datastore.DeliveryAttempt{
+		ID:         primitive.NewObjectID(),
+		UID:        uuid.New().String(),
+		URL:        e.TargetURL,
+		Method:     string(convoy.HttpPost),
+		EndpointID: e.UID,
+		APIVersion: DeliveryAttemptAPIVersion,
+		Status:     attemptStatus,
+
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	if resp == nil {
+		return attempt
+	}
+
+	if resp.URL != nil {
+		attempt.URL = resp.URL.String()
+	}
+
+	if resp.Method != "" {
+		attempt.Method = resp.Method
+	}
+
+	if resp.RequestHeader != nil {
+		attempt.RequestHeader = *util.ConvertDefaultHeaderToCustomHeader(&resp.RequestHeader)
+	}
+
+	if resp.ResponseHeader != nil {
+		attempt.ResponseHeader = *util.ConvertDefaultHeaderToCustomHeader(&resp.ResponseHeader)
+	}
+
+	attempt.IPAddress = resp.IP
+	attempt.HttpResponseCode = resp.Status
+	attempt.ResponseData = string(resp.Body)
+	attempt.Error = resp.Error
+
+	return attempt
+}
@@ -0,0 +1,43 @@
+package net
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeliveryAttempt(t *testing.T) {
+	endpoint := &datastore.Endpoint{UID: "endpoint-1", TargetURL: "https://example.com/webhook"}
+
+	attempt := NewDeliveryAttempt(endpoint, nil, false)
+	require.Equal(t, endpoint.TargetURL, attempt.URL)
+	require.Equal(t, http.MethodPost, attempt.Method)
+	require.Equal(t, endpoint.UID, attempt.EndpointID)
+	require.Equal(t, DeliveryAttemptAPIVersion, attempt.APIVersion)
+
+	u, err := url.Parse("https://example.com/webhook?retry=1")
+	require.NoError(t, err)
+
+	resp := &Response{
+		Status:         "200 OK",
+		Method:         http.MethodPut,
+		URL:            u,
+		RequestHeader:  http.Header{"X-Convoy-Signature": []string{"sig"}},
+		ResponseHeader: http.Header{"Content-Type": []string{"text/plain"}},
+		Body:           []byte("ok"),
+		IP:             "127.0.0.1",
+	}
+
+	attempt = NewDeliveryAttempt(endpoint, resp, true)
+	require.True(t, attempt.Status)
+	require.Equal(t, u.String(), attempt.URL)
+	require.Equal(t, http.MethodPut, attempt.Method)
+	require.Equal(t, "200 OK", attempt.HttpResponseCode)
+	require.Equal(t, "ok", attempt.ResponseData)
+	require.Equal(t, "127.0.0.1", attempt.IPAddress)
+	require.Equal(t, "sig", attempt.RequestHeader["X-Convoy-Signature"])
+	require.Equal(t, "text/plain", attempt.ResponseHeader["Content-Type"])
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptrace"
@@ -18,6 +19,26 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// SignPayload computes the signature sent with a request to an endpoint.
+// When the group guards against replay attacks the signed payload is
+// prefixed with the returned timestamp, which must be sent along.
+func SignPayload(g *datastore.Group, secret string, payload []byte) (hmac string, timestamp string, err error) {
+	var signedPayload strings.Builder
+	if g.Config.ReplayAttacks {
+		timestamp = fmt.Sprint(time.Now().Unix())
+		signedPayload.WriteString(timestamp)
+		signedPayload.WriteString(",")
+	}
+	signedPayload.Write(payload)
+
+	hmac, err = util.ComputeJSONHmac(g.Config.Signature.Hash, signedPayload.String(), secret, false)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hmac, timestamp, nil
+}
+
 type Dispatcher struct {
 	client *http.Client
 }
@@ -29,6 +50,12 @@ func NewDispatcher(timeout time.Duration) *Dispatcher {
 }
 
 func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessage, g *datastore.Group, hmac string, timestamp string, maxResponseSize int64, headers httpheader.HTTPHeader) (*Response, error) {
+	return d.SendRawRequest(endpoint, method, jsonData, "application/json", g, hmac, timestamp, maxResponseSize, headers)
+}
+
+// SendRawRequest sends payload as it is with the given content type, it is
+// used to forward the original body of ingested events.
+func (d *Dispatcher) SendRawRequest(endpoint, method string, payload []byte, contentType string, g *datastore.Group, hmac string, timestamp string, maxResponseSize int64, headers httpheader.HTTPHeader) (*Response, error) {
 	r := &Response{}
 	signatureHeader := g.Config.Signature.Header.String()
 	if util.IsStringEmpty(signatureHeader) || util.IsStringEmpty(hmac) {
@@ -38,14 +65,14 @@ func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessa
 		return r, err
 	}
 
-	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(payload))
 	if err != nil {
 		log.WithError(err).Error("error occurred while creating request")
 		return r, err
 	}
 
 	req.Header.Set(signatureHeader, hmac)
-	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Content-Type", contentType)
 	req.Header.Add("User-Agent", defaultUserAgent())
 	if g.Config.ReplayAttacks {
 		if util.IsStringEmpty(timestamp) {
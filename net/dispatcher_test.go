@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"testing"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/util"
 	"github.com/jarcoal/httpmock"
 
 	"github.com/frain-dev/convoy/config"
@@ -334,3 +336,74 @@ func TestDispatcher_SendRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestSignPayload(t *testing.T) {
+	payload := []byte(`{"name":"convoy"}`)
+
+	tests := []struct {
+		name          string
+		replayAttacks bool
+	}{
+		{
+			name:          "should_sign_payload",
+			replayAttacks: false,
+		},
+		{
+			name:          "should_sign_payload_with_timestamp",
+			replayAttacks: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &datastore.Group{
+				Config: &datastore.GroupConfig{
+					Signature:     &datastore.DefaultSignatureConfig,
+					ReplayAttacks: tt.replayAttacks,
+				},
+			}
+
+			hmac, timestamp, err := SignPayload(g, "secret", payload)
+			require.NoError(t, err)
+
+			signedPayload := string(payload)
+			if tt.replayAttacks {
+				require.NotEmpty(t, timestamp)
+				signedPayload = timestamp + "," + signedPayload
+			} else {
+				require.Empty(t, timestamp)
+			}
+
+			want, err := util.ComputeJSONHmac("SHA256", signedPayload, "secret", false)
+			require.NoError(t, err)
+			require.Equal(t, want, hmac)
+		})
+	}
+}
+
+func TestDispatcher_SendRawRequest(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	payload := []byte(`MessageSid=SM123&Body=Hello`)
+	httpmock.RegisterResponder(http.MethodPost, "https://google.com",
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			require.Equal(t, payload, body)
+			require.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+
+			return httpmock.NewStringResponse(http.StatusOK, string(successBody)), nil
+		})
+
+	g := &datastore.Group{
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.DefaultSignatureConfig,
+		},
+	}
+
+	d := NewDispatcher(10 * time.Second)
+	resp, err := d.SendRawRequest("https://google.com", http.MethodPost, payload, "application/x-www-form-urlencoded", g, "hmac", "", config.MaxResponseSize, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
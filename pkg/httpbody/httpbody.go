@@ -0,0 +1,202 @@
+package httpbody
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrUnsupportedContentType = errors.New("unsupported content type")
+	ErrEmptyXMLDocument       = errors.New("xml document has no root element")
+)
+
+// Normalize converts a request body of the given content type to JSON.
+// Form-urlencoded and multipart bodies become objects of their fields and
+// XML documents an object keyed by the root element. It reports whether
+// the body was converted, JSON bodies are returned as they are.
+func Normalize(contentType string, body []byte) (json.RawMessage, bool, error) {
+	if len(strings.TrimSpace(contentType)) == 0 {
+		return body, false, nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid content type: %s", contentType)
+	}
+
+	var data interface{}
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return body, false, nil
+
+	case mediaType == "application/x-www-form-urlencoded":
+		data, err = formToMap(body)
+
+	case mediaType == "multipart/form-data":
+		data, err = multipartToMap(body, params["boundary"])
+
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		data, err = xmlToMap(body)
+
+	default:
+		// clients often label JSON bodies as text/plain.
+		if json.Valid(body) {
+			return body, false, nil
+		}
+		return nil, false, ErrUnsupportedContentType
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return b, true, nil
+}
+
+func formToMap(body []byte) (map[string]interface{}, error) {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid form body: %v", err)
+	}
+
+	return valuesToMap(values), nil
+}
+
+func multipartToMap(body []byte, boundary string) (map[string]interface{}, error) {
+	if len(boundary) == 0 {
+		return nil, errors.New("multipart body has no boundary")
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(body), boundary).ReadForm(int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid multipart body: %v", err)
+	}
+	defer func() { _ = form.RemoveAll() }()
+
+	fields := valuesToMap(form.Value)
+	for name, files := range form.File {
+		list := make([]interface{}, 0, len(files))
+		for _, f := range files {
+			list = append(list, map[string]interface{}{
+				"filename":     f.Filename,
+				"content_type": f.Header.Get("Content-Type"),
+				"size":         f.Size,
+			})
+		}
+
+		if len(list) == 1 {
+			fields[name] = list[0]
+			continue
+		}
+		fields[name] = list
+	}
+
+	return fields, nil
+}
+
+// valuesToMap keeps single values as strings and repeated ones as lists.
+func valuesToMap(values map[string][]string) map[string]interface{} {
+	fields := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			fields[k] = v[0]
+			continue
+		}
+		fields[k] = v
+	}
+
+	return fields
+}
+
+// xmlToMap converts an XML document to a map keyed by its root element.
+// Attributes are prefixed with @, repeated elements become lists and the
+// text of elements with children or attributes is kept under #text.
+func xmlToMap(body []byte) (map[string]interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmptyXMLDocument
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid xml body: %v", err)
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			v, err := decodeXMLElement(dec, start)
+			if err != nil {
+				return nil, fmt.Errorf("invalid xml body: %v", err)
+			}
+
+			return map[string]interface{}{start.Name.Local: v}, nil
+		}
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	fields := map[string]interface{}{}
+	for _, attr := range start.Attr {
+		fields["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			v, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			addXMLField(fields, t.Name.Local, v)
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(fields) == 0 {
+				return s, nil
+			}
+
+			if len(s) > 0 {
+				fields["#text"] = s
+			}
+			return fields, nil
+		}
+	}
+}
+
+func addXMLField(fields map[string]interface{}, key string, v interface{}) {
+	existing, ok := fields[key]
+	if !ok {
+		fields[key] = v
+		return
+	}
+
+	if list, ok := existing.([]interface{}); ok {
+		fields[key] = append(list, v)
+		return
+	}
+
+	fields[key] = []interface{}{existing, v}
+}
@@ -0,0 +1,112 @@
+package httpbody
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Normalize(t *testing.T) {
+	tests := map[string]struct {
+		contentType        string
+		body               []byte
+		expectedData       string
+		expectedNormalized bool
+		expectedError      string
+	}{
+		"json": {
+			contentType:  "application/json; charset=utf-8",
+			body:         []byte(`{"type":"charge.success"}`),
+			expectedData: `{"type":"charge.success"}`,
+		},
+		"vendor_json": {
+			contentType:  "application/vnd.api+json",
+			body:         []byte(`{"type":"charge.success"}`),
+			expectedData: `{"type":"charge.success"}`,
+		},
+		"no_content_type": {
+			body:         []byte(`{"type":"charge.success"}`),
+			expectedData: `{"type":"charge.success"}`,
+		},
+		"json_as_text": {
+			contentType:  "text/plain",
+			body:         []byte(`{"type":"charge.success"}`),
+			expectedData: `{"type":"charge.success"}`,
+		},
+		"form_urlencoded": {
+			contentType:        "application/x-www-form-urlencoded",
+			body:               []byte(`MessageSid=SM123&Body=Hello+there&MediaUrl=a&MediaUrl=b`),
+			expectedData:       `{"Body":"Hello there","MediaUrl":["a","b"],"MessageSid":"SM123"}`,
+			expectedNormalized: true,
+		},
+		"xml": {
+			contentType:        "application/xml",
+			body:               []byte(`<?xml version="1.0"?><notification id="1"><type>payment</type><item>a</item><item>b</item><amount currency="USD">10</amount></notification>`),
+			expectedData:       `{"notification":{"@id":"1","amount":{"#text":"10","@currency":"USD"},"item":["a","b"],"type":"payment"}}`,
+			expectedNormalized: true,
+		},
+		"empty_xml": {
+			contentType:   "text/xml",
+			body:          []byte(`<?xml version="1.0"?>`),
+			expectedError: "xml document has no root element",
+		},
+		"invalid_xml": {
+			contentType:   "text/xml",
+			body:          []byte(`<notification><type>payment</notification>`),
+			expectedError: "invalid xml body: XML syntax error on line 1: element <type> closed by </notification>",
+		},
+		"multipart_without_boundary": {
+			contentType:   "multipart/form-data",
+			body:          []byte(`field`),
+			expectedError: "multipart body has no boundary",
+		},
+		"unsupported_content_type": {
+			contentType:   "application/octet-stream",
+			body:          []byte{0x01, 0x02},
+			expectedError: "unsupported content type",
+		},
+		"invalid_content_type": {
+			contentType:   "application/",
+			body:          []byte(`{}`),
+			expectedError: "invalid content type: application/",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, normalized, err := Normalize(tc.contentType, tc.body)
+			if len(tc.expectedError) > 0 {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedNormalized, normalized)
+			require.JSONEq(t, tc.expectedData, string(data))
+		})
+	}
+}
+
+func Test_Normalize_Multipart(t *testing.T) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	require.NoError(t, w.WriteField("payment_status", "Completed"))
+	require.NoError(t, w.WriteField("txn_id", "61E67681CH3238416"))
+
+	f, err := w.CreateFormFile("receipt", "receipt.txt")
+	require.NoError(t, err)
+	_, err = f.Write([]byte("receipt"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, normalized, err := Normalize(w.FormDataContentType(), body.Bytes())
+	require.NoError(t, err)
+	require.True(t, normalized)
+	require.JSONEq(t, `{
+		"payment_status": "Completed",
+		"txn_id": "61E67681CH3238416",
+		"receipt": {"filename": "receipt.txt", "content_type": "application/octet-stream", "size": 7}
+	}`, string(data))
+}
@@ -1,5 +1,12 @@
 package httpheader
 
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
 // HTTPHeader is our custom  header type that can merge fields.
 type HTTPHeader map[string][]string
 
@@ -15,3 +22,97 @@ func (h HTTPHeader) MergeHeaders(nh HTTPHeader) {
 		h[k] = v
 	}
 }
+
+// hopByHopHeaders only apply to a single connection and are never forwarded.
+var hopByHopHeaders = []string{
+	"connection",
+	"keep-alive",
+	"proxy-authenticate",
+	"proxy-authorization",
+	"proxy-connection",
+	"te",
+	"trailer",
+	"transfer-encoding",
+	"upgrade",
+}
+
+// sensitiveHeaders carry credentials or signatures, wildcards never match
+// them so they are only forwarded when named explicitly.
+var sensitiveHeaders = []string{
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"*-signature",
+	"*-signature-*",
+	"x-hub-signature*",
+	"*-token",
+	"x-shopify-hmac-*",
+	"verif-hash",
+}
+
+// ValidatePatterns checks that every pattern is a valid header name or
+// wildcard pattern.
+func ValidatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if len(strings.TrimSpace(pattern)) == 0 {
+			return errors.New("header pattern cannot be empty")
+		}
+
+		if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+			return fmt.Errorf("invalid header pattern: %s", pattern)
+		}
+	}
+
+	return nil
+}
+
+// Forward returns the headers matching any of the patterns, which are
+// header names or wildcard patterns such as X-GitHub-*. Hop-by-hop headers
+// are always dropped, and sensitive headers, which includes any extra names
+// passed, are only kept when a pattern names them exactly.
+func (h HTTPHeader) Forward(patterns []string, sensitive ...string) HTTPHeader {
+	forwarded := HTTPHeader{}
+
+	for k, v := range h {
+		name := strings.ToLower(k)
+		if matchAny(hopByHopHeaders, name) {
+			continue
+		}
+
+		isSensitive := matchAny(sensitiveHeaders, name) || matchAny(lower(sensitive), name)
+
+		for _, pattern := range lower(patterns) {
+			if pattern == name || (!isSensitive && matchPattern(pattern, name)) {
+				forwarded[k] = v
+				break
+			}
+		}
+	}
+
+	return forwarded
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPattern(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
+
+func lower(s []string) []string {
+	l := make([]string, len(s))
+	for i := range s {
+		l[i] = strings.ToLower(strings.TrimSpace(s[i]))
+	}
+
+	return l
+}
@@ -55,3 +55,65 @@ func Test_MergeHeaders(t *testing.T) {
 		})
 	}
 }
+
+func Test_Forward(t *testing.T) {
+	header := HTTPHeader(map[string][]string{
+		"Authorization":       {"Bearer secret"},
+		"Connection":          {"keep-alive"},
+		"Content-Type":        {"application/json"},
+		"X-Github-Delivery":   {"c2520a2e-121b-11ed-862c-d3f38c5356fa"},
+		"X-Github-Event":      {"issue_comment"},
+		"X-Hub-Signature-256": {"sha256=abc"},
+		"X-Convoy-Signature":  {"abc"},
+		"X-Source-Key":        {"key"},
+	})
+
+	tests := map[string]struct {
+		patterns  []string
+		sensitive []string
+		expected  []string
+	}{
+		"no_patterns": {
+			expected: []string{},
+		},
+		"exact_names": {
+			patterns: []string{"x-github-event", "Content-Type"},
+			expected: []string{"Content-Type", "X-Github-Event"},
+		},
+		"wildcard_skips_sensitive_headers": {
+			patterns: []string{"X-*"},
+			expected: []string{"X-Github-Delivery", "X-Github-Event", "X-Source-Key"},
+		},
+		"extra_sensitive_headers": {
+			patterns:  []string{"X-*"},
+			sensitive: []string{"X-Source-Key"},
+			expected:  []string{"X-Github-Delivery", "X-Github-Event"},
+		},
+		"sensitive_headers_named_explicitly": {
+			patterns: []string{"Authorization", "X-Hub-Signature-256"},
+			expected: []string{"Authorization", "X-Hub-Signature-256"},
+		},
+		"hop_by_hop_headers_are_dropped": {
+			patterns: []string{"*", "Connection"},
+			expected: []string{"Content-Type", "X-Github-Delivery", "X-Github-Event", "X-Source-Key"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			forwarded := header.Forward(tc.patterns, tc.sensitive...)
+
+			keys := make([]string, 0, len(forwarded))
+			for k := range forwarded {
+				keys = append(keys, k)
+			}
+			require.ElementsMatch(t, tc.expected, keys)
+		})
+	}
+}
+
+func Test_ValidatePatterns(t *testing.T) {
+	require.NoError(t, ValidatePatterns([]string{"X-GitHub-*", "Content-Type"}))
+	require.EqualError(t, ValidatePatterns([]string{"X-GitHub-["}), "invalid header pattern: X-GitHub-[")
+	require.EqualError(t, ValidatePatterns([]string{" "}), "header pattern cannot be empty")
+}
@@ -0,0 +1,93 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// NewEventType returns the function deriving event types from requests as
+// configured by cfg. Exactly one of the header, JSON path or template must
+// be set.
+func NewEventType(cfg *datastore.SourceEventType) (func(r *http.Request, payload []byte) string, error) {
+	set := 0
+	for _, v := range []string{cfg.Header, cfg.JSONPath, cfg.Template} {
+		if len(strings.TrimSpace(v)) > 0 {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return nil, errors.New("event type requires exactly one of header, json path or template")
+	}
+
+	switch {
+	case len(strings.TrimSpace(cfg.Header)) > 0:
+		return HeaderEventType(strings.TrimSpace(cfg.Header)), nil
+	case len(strings.TrimSpace(cfg.JSONPath)) > 0:
+		return JSONEventType(strings.Split(strings.TrimSpace(cfg.JSONPath), ".")...), nil
+	}
+
+	tmpl, err := template.New("event_type").Parse(cfg.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event type template: %v", err)
+	}
+
+	return func(r *http.Request, payload []byte) string {
+		data := &eventTypeData{r: r}
+		_ = json.Unmarshal(payload, &data.body)
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return ""
+		}
+
+		return strings.TrimSpace(buf.String())
+	}, nil
+}
+
+// eventTypeData is what event type templates are executed with.
+type eventTypeData struct {
+	r    *http.Request
+	body interface{}
+}
+
+// Header returns the value of the request header name.
+func (d *eventTypeData) Header(name string) string {
+	return d.r.Header.Get(name)
+}
+
+// JSON returns the body field at the dot separated path.
+func (d *eventTypeData) JSON(path string) string {
+	return jsonPathValue(d.body, strings.Split(path, "."))
+}
+
+// jsonPathValue returns the string, number or boolean field of a decoded
+// JSON body at the given path.
+func jsonPathValue(body interface{}, path []string) string {
+	for _, key := range path {
+		fields, ok := body.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		body = fields[key]
+	}
+
+	switch v := body.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,74 @@
+package verifier
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewEventType(t *testing.T) {
+	payload := []byte(`{"action":"opened","data":{"object":{"type":"invoice.paid","attempt":2,"live":false}}}`)
+
+	tests := map[string]struct {
+		cfg               *datastore.SourceEventType
+		expectedEventType string
+		expectedError     string
+	}{
+		"header": {
+			cfg:               &datastore.SourceEventType{Header: "X-GitHub-Event"},
+			expectedEventType: "pull_request",
+		},
+		"json_path": {
+			cfg:               &datastore.SourceEventType{JSONPath: "data.object.type"},
+			expectedEventType: "invoice.paid",
+		},
+		"json_path_number": {
+			cfg:               &datastore.SourceEventType{JSONPath: "data.object.attempt"},
+			expectedEventType: "2",
+		},
+		"json_path_bool": {
+			cfg:               &datastore.SourceEventType{JSONPath: "data.object.live"},
+			expectedEventType: "false",
+		},
+		"missing_json_path": {
+			cfg:               &datastore.SourceEventType{JSONPath: "data.type"},
+			expectedEventType: "",
+		},
+		"template": {
+			cfg:               &datastore.SourceEventType{Template: `{{ .Header "X-GitHub-Event" }}.{{ .JSON "action" }}`},
+			expectedEventType: "pull_request.opened",
+		},
+		"invalid_template": {
+			cfg:           &datastore.SourceEventType{Template: `{{ .Header "X-GitHub-Event" }`},
+			expectedError: `invalid event type template: template: event_type:1: unexpected "}" in operand`,
+		},
+		"no_source": {
+			cfg:           &datastore.SourceEventType{},
+			expectedError: "event type requires exactly one of header, json path or template",
+		},
+		"many_sources": {
+			cfg:           &datastore.SourceEventType{Header: "X-GitHub-Event", JSONPath: "action"},
+			expectedError: "event type requires exactly one of header, json path or template",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			eventType, err := NewEventType(tc.cfg)
+			if len(tc.expectedError) > 0 {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("X-GitHub-Event", "pull_request")
+
+			require.Equal(t, tc.expectedEventType, eventType(req, payload))
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package verifier
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/frain-dev/convoy/datastore"
+)
+
+// NewIdempotencyKey returns the function reading the key identifying
+// retries of a request as configured by cfg. At most one of the header or
+// JSON path can be set, it returns nil when neither is.
+func NewIdempotencyKey(cfg *datastore.SourceDeduplication) (func(r *http.Request, payload []byte) string, error) {
+	header, jsonPath := strings.TrimSpace(cfg.Header), strings.TrimSpace(cfg.JSONPath)
+
+	switch {
+	case len(header) > 0 && len(jsonPath) > 0:
+		return nil, errors.New("deduplication accepts only one of header or json path")
+	case len(header) > 0:
+		return HeaderEventType(header), nil
+	case len(jsonPath) > 0:
+		return JSONEventType(strings.Split(jsonPath, ".")...), nil
+	}
+
+	return nil, nil
+}
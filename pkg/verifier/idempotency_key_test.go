@@ -0,0 +1,64 @@
+package verifier
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewIdempotencyKey(t *testing.T) {
+	payload := []byte(`{"id":"evt_1NG8Du2eZvKYlo2C","data":{"attempt":2}}`)
+
+	tests := map[string]struct {
+		cfg                    *datastore.SourceDeduplication
+		expectedIdempotencyKey string
+		expectedNil            bool
+		expectedError          string
+	}{
+		"header": {
+			cfg:                    &datastore.SourceDeduplication{Header: "X-GitHub-Delivery"},
+			expectedIdempotencyKey: "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		},
+		"json_path": {
+			cfg:                    &datastore.SourceDeduplication{JSONPath: "id"},
+			expectedIdempotencyKey: "evt_1NG8Du2eZvKYlo2C",
+		},
+		"nested_json_path": {
+			cfg:                    &datastore.SourceDeduplication{JSONPath: "data.attempt"},
+			expectedIdempotencyKey: "2",
+		},
+		"provider_default": {
+			cfg:         &datastore.SourceDeduplication{Window: 60},
+			expectedNil: true,
+		},
+		"many_sources": {
+			cfg:           &datastore.SourceDeduplication{Header: "X-GitHub-Delivery", JSONPath: "id"},
+			expectedError: "deduplication accepts only one of header or json path",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			idempotencyKey, err := NewIdempotencyKey(tc.cfg)
+			if len(tc.expectedError) > 0 {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.expectedNil {
+				require.Nil(t, idempotencyKey)
+				return
+			}
+
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+
+			require.Equal(t, tc.expectedIdempotencyKey, idempotencyKey(req, payload))
+		})
+	}
+}
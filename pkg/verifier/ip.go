@@ -0,0 +1,115 @@
+package verifier
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// IPAllowlist is a list of networks requests are allowed from.
+type IPAllowlist []*net.IPNet
+
+// NewIPAllowlist parses a list of CIDRs, plain IPs are treated as
+// single address networks.
+func NewIPAllowlist(entries []string) (IPAllowlist, error) {
+	allowlist := make(IPAllowlist, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address or cidr: %s", entry)
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			allowlist = append(allowlist, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ip address or cidr: %s", entry)
+		}
+
+		allowlist = append(allowlist, ipNet)
+	}
+
+	return allowlist, nil
+}
+
+// Contains reports whether ip is in any of the allowed networks.
+func (l IPAllowlist) Contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range l {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIP returns the address of the client that sent r. The
+// X-Forwarded-For header is only used when the request came from a
+// trusted proxy, in which case the right-most address not belonging to
+// a trusted proxy is the client.
+func ClientIP(r *http.Request, trustedProxies IPAllowlist) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if !trustedProxies.Contains(ip) {
+		return ip
+	}
+
+	var forwarded []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(header, ",")...)
+	}
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(forwarded[i]))
+		if hop == nil {
+			break
+		}
+
+		ip = hop
+		if !trustedProxies.Contains(hop) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// IPVerifier accepts requests whose client IP is in the allowlist.
+type IPVerifier struct {
+	allowlist      IPAllowlist
+	trustedProxies IPAllowlist
+}
+
+func NewIPVerifier(allowlist, trustedProxies IPAllowlist) *IPVerifier {
+	return &IPVerifier{
+		allowlist:      allowlist,
+		trustedProxies: trustedProxies,
+	}
+}
+
+func (iV *IPVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	if !iV.allowlist.Contains(ClientIP(r, iV.trustedProxies)) {
+		return ErrInvalidIP
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package verifier
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewIPAllowlist(t *testing.T) {
+	allowlist, err := NewIPAllowlist([]string{"192.30.252.0/22", " 10.0.0.1 ", "2a0a:a440::/29", "::1"})
+	require.NoError(t, err)
+	require.Len(t, allowlist, 4)
+
+	_, err = NewIPAllowlist([]string{"192.30.252.0/33"})
+	require.EqualError(t, err, "invalid ip address or cidr: 192.30.252.0/33")
+
+	_, err = NewIPAllowlist([]string{"not-an-ip"})
+	require.EqualError(t, err, "invalid ip address or cidr: not-an-ip")
+}
+
+func Test_IPVerifier_VerifyRequest(t *testing.T) {
+	tests := map[string]struct {
+		allowlist      []string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   []string
+		expectedError  error
+	}{
+		"allowed_ip": {
+			allowlist:  []string{"192.30.252.0/22"},
+			remoteAddr: "192.30.252.10:4300",
+		},
+		"allowed_single_ip": {
+			allowlist:  []string{"10.0.0.1"},
+			remoteAddr: "10.0.0.1:4300",
+		},
+		"allowed_ipv6": {
+			allowlist:  []string{"2a0a:a440::/29"},
+			remoteAddr: "[2a0a:a440::1]:4300",
+		},
+		"ip_not_in_allowlist": {
+			allowlist:     []string{"192.30.252.0/22"},
+			remoteAddr:    "172.16.0.5:4300",
+			expectedError: ErrInvalidIP,
+		},
+		"forwarded_for_ignored_from_untrusted_peer": {
+			allowlist:     []string{"192.30.252.0/22"},
+			remoteAddr:    "172.16.0.5:4300",
+			forwardedFor:  []string{"192.30.252.10"},
+			expectedError: ErrInvalidIP,
+		},
+		"forwarded_for_used_from_trusted_proxy": {
+			allowlist:      []string{"192.30.252.0/22"},
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:4300",
+			forwardedFor:   []string{"192.30.252.10, 10.0.0.3"},
+		},
+		"spoofed_forwarded_for_rejected": {
+			allowlist:      []string{"192.30.252.0/22"},
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:4300",
+			forwardedFor:   []string{"192.30.252.10", "172.16.0.5"},
+			expectedError:  ErrInvalidIP,
+		},
+		"trusted_proxy_without_forwarded_for": {
+			allowlist:      []string{"192.30.252.0/22"},
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.0.0.2:4300",
+			expectedError:  ErrInvalidIP,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			allowlist, err := NewIPAllowlist(tc.allowlist)
+			require.NoError(t, err)
+
+			trustedProxies, err := NewIPAllowlist(tc.trustedProxies)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+
+			req.RemoteAddr = tc.remoteAddr
+			for _, header := range tc.forwardedFor {
+				req.Header.Add("X-Forwarded-For", header)
+			}
+
+			v := NewIPVerifier(allowlist, trustedProxies)
+			require.Equal(t, tc.expectedError, v.VerifyRequest(req, nil))
+		})
+	}
+}
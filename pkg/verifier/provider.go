@@ -0,0 +1,319 @@
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/internal/pkg/crc"
+)
+
+// Provider describes a webhook provider sources can be created for.
+// Adding a provider only requires registering it here.
+type Provider struct {
+	// Name is the provider sources are created with, e.g. github.
+	Name string
+
+	// NewVerifier builds the request verifier from the source secret.
+	NewVerifier func(secret string) Verifier
+
+	// NewCrc builds the handler answering the provider's CRC or
+	// challenge requests. It is nil for providers without one.
+	NewCrc func(secret string) crc.Crc
+
+	// VerifyChallenge is set for providers signing their challenge
+	// requests, which are then verified before they are answered.
+	VerifyChallenge bool
+
+	// HandshakeInterval is how often the provider repeats its challenge,
+	// it is zero for providers that only send one when set up.
+	HandshakeInterval time.Duration
+
+	// EventType extracts the event type of a verified request. It is
+	// nil for providers whose requests don't carry one.
+	EventType func(r *http.Request, payload []byte) string
+
+	// IdempotencyKey reads the ID the provider sends with every attempt
+	// of a delivery. It is nil for providers without one.
+	IdempotencyKey func(r *http.Request, payload []byte) string
+
+	// ForwardHeaders are the request headers forwarded to endpoints
+	// for sources of this provider when none are configured.
+	ForwardHeaders []string
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]*Provider{}
+)
+
+// Register adds a provider to the registry. It panics if the provider
+// is incomplete or a provider with the same name exists.
+func Register(p *Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	if p == nil || len(p.Name) == 0 || p.NewVerifier == nil {
+		panic("verifier: provider name and verifier are required")
+	}
+
+	if _, ok := providers[p.Name]; ok {
+		panic(fmt.Sprintf("verifier: provider %s is already registered", p.Name))
+	}
+
+	providers[p.Name] = p
+}
+
+// GetProvider returns the registered provider with the given name.
+func GetProvider(name string) (*Provider, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	p, ok := providers[name]
+	return p, ok
+}
+
+// Providers returns the names of the registered providers, sorted.
+func Providers() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// HeaderEventType reads the event type from a request header.
+func HeaderEventType(header string) func(r *http.Request, payload []byte) string {
+	return func(r *http.Request, payload []byte) string {
+		return r.Header.Get(header)
+	}
+}
+
+// JSONEventType reads the event type from the field of a JSON body at
+// the given path.
+func JSONEventType(path ...string) func(r *http.Request, payload []byte) string {
+	return func(r *http.Request, payload []byte) string {
+		var body interface{}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return ""
+		}
+
+		return jsonPathValue(body, path)
+	}
+}
+
+func init() {
+	Register(&Provider{
+		Name: "github",
+		NewVerifier: func(secret string) Verifier {
+			return NewGithubVerifier(secret)
+		},
+		EventType:      HeaderEventType("X-GitHub-Event"),
+		IdempotencyKey: HeaderEventType("X-GitHub-Delivery"),
+		ForwardHeaders: []string{"X-GitHub-Event", "X-GitHub-Delivery", "X-GitHub-Hook-ID"},
+	})
+
+	Register(&Provider{
+		Name: "twitter",
+		NewVerifier: func(secret string) Verifier {
+			return NewTwitterVerifier(secret)
+		},
+		NewCrc: func(secret string) crc.Crc {
+			return crc.NewTwitterCrc(secret)
+		},
+		HandshakeInterval: 2 * time.Hour,
+		EventType:         twitterEventType,
+	})
+
+	Register(&Provider{
+		Name: "shopify",
+		NewVerifier: func(secret string) Verifier {
+			return NewShopifyVerifier(secret)
+		},
+		EventType:      HeaderEventType("X-Shopify-Topic"),
+		IdempotencyKey: HeaderEventType("X-Shopify-Webhook-Id"),
+		ForwardHeaders: []string{
+			"X-Shopify-Topic",
+			"X-Shopify-Shop-Domain",
+			"X-Shopify-API-Version",
+			"X-Shopify-Webhook-Id",
+		},
+	})
+
+	Register(&Provider{
+		Name: "stripe",
+		NewVerifier: func(secret string) Verifier {
+			return NewStripeVerifier(secret)
+		},
+		EventType:      JSONEventType("type"),
+		IdempotencyKey: JSONEventType("id"),
+	})
+
+	Register(&Provider{
+		Name: "gitlab",
+		NewVerifier: func(secret string) Verifier {
+			return NewAPIKeyVerifier(secret, "X-Gitlab-Token")
+		},
+		EventType:      HeaderEventType("X-Gitlab-Event"),
+		IdempotencyKey: HeaderEventType("Idempotency-Key"),
+		ForwardHeaders: []string{"X-Gitlab-Event", "X-Gitlab-Event-UUID", "X-Gitlab-Instance"},
+	})
+
+	Register(&Provider{
+		Name: "bitbucket",
+		NewVerifier: func(secret string) Verifier {
+			return NewBitbucketVerifier(secret)
+		},
+		EventType:      HeaderEventType("X-Event-Key"),
+		ForwardHeaders: []string{"X-Event-Key", "X-Request-UUID", "X-Hook-UUID"},
+	})
+
+	Register(&Provider{
+		Name: "slack",
+		NewVerifier: func(secret string) Verifier {
+			return NewSlackVerifier(secret)
+		},
+		NewCrc: func(secret string) crc.Crc {
+			return crc.NewSlackCrc()
+		},
+		VerifyChallenge: true,
+		EventType:       slackEventType,
+		IdempotencyKey:  JSONEventType("event_id"),
+	})
+
+	Register(&Provider{
+		Name: "twilio",
+		NewVerifier: func(secret string) Verifier {
+			return NewTwilioVerifier(secret)
+		},
+	})
+
+	Register(&Provider{
+		Name: "paystack",
+		NewVerifier: func(secret string) Verifier {
+			return NewPaystackVerifier(secret)
+		},
+		EventType: JSONEventType("event"),
+	})
+
+	Register(&Provider{
+		Name: "flutterwave",
+		NewVerifier: func(secret string) Verifier {
+			return NewAPIKeyVerifier(secret, "verif-hash")
+		},
+		EventType: flutterwaveEventType,
+	})
+
+	Register(&Provider{
+		Name: "zoom",
+		NewVerifier: func(secret string) Verifier {
+			return NewZoomVerifier(secret)
+		},
+		NewCrc: func(secret string) crc.Crc {
+			return crc.NewZoomCrc(secret)
+		},
+		VerifyChallenge:   true,
+		HandshakeInterval: 72 * time.Hour,
+		EventType:         JSONEventType("event"),
+	})
+
+	Register(&Provider{
+		Name: "dropbox",
+		NewVerifier: func(secret string) Verifier {
+			return NewDropboxVerifier(secret)
+		},
+		NewCrc: func(secret string) crc.Crc {
+			return crc.NewDropboxCrc()
+		},
+	})
+
+	Register(&Provider{
+		Name: "msgraph",
+		NewVerifier: func(secret string) Verifier {
+			return NewMSGraphVerifier(secret)
+		},
+		NewCrc: func(secret string) crc.Crc {
+			return crc.NewMSGraphCrc()
+		},
+		EventType: msGraphEventType,
+	})
+
+	Register(&Provider{
+		Name: "meta",
+		NewVerifier: func(secret string) Verifier {
+			return NewMetaVerifier(secret)
+		},
+		NewCrc: func(secret string) crc.Crc {
+			return crc.NewMetaCrc()
+		},
+		EventType: JSONEventType("object"),
+	})
+}
+
+// slackEventType returns the inner event type of Events API callbacks,
+// e.g. app_mention, and the payload type otherwise.
+func slackEventType(r *http.Request, payload []byte) string {
+	if eventType := JSONEventType("event", "type")(r, payload); len(eventType) > 0 {
+		return eventType
+	}
+
+	return JSONEventType("type")(r, payload)
+}
+
+// flutterwaveEventType reads the event type of v3 webhooks, falling back
+// to the event.type field of older payloads.
+func flutterwaveEventType(r *http.Request, payload []byte) string {
+	if eventType := JSONEventType("event")(r, payload); len(eventType) > 0 {
+		return eventType
+	}
+
+	return JSONEventType("event.type")(r, payload)
+}
+
+// msGraphEventType returns the change type of the first notification,
+// e.g. created.
+func msGraphEventType(r *http.Request, payload []byte) string {
+	var body struct {
+		Value []struct {
+			ChangeType string `json:"changeType"`
+		} `json:"value"`
+	}
+
+	if err := json.Unmarshal(payload, &body); err != nil || len(body.Value) == 0 {
+		return ""
+	}
+
+	return body.Value[0].ChangeType
+}
+
+// twitterEventType returns the activity type of Account Activity API
+// payloads, which is the *_events key they carry, e.g. tweet_create_events.
+func twitterEventType(r *http.Request, payload []byte) string {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return ""
+	}
+
+	keys := make([]string, 0, len(body))
+	for key := range body {
+		if strings.HasSuffix(key, "_events") {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return ""
+	}
+
+	sort.Strings(keys)
+	return keys[0]
+}
@@ -2,14 +2,22 @@ package verifier
 
 import (
 	"crypto/hmac"
+	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"hash"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var ErrAlgoNotFound = errors.New("Algorithm not found")
@@ -24,6 +32,15 @@ var ErrAuthHeaderCannotBeEmpty = errors.New("Auth header cannot be empty")
 var ErrInvalidHeaderStructure = errors.New("Invalid header structure")
 var ErrInvalidAuthLength = errors.New("Invalid Basic Auth Length")
 var ErrInvalidEncoding = errors.New("Invalid header encoding")
+var ErrInvalidTimestamp = errors.New("Invalid signature timestamp")
+var ErrTimestampOutsideTolerance = errors.New("Signature timestamp is outside the tolerance window")
+
+// DefaultTolerance is the maximum age of a timestamped signature
+// when no tolerance is configured.
+const DefaultTolerance = 5 * time.Minute
+
+// timeNow is replaced in tests to check signatures of recorded requests.
+var timeNow = time.Now
 
 type Verifier interface {
 	VerifyRequest(r *http.Request, payload []byte) error
@@ -35,6 +52,26 @@ type HmacOptions struct {
 	Hash         string
 	Secret       string
 	Encoding     string
+
+	// TimestampKey and SignatureKey split composite signature headers
+	// such as Stripe's "t=1492774577,v1=5257a8...,v1=..." into a timestamp
+	// and one or more candidate signatures. Both are empty for headers
+	// holding a single signature.
+	TimestampKey string
+	SignatureKey string
+
+	// TimestampHeader is set for providers sending the signature
+	// timestamp in a header of its own, e.g. Slack.
+	TimestampHeader string
+
+	// SignedPayload is the template of the signed content, {timestamp}
+	// and {payload} are replaced with the request timestamp and body.
+	// It defaults to the body alone.
+	SignedPayload string
+
+	// Tolerance is the maximum age of a timestamped signature,
+	// it defaults to DefaultTolerance.
+	Tolerance time.Duration
 }
 
 type HmacVerifier struct {
@@ -53,50 +90,136 @@ func (hV *HmacVerifier) VerifyRequest(r *http.Request, payload []byte) error {
 		return err
 	}
 
-	signature := r.Header.Get(hV.opts.Header)
+	header := r.Header.Get(hV.opts.Header)
+	signatures := []string{header}
 
-	if hV.opts.GetSignature != nil {
-		signature = hV.opts.GetSignature(signature)
+	var timestamp string
+	if len(hV.opts.TimestampKey) > 0 {
+		timestamp, signatures = hV.parseCompositeHeader(header)
 	}
 
-	if len(strings.TrimSpace(signature)) == 0 {
-		return ErrSignatureCannotBeEmpty
+	if len(hV.opts.TimestampHeader) > 0 {
+		timestamp = r.Header.Get(hV.opts.TimestampHeader)
+	}
+
+	if len(hV.opts.TimestampKey) > 0 || len(hV.opts.TimestampHeader) > 0 {
+		if err := hV.checkTimestamp(timestamp); err != nil {
+			return err
+		}
 	}
 
 	mac := hmac.New(hash, []byte(hV.opts.Secret))
-	mac.Write(payload)
+	mac.Write(hV.signedPayload(timestamp, payload))
 	computedMAC := mac.Sum(nil)
 
-	var sentMAC []byte
+	// the request is valid if any of the candidate signatures match,
+	// providers send several while a secret is being rolled.
+	err = ErrSignatureCannotBeEmpty
+	for _, signature := range signatures {
+		if hV.opts.GetSignature != nil {
+			signature = hV.opts.GetSignature(signature)
+		}
+
+		if len(strings.TrimSpace(signature)) == 0 {
+			continue
+		}
+
+		sentMAC, decodeErr := hV.decodeSignature(signature)
+		if decodeErr != nil {
+			if err == ErrSignatureCannotBeEmpty {
+				err = decodeErr
+			}
+			continue
+		}
+
+		if hmac.Equal(sentMAC, computedMAC) {
+			return nil
+		}
 
-	if hV.opts.Encoding == "hex" {
-		sentMAC, err = hex.DecodeString(signature)
+		err = ErrHashDoesNotMatch
+	}
+
+	return err
+}
+
+func (hV *HmacVerifier) decodeSignature(signature string) ([]byte, error) {
+	switch hV.opts.Encoding {
+	case "hex":
+		sentMAC, err := hex.DecodeString(signature)
 		if err != nil {
-			return ErrCannotDecodeHexEncodedMACHeader
+			return nil, ErrCannotDecodeHexEncodedMACHeader
 		}
-	} else if hV.opts.Encoding == "base64" {
-		sentMAC, err = base64.StdEncoding.DecodeString(signature)
+		return sentMAC, nil
+	case "base64":
+		sentMAC, err := base64.StdEncoding.DecodeString(signature)
 		if err != nil {
-			return ErrCannotDecodeBase64EncodedMACHeader
+			return nil, ErrCannotDecodeBase64EncodedMACHeader
+		}
+		return sentMAC, nil
+	default:
+		return nil, ErrInvalidEncoding
+	}
+}
+
+// parseCompositeHeader reads the timestamp and candidate signatures
+// from a header of comma separated key=value pairs.
+func (hV *HmacVerifier) parseCompositeHeader(header string) (string, []string) {
+	var timestamp string
+	var signatures []string
+
+	for _, pair := range strings.Split(header, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case hV.opts.TimestampKey:
+			timestamp = parts[1]
+		case hV.opts.SignatureKey:
+			signatures = append(signatures, parts[1])
 		}
-	} else {
-		return ErrInvalidEncoding
 	}
 
-	validMAC := hmac.Equal(sentMAC, computedMAC)
-	if !validMAC {
-		return ErrHashDoesNotMatch
+	return timestamp, signatures
+}
+
+func (hV *HmacVerifier) checkTimestamp(timestamp string) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	tolerance := hV.opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	age := timeNow().Sub(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrTimestampOutsideTolerance
 	}
 
 	return nil
 }
 
+func (hV *HmacVerifier) signedPayload(timestamp string, payload []byte) []byte {
+	if len(hV.opts.SignedPayload) == 0 {
+		return payload
+	}
+
+	r := strings.NewReplacer("{timestamp}", timestamp, "{payload}", string(payload))
+	return []byte(r.Replace(hV.opts.SignedPayload))
+}
+
 func (hV *HmacVerifier) getHashFunction(algo string) (func() hash.Hash, error) {
 	switch algo {
 	case "SHA256":
 		return sha256.New, nil
 	case "SHA512":
 		return sha512.New, nil
+	case "SHA1":
+		return sha1.New, nil
 	default:
 		return nil, ErrAlgoNotFound
 	}
@@ -258,6 +381,215 @@ func (tV *TwitterVerifier) getSignature(sig string) string {
 	return strings.Split(sig, "sha256=")[1]
 }
 
+type StripeVerifier struct {
+	HmacOpts *HmacOptions
+}
+
+func NewStripeVerifier(secret string) *StripeVerifier {
+	sv := &StripeVerifier{}
+	sv.HmacOpts = &HmacOptions{
+		Header:        "Stripe-Signature",
+		Hash:          "SHA256",
+		Secret:        secret,
+		Encoding:      "hex",
+		TimestampKey:  "t",
+		SignatureKey:  "v1",
+		SignedPayload: "{timestamp}.{payload}",
+		Tolerance:     DefaultTolerance,
+	}
+
+	return sv
+}
+
+func (sv *StripeVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	v := HmacVerifier{sv.HmacOpts}
+	return v.VerifyRequest(r, payload)
+}
+
+func NewSlackVerifier(secret string) *HmacVerifier {
+	return NewHmacVerifier(&HmacOptions{
+		Header:          "X-Slack-Signature",
+		Hash:            "SHA256",
+		Secret:          secret,
+		Encoding:        "hex",
+		TimestampHeader: "X-Slack-Request-Timestamp",
+		SignedPayload:   "v0:{timestamp}:{payload}",
+		Tolerance:       DefaultTolerance,
+		GetSignature: func(sig string) string {
+			return strings.TrimPrefix(sig, "v0=")
+		},
+	})
+}
+
+func NewBitbucketVerifier(secret string) *HmacVerifier {
+	return NewHmacVerifier(&HmacOptions{
+		Header:   "X-Hub-Signature",
+		Hash:     "SHA256",
+		Secret:   secret,
+		Encoding: "hex",
+		GetSignature: func(sig string) string {
+			return strings.TrimPrefix(sig, "sha256=")
+		},
+	})
+}
+
+func NewPaystackVerifier(secret string) *HmacVerifier {
+	return NewHmacVerifier(&HmacOptions{
+		Header:   "X-Paystack-Signature",
+		Hash:     "SHA512",
+		Secret:   secret,
+		Encoding: "hex",
+	})
+}
+
+// TwilioVerifier checks the X-Twilio-Signature header, an HMAC-SHA1 of
+// the full request URL followed by the sorted form parameters. JSON
+// requests are signed over the URL alone, which carries a bodySHA256
+// parameter holding the hash of the body.
+type TwilioVerifier struct {
+	authToken string
+}
+
+func NewTwilioVerifier(authToken string) *TwilioVerifier {
+	return &TwilioVerifier{authToken: authToken}
+}
+
+func (tv *TwilioVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	signature := r.Header.Get("X-Twilio-Signature")
+	if len(strings.TrimSpace(signature)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	sentMAC, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrCannotDecodeBase64EncodedMACHeader
+	}
+
+	var signedPayload strings.Builder
+	signedPayload.WriteString(requestURL(r))
+
+	bodyHash := r.URL.Query().Get("bodySHA256")
+	if len(bodyHash) > 0 {
+		sum := sha256.Sum256(payload)
+		if !hmac.Equal([]byte(hex.EncodeToString(sum[:])), []byte(bodyHash)) {
+			return ErrHashDoesNotMatch
+		}
+	} else {
+		params, err := url.ParseQuery(string(payload))
+		if err != nil {
+			return ErrCannotReadRequestBody
+		}
+
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			for _, v := range params[k] {
+				signedPayload.WriteString(k)
+				signedPayload.WriteString(v)
+			}
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(tv.authToken))
+	mac.Write([]byte(signedPayload.String()))
+
+	if !hmac.Equal(sentMAC, mac.Sum(nil)) {
+		return ErrHashDoesNotMatch
+	}
+
+	return nil
+}
+
+// requestURL rebuilds the URL the request was sent to, as seen by the
+// sender before any proxy in front of us.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if proto := r.Header.Get("X-Forwarded-Proto"); len(proto) > 0 {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	} else if len(r.URL.Scheme) > 0 {
+		scheme = r.URL.Scheme
+	} else if r.TLS != nil {
+		scheme = "https"
+	}
+
+	host := r.Host
+	if len(host) == 0 {
+		host = r.URL.Host
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, r.URL.RequestURI())
+}
+
+func NewZoomVerifier(secret string) *HmacVerifier {
+	return NewHmacVerifier(&HmacOptions{
+		Header:          "X-Zm-Signature",
+		Hash:            "SHA256",
+		Secret:          secret,
+		Encoding:        "hex",
+		TimestampHeader: "X-Zm-Request-Timestamp",
+		SignedPayload:   "v0:{timestamp}:{payload}",
+		Tolerance:       DefaultTolerance,
+		GetSignature: func(sig string) string {
+			return strings.TrimPrefix(sig, "v0=")
+		},
+	})
+}
+
+func NewDropboxVerifier(secret string) *HmacVerifier {
+	return NewHmacVerifier(&HmacOptions{
+		Header:   "X-Dropbox-Signature",
+		Hash:     "SHA256",
+		Secret:   secret,
+		Encoding: "hex",
+	})
+}
+
+func NewMetaVerifier(secret string) *HmacVerifier {
+	return NewHmacVerifier(&HmacOptions{
+		Header:   "X-Hub-Signature-256",
+		Hash:     "SHA256",
+		Secret:   secret,
+		Encoding: "hex",
+		GetSignature: func(sig string) string {
+			return strings.TrimPrefix(sig, "sha256=")
+		},
+	})
+}
+
+// MSGraphVerifier checks the clientState Microsoft Graph sends with every
+// change notification, since the notifications are not signed.
+type MSGraphVerifier struct {
+	clientState string
+}
+
+func NewMSGraphVerifier(clientState string) *MSGraphVerifier {
+	return &MSGraphVerifier{clientState: clientState}
+}
+
+func (mV *MSGraphVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	var body struct {
+		Value []struct {
+			ClientState string `json:"clientState"`
+		} `json:"value"`
+	}
+
+	if err := json.Unmarshal(payload, &body); err != nil || len(body.Value) == 0 {
+		return ErrHashDoesNotMatch
+	}
+
+	for _, v := range body.Value {
+		if subtle.ConstantTimeCompare([]byte(v.ClientState), []byte(mV.clientState)) != 1 {
+			return ErrHashDoesNotMatch
+		}
+	}
+
+	return nil
+}
+
 type NoopVerifier struct{}
 
 func (nV *NoopVerifier) VerifyRequest(r *http.Request, payload []byte) error {
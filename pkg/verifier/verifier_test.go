@@ -1,10 +1,14 @@
 package verifier
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -454,3 +458,141 @@ func Test_TwitterVerifier_VerifyRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_HmacVerifier_VerifyTimestampedRequest(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded"}`)
+	opts := &HmacOptions{
+		Header:        "X-Convoy-Signature",
+		Hash:          "SHA256",
+		Secret:        "Convoy",
+		Encoding:      "hex",
+		TimestampKey:  "t",
+		SignatureKey:  "v1",
+		SignedPayload: "{timestamp}.{payload}",
+		Tolerance:     time.Minute,
+	}
+
+	tests := map[string]struct {
+		header        func(now int64) string
+		expectedError error
+	}{
+		"valid_signature": {
+			header: func(now int64) string {
+				return fmt.Sprintf("t=%d,v1=%s", now, sign(t, "Convoy", fmt.Sprintf("%d.%s", now, payload)))
+			},
+			expectedError: nil,
+		},
+		"valid_second_signature_candidate": {
+			header: func(now int64) string {
+				return fmt.Sprintf("t=%d,v1=%s,v1=%s,v0=6ffbb59b2300aae63f272406069a9788598b792a944a07aba816edb039989a39",
+					now, sign(t, "Old-Secret", fmt.Sprintf("%d.%s", now, payload)), sign(t, "Convoy", fmt.Sprintf("%d.%s", now, payload)))
+			},
+			expectedError: nil,
+		},
+		"signature_over_different_timestamp": {
+			header: func(now int64) string {
+				return fmt.Sprintf("t=%d,v1=%s", now, sign(t, "Convoy", fmt.Sprintf("%d.%s", now-1, payload)))
+			},
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"stale_timestamp": {
+			header: func(now int64) string {
+				then := now - 120
+				return fmt.Sprintf("t=%d,v1=%s", then, sign(t, "Convoy", fmt.Sprintf("%d.%s", then, payload)))
+			},
+			expectedError: ErrTimestampOutsideTolerance,
+		},
+		"missing_timestamp": {
+			header: func(now int64) string {
+				return fmt.Sprintf("v1=%s", sign(t, "Convoy", string(payload)))
+			},
+			expectedError: ErrInvalidTimestamp,
+		},
+		"missing_signature": {
+			header: func(now int64) string {
+				return fmt.Sprintf("t=%d", now)
+			},
+			expectedError: ErrSignatureCannotBeEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange.
+			v := NewHmacVerifier(opts)
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+
+			req.Header.Add("X-Convoy-Signature", tc.header(time.Now().Unix()))
+
+			// Assert.
+			err = v.VerifyRequest(req, payload)
+
+			// Act.
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func Test_StripeVerifier_VerifyRequest(t *testing.T) {
+	payload := []byte(`{"id":"evt_1","object":"event","type":"customer.created"}`)
+
+	tests := map[string]struct {
+		secret        string
+		requestFn     func(t *testing.T) *http.Request
+		expectedError error
+	}{
+		"valid_signature": {
+			secret: "whsec_Convoy",
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				now := time.Now().Unix()
+				signature := sign(t, "whsec_Convoy", fmt.Sprintf("%d.%s", now, payload))
+
+				req.Header.Add("Stripe-Signature", fmt.Sprintf("t=%d,v1=%s", now, signature))
+				return req
+			},
+			expectedError: nil,
+		},
+		"replayed_request": {
+			secret: "whsec_Convoy",
+			requestFn: func(t *testing.T) *http.Request {
+				req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+				require.NoError(t, err)
+
+				then := time.Now().Add(-time.Hour).Unix()
+				signature := sign(t, "whsec_Convoy", fmt.Sprintf("%d.%s", then, payload))
+
+				req.Header.Add("Stripe-Signature", fmt.Sprintf("t=%d,v1=%s", then, signature))
+				return req
+			},
+			expectedError: ErrTimestampOutsideTolerance,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange.
+			v := NewStripeVerifier(tc.secret)
+			req := tc.requestFn(t)
+
+			// Assert.
+			err := v.VerifyRequest(req, payload)
+
+			// Act.
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func sign(t *testing.T, secret, payload string) string {
+	t.Helper()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, err := mac.Write([]byte(payload))
+	require.NoError(t, err)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -177,8 +178,9 @@ func (a *ApplicationHandler) CreateAppEndpoint(w http.ResponseWriter, r *http.Re
 	}
 
 	app := m.GetApplicationFromContext(r.Context())
+	group := m.GetGroupFromContext(r.Context())
 
-	endpoint, err := a.S.AppService.CreateAppEndpoint(r.Context(), e, app)
+	endpoint, err := a.S.AppService.CreateAppEndpoint(r.Context(), e, app, group)
 	if err != nil {
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
 		return
@@ -187,6 +189,25 @@ func (a *ApplicationHandler) CreateAppEndpoint(w http.ResponseWriter, r *http.Re
 	_ = render.Render(w, r, util.NewServerResponse("App endpoint created successfully", endpoint, http.StatusCreated))
 }
 
+// VerifyAppEndpoint
+// @Summary Verify an application endpoint
+// @Description This endpoint completes the verification of a pending endpoint. It is the verification link sent to the endpoint in the verification challenge
+// @Tags Application Endpoints
+// @Produce  json
+// @Param token path string true "verification token"
+// @Success 200 {object} serverResponse{data=datastore.Endpoint}
+// @Failure 400,404 {object} serverResponse{data=Stub}
+// @Router /endpoint-verification/{token} [get]
+func (a *ApplicationHandler) VerifyAppEndpoint(w http.ResponseWriter, r *http.Request) {
+	endpoint, err := a.S.AppService.VerifyAppEndpoint(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("App endpoint verified successfully", endpoint, http.StatusOK))
+}
+
 // GetAppEndpoint
 // @Summary Get application endpoint
 // @Description This endpoint fetches an application endpoint
@@ -248,8 +269,9 @@ func (a *ApplicationHandler) UpdateAppEndpoint(w http.ResponseWriter, r *http.Re
 
 	app := m.GetApplicationFromContext(r.Context())
 	endPointId := chi.URLParam(r, "endpointID")
+	group := m.GetGroupFromContext(r.Context())
 
-	endpoint, err := a.S.AppService.UpdateAppEndpoint(r.Context(), e, endPointId, app)
+	endpoint, err := a.S.AppService.UpdateAppEndpoint(r.Context(), e, endPointId, app, group)
 	if err != nil {
 		_ = render.Render(w, r, util.NewServiceErrResponse(err))
 		return
@@ -258,6 +280,71 @@ func (a *ApplicationHandler) UpdateAppEndpoint(w http.ResponseWriter, r *http.Re
 	_ = render.Render(w, r, util.NewServerResponse("Apps endpoint updated successfully", endpoint, http.StatusAccepted))
 }
 
+// SendTestEvent
+// @Summary Send a test event to an application endpoint
+// @Description This endpoint sends a test event to an application endpoint and returns the request and response exchanged. Test events are not counted as event deliveries
+// @Tags Application Endpoints
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param endpointID path string true "endpoint id"
+// @Param event body models.TestEvent false "Test Event Details"
+// @Success 200 {object} serverResponse{data=datastore.TestAttempt}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/endpoints/{endpointID}/test [post]
+func (a *ApplicationHandler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	var testEvent models.TestEvent
+	err := util.ReadJSON(r, &testEvent)
+	if err != nil && !errors.Is(err, util.ErrEmptyBody) {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	app := m.GetApplicationFromContext(r.Context())
+	endpoint := m.GetApplicationEndpointFromContext(r.Context())
+	group := m.GetGroupFromContext(r.Context())
+
+	attempt, err := a.S.AppService.SendTestEvent(r.Context(), &testEvent, app, endpoint, group)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Test event sent successfully", attempt, http.StatusOK))
+}
+
+// GetTestAttempts
+// @Summary Fetch test attempts of an application endpoint
+// @Description This endpoint fetches the test events sent to an application endpoint
+// @Tags Application Endpoints
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param endpointID path string true "endpoint id"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.TestAttempt}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/endpoints/{endpointID}/test [get]
+func (a *ApplicationHandler) GetTestAttempts(w http.ResponseWriter, r *http.Request) {
+	pageable := m.GetPageableFromContext(r.Context())
+	app := m.GetApplicationFromContext(r.Context())
+	endpoint := m.GetApplicationEndpointFromContext(r.Context())
+
+	attempts, paginationData, err := a.S.AppService.LoadTestAttemptsPaged(r.Context(), app, endpoint, pageable)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Test attempts fetched successfully",
+		pagedResponse{Content: &attempts, Pagination: &paginationData}, http.StatusOK))
+}
+
 // DeleteAppEndpoint
 // @Summary Delete application endpoint
 // @Description This endpoint deletes an application endpoint
@@ -3,8 +3,10 @@ package server
 import (
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 
+	"github.com/frain-dev/convoy/datastore"
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/util"
 )
@@ -19,13 +21,20 @@ import (
 // @Param eventDeliveryID path string true "event delivery id"
 // @Param deliveryAttemptID path string true "delivery attempt id"
 // @Success 200 {object} serverResponse{data=datastore.DeliveryAttempt}
-// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Failure 400,401,404,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /events/{eventID}/eventdeliveries/{eventDeliveryID}/deliveryattempts/{deliveryAttemptID} [get]
 func (a *ApplicationHandler) GetDeliveryAttempt(w http.ResponseWriter, r *http.Request) {
+	eventDelivery := m.GetEventDeliveryFromContext(r.Context())
+
+	attempt, err := a.S.EventService.GetDeliveryAttempt(r.Context(), eventDelivery, chi.URLParam(r, "deliveryAttemptID"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
 
 	_ = render.Render(w, r, util.NewServerResponse("App event delivery attempt fetched successfully",
-		*m.GetDeliveryAttemptFromContext(r.Context()), http.StatusOK))
+		attempt, http.StatusOK))
 }
 
 // GetDeliveryAttempts
@@ -36,12 +45,69 @@ func (a *ApplicationHandler) GetDeliveryAttempt(w http.ResponseWriter, r *http.R
 // @Produce  json
 // @Param eventID path string true "event id"
 // @Param eventDeliveryID path string true "event delivery id"
-// @Success 200 {object} serverResponse{data=[]datastore.DeliveryAttempt}
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Param next_cursor query string false "cursor of the next page"
+// @Param prev_cursor query string false "cursor of the previous page"
+// @Param include_total query bool false "count the total results, defaults to false when paging with cursors"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.DeliveryAttempt}}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
 // @Router /events/{eventID}/eventdeliveries/{eventDeliveryID}/deliveryattempts [get]
 func (a *ApplicationHandler) GetDeliveryAttempts(w http.ResponseWriter, r *http.Request) {
+	eventDelivery := m.GetEventDeliveryFromContext(r.Context())
+
+	filter := &datastore.DeliveryAttemptFilter{
+		GroupID:         eventDelivery.GroupID,
+		EventDeliveryID: eventDelivery.UID,
+	}
+
+	attempts, paginationData, err := a.S.EventService.LoadDeliveryAttemptsPaged(r.Context(), filter, m.GetPageableFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
 
 	_ = render.Render(w, r, util.NewServerResponse("App event delivery attempts fetched successfully",
-		*m.GetDeliveryAttemptsFromContext(r.Context()), http.StatusOK))
+		pagedResponse{Content: &attempts, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetEndpointDeliveryAttempts
+// @Summary Get endpoint delivery attempts
+// @Description This endpoint fetches the delivery attempts made to an application endpoint
+// @Tags DeliveryAttempts
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param endpointID path string true "endpoint id"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Param sort query string false "sort order"
+// @Param next_cursor query string false "cursor of the next page"
+// @Param prev_cursor query string false "cursor of the previous page"
+// @Param include_total query bool false "count the total results, defaults to false when paging with cursors"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.DeliveryAttempt}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/endpoints/{endpointID}/deliveryattempts [get]
+func (a *ApplicationHandler) GetEndpointDeliveryAttempts(w http.ResponseWriter, r *http.Request) {
+	app := m.GetApplicationFromContext(r.Context())
+	endpoint := m.GetApplicationEndpointFromContext(r.Context())
+
+	filter := &datastore.DeliveryAttemptFilter{
+		GroupID:    app.GroupID,
+		AppID:      app.UID,
+		EndpointID: endpoint.UID,
+	}
+
+	attempts, paginationData, err := a.S.EventService.LoadDeliveryAttemptsPaged(r.Context(), filter, m.GetPageableFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Endpoint delivery attempts fetched successfully",
+		pagedResponse{Content: &attempts, Pagination: &paginationData}, http.StatusOK))
 }
@@ -86,9 +86,16 @@ func (a *ApplicationHandler) ReplayAppEvent(w http.ResponseWriter, r *http.Reque
 // @Security ApiKeyAuth
 // @Router /events/{eventID} [get]
 func (a *ApplicationHandler) GetAppEvent(w http.ResponseWriter, r *http.Request) {
+	event := m.GetEventFromContext(r.Context())
+
+	err := a.S.EventService.LoadEventPayload(r.Context(), event)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
 
 	_ = render.Render(w, r, util.NewServerResponse("App event fetched successfully",
-		*m.GetEventFromContext(r.Context()), http.StatusOK))
+		*event, http.StatusOK))
 }
 
 // GetEventDelivery
@@ -104,9 +111,16 @@ func (a *ApplicationHandler) GetAppEvent(w http.ResponseWriter, r *http.Request)
 // @Security ApiKeyAuth
 // @Router /eventdeliveries/{eventDeliveryID} [get]
 func (a *ApplicationHandler) GetEventDelivery(w http.ResponseWriter, r *http.Request) {
+	eventDelivery := m.GetEventDeliveryFromContext(r.Context())
+
+	err := a.S.EventService.LoadEventDeliveryPayload(r.Context(), eventDelivery)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
 
 	_ = render.Render(w, r, util.NewServerResponse("Event Delivery fetched successfully",
-		*m.GetEventDeliveryFromContext(r.Context()), http.StatusOK))
+		*eventDelivery, http.StatusOK))
 }
 
 // ResendEventDelivery
@@ -275,6 +289,9 @@ func (a *ApplicationHandler) ForceResendEventDeliveries(w http.ResponseWriter, r
 // @Param perPage query string false "results per page"
 // @Param page query string false "page number"
 // @Param sort query string false "sort order"
+// @Param next_cursor query string false "cursor of the next page"
+// @Param prev_cursor query string false "cursor of the previous page"
+// @Param include_total query bool false "count the total results, defaults to false when paging with cursors"
 // @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.Event{data=Stub}}}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
 // @Security ApiKeyAuth
@@ -341,6 +358,9 @@ func (a *ApplicationHandler) GetEventsPaged(w http.ResponseWriter, r *http.Reque
 // @Param perPage query string false "results per page"
 // @Param page query string false "page number"
 // @Param sort query string false "sort order"
+// @Param next_cursor query string false "cursor of the next page"
+// @Param prev_cursor query string false "cursor of the previous page"
+// @Param include_total query bool false "count the total results, defaults to false when paging with cursors"
 // @Param status query []string false "status"
 // @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.EventDelivery{data=Stub}}}
 // @Failure 400,401,500 {object} serverResponse{data=Stub}
@@ -419,25 +439,3 @@ func getSearchParams(r *http.Request) (datastore.SearchParams, error) {
 
 	return searchParams, nil
 }
-
-func fetchDeliveryAttempts() func(next http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-
-			e := m.GetEventDeliveryFromContext(r.Context())
-
-			r = r.WithContext(m.SetDeliveryAttemptsInContext(r.Context(), &e.DeliveryAttempts))
-			next.ServeHTTP(w, r)
-		})
-	}
-}
-
-func findMessageDeliveryAttempt(attempts *[]datastore.DeliveryAttempt, id string) (*datastore.DeliveryAttempt, error) {
-	for _, a := range *attempts {
-		if a.UID == id {
-			return &a, nil
-		}
-	}
-	return nil, datastore.ErrEventDeliveryAttemptNotFound
-}
@@ -227,6 +227,81 @@ func (s *EventIntegrationTestSuite) Test_GetEventDelivery_Event_not_found() {
 	require.Equal(s.T(), expectedStatusCode, w.Code)
 }
 
+func (s *EventIntegrationTestSuite) Test_GetDeliveryAttempts_Valid_EventDelivery() {
+	expectedStatusCode := http.StatusOK
+
+	// Just Before.
+	app, _ := testdb.SeedApplication(s.DB, s.DefaultGroup, uuid.NewString(), "", false)
+	eventDelivery, _ := testdb.SeedEventDelivery(s.DB, app, &datastore.Event{}, &datastore.Endpoint{}, s.DefaultGroup.UID, "", datastore.FailureEventStatus, &datastore.Subscription{})
+	a1, _ := testdb.SeedDeliveryAttempt(s.DB, eventDelivery, false)
+	a2, _ := testdb.SeedDeliveryAttempt(s.DB, eventDelivery, true)
+
+	// an attempt of another event delivery.
+	other, _ := testdb.SeedEventDelivery(s.DB, app, &datastore.Event{}, &datastore.Endpoint{}, s.DefaultGroup.UID, "", datastore.FailureEventStatus, &datastore.Subscription{})
+	_, _ = testdb.SeedDeliveryAttempt(s.DB, other, false)
+
+	url := fmt.Sprintf("/api/v1/eventdeliveries/%s/deliveryattempts?sort=1", eventDelivery.UID)
+	req := createRequest(http.MethodGet, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+
+	// Deep Assert.
+	var respAttempts []datastore.DeliveryAttempt
+	resp := pagedResponse{Content: &respAttempts}
+	parseResponse(s.T(), w.Result(), &resp)
+	require.Equal(s.T(), int64(2), resp.Pagination.Total)
+	require.Equal(s.T(), a1.UID, respAttempts[0].UID)
+	require.Equal(s.T(), a2.UID, respAttempts[1].UID)
+}
+
+func (s *EventIntegrationTestSuite) Test_GetDeliveryAttempt_Valid_DeliveryAttempt() {
+	expectedStatusCode := http.StatusOK
+
+	// Just Before.
+	app, _ := testdb.SeedApplication(s.DB, s.DefaultGroup, uuid.NewString(), "", false)
+	eventDelivery, _ := testdb.SeedEventDelivery(s.DB, app, &datastore.Event{}, &datastore.Endpoint{}, s.DefaultGroup.UID, "", datastore.SuccessEventStatus, &datastore.Subscription{})
+	attempt, _ := testdb.SeedDeliveryAttempt(s.DB, eventDelivery, true)
+
+	url := fmt.Sprintf("/api/v1/eventdeliveries/%s/deliveryattempts/%s", eventDelivery.UID, attempt.UID)
+	req := createRequest(http.MethodGet, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+
+	// Deep Assert.
+	var respAttempt datastore.DeliveryAttempt
+	parseResponse(s.T(), w.Result(), &respAttempt)
+	require.Equal(s.T(), attempt.UID, respAttempt.UID)
+	require.Equal(s.T(), eventDelivery.UID, respAttempt.MsgID)
+}
+
+func (s *EventIntegrationTestSuite) Test_GetDeliveryAttempt_DeliveryAttempt_not_found() {
+	expectedStatusCode := http.StatusNotFound
+
+	// Just Before.
+	app, _ := testdb.SeedApplication(s.DB, s.DefaultGroup, uuid.NewString(), "", false)
+	eventDelivery, _ := testdb.SeedEventDelivery(s.DB, app, &datastore.Event{}, &datastore.Endpoint{}, s.DefaultGroup.UID, "", datastore.SuccessEventStatus, &datastore.Subscription{})
+
+	url := fmt.Sprintf("/api/v1/eventdeliveries/%s/deliveryattempts/%s", eventDelivery.UID, uuid.NewString())
+	req := createRequest(http.MethodGet, url, s.APIKey, nil)
+	w := httptest.NewRecorder()
+
+	// Act.
+	s.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(s.T(), expectedStatusCode, w.Code)
+}
+
 func (s *EventIntegrationTestSuite) Test_ResendEventDelivery_Valid_Resend() {
 	eventDeliveryID := uuid.NewString()
 	expectedStatusCode := http.StatusOK
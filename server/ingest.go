@@ -1,21 +1,30 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"strconv"
+	"text/template"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/crc"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/pkg/httpbody"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/pkg/verifier"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/util"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/go-redis/redis_rate/v9"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -39,24 +48,63 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// 3. Select verifier based of source config.
+	// 3. Reject requests from outside the source's ip allowlist.
+	if len(source.IPAllowlist) > 0 {
+		err := a.verifySourceIP(r, source)
+		if errors.Is(err, verifier.ErrInvalidIP) {
+			log.WithFields(log.Fields{"source_id": source.UID, "remote_addr": r.RemoteAddr}).
+				Warn("ingest request rejected by source ip allowlist")
+			metrics.IngestRequests().WithLabelValues(source.UID, "ip_rejected").Inc()
+
+			if rErr := a.S.SourceService.RecordRejectedRequest(r.Context(), source); rErr != nil {
+				log.WithError(rErr).Error("failed to record rejected ingest request")
+			}
+
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusForbidden))
+			return
+		}
+
+		if err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+	}
+
+	// 3.1 Rate limit requests to the source, only requests from the
+	// allowlist count against its limit.
+	res, err := a.limitSourceRequests(r, source)
+	if err != nil {
+		message := "an error occured while getting rate limit"
+		log.WithError(err).Error(message)
+		_ = render.Render(w, r, util.NewErrorResponse(message, http.StatusBadRequest))
+		return
+	}
+
+	if res.Allowed == 0 {
+		metrics.IngestRequests().WithLabelValues(source.UID, "rate_limited").Inc()
+
+		retryAfter := int(math.Ceil(res.RetryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, float64(retryAfter)))))
+		_ = render.Render(w, r, util.NewErrorResponse("Too Many Requests", http.StatusTooManyRequests))
+		return
+	}
+
+	// 4. Select verifier based of source config.
 	// TODO(subomi): Can verifier be nil?
 	var v verifier.Verifier
+	var provider *verifier.Provider
 	verifierConfig := source.Verifier
 
 	if !util.IsStringEmpty(string(source.Provider)) {
-		switch source.Provider {
-		case datastore.GithubSourceProvider:
-			v = verifier.NewGithubVerifier(verifierConfig.HMac.Secret)
-		case datastore.TwitterSourceProvider:
-			v = verifier.NewTwitterVerifier(verifierConfig.HMac.Secret)
-		case datastore.ShopifySourceProvider:
-			v = verifier.NewShopifyVerifier(verifierConfig.HMac.Secret)
-		default:
+		var ok bool
+		provider, ok = verifier.GetProvider(string(source.Provider))
+		if !ok {
 			_ = render.Render(w, r, util.NewErrorResponse("Provider type undefined",
 				http.StatusBadRequest))
 			return
 		}
+
+		v = provider.NewVerifier(verifierConfig.HMac.Secret)
 	} else {
 		switch verifierConfig.Type {
 		case datastore.HMacVerifier:
@@ -65,6 +113,12 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 				Hash:     verifierConfig.HMac.Hash,
 				Secret:   verifierConfig.HMac.Secret,
 				Encoding: string(verifierConfig.HMac.Encoding),
+
+				TimestampKey:    verifierConfig.HMac.TimestampKey,
+				SignatureKey:    verifierConfig.HMac.SignatureKey,
+				TimestampHeader: verifierConfig.HMac.TimestampHeader,
+				SignedPayload:   verifierConfig.HMac.SignedPayload,
+				Tolerance:       time.Duration(verifierConfig.HMac.Tolerance) * time.Second,
 			}
 			v = verifier.NewHmacVerifier(opts)
 
@@ -83,35 +137,110 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	// 3.1 On Failure
-	// Return 400 Bad Request.
-	body := io.LimitReader(r.Body, config.MaxRequestSize)
+	// 4.1 On Failure
+	// Return 400 Bad Request, or 413 for bodies above the source's limit.
+	maxBodySize := int64(config.MaxRequestSize)
+	if source.MaxBodySize > 0 {
+		maxBodySize = source.MaxBodySize
+	}
+
+	body := io.LimitReader(r.Body, maxBodySize+1)
 	payload, err := io.ReadAll(body)
 	if err != nil {
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 		return
 	}
 
-	if err = v.VerifyRequest(r, payload); err != nil {
-		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if int64(len(payload)) > maxBodySize {
+		msg := fmt.Sprintf("request body is larger than the source's limit of %d bytes", maxBodySize)
+		_ = render.Render(w, r, util.NewErrorResponse(msg, http.StatusRequestEntityTooLarge))
 		return
 	}
 
-	// 3.2 On success
+	// Provider challenges are answered instead of ingested, only the
+	// ones providers sign are verified.
+	var challenge crc.Crc
+	if provider != nil && provider.NewCrc != nil {
+		if c := provider.NewCrc(verifierConfig.HMac.Secret); c.IsChallenge(r, payload) {
+			challenge = c
+		}
+	}
+
+	if challenge == nil || provider.VerifyChallenge {
+		if err = v.VerifyRequest(r, payload); err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+			return
+		}
+	}
+
+	if challenge != nil {
+		err = challenge.HandleRequest(w, r, payload, source, a.R.SourceRepo)
+		if err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		}
+		return
+	}
+
+	// 4.2 On success
+	// Normalize form and xml bodies to JSON.
 	// Attach Source to Event.
 	// Write Event to the Ingestion Queue.
+	contentType := r.Header.Get("Content-Type")
+	data, normalized, err := httpbody.Normalize(contentType, payload)
+	if errors.Is(err, httpbody.ErrUnsupportedContentType) {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusUnsupportedMediaType))
+		return
+	}
+
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	eventType := a.sourceEventType(r, source, provider, data)
+
+	// Retries of requests ingested within the source's deduplication
+	// window are acknowledged without creating another event.
+	eventID := uuid.New().String()
+	idempotencyKey := sourceIdempotencyKey(r, source, provider, data)
+	if !util.IsStringEmpty(idempotencyKey) {
+		duplicate, err := a.S.EventService.FindDuplicateEvent(r.Context(), source, idempotencyKey, eventID)
+		if err != nil {
+			log.WithError(err).Errorf("failed to deduplicate request to source %s", source.UID)
+		}
+
+		if duplicate != nil {
+			metrics.IngestRequests().WithLabelValues(source.UID, "duplicate").Inc()
+
+			if source.Response != nil {
+				writeSourceResponse(w, source, duplicate)
+				return
+			}
+
+			_ = render.Render(w, r, util.NewServerResponse("Event received", nil, http.StatusOK))
+			return
+		}
+	}
+
 	event := &datastore.Event{
-		UID:            uuid.New().String(),
-		EventType:      datastore.EventType(maskID),
+		UID:            eventID,
+		EventType:      datastore.EventType(eventType),
 		SourceID:       source.UID,
 		GroupID:        source.GroupID,
-		Data:           payload,
-		Headers:        httpheader.HTTPHeader(r.Header),
+		Data:           data,
+		Headers:        httpheader.HTTPHeader(r.Header).Forward(source.ForwardHeaders, credentialHeaders(source)...),
+		IdempotencyKey: idempotencyKey,
+		Priority:       source.Priority,
 		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 		DocumentStatus: datastore.ActiveDocumentStatus,
 	}
 
+	if normalized {
+		event.Raw = payload
+		event.ContentType = contentType
+	}
+
 	eventByte, err := json.Marshal(event)
 	if err != nil {
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
@@ -124,15 +253,169 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		Delay:   0,
 	}
 
-	err = a.S.Queue.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+	err = a.S.Queue.Write(convoy.CreateEventProcessor, event.Priority.CreateEventQueue(), job)
 	if err != nil {
 		log.Errorf("Error occurred sending new event to the queue %s", err)
+
+		// the provider retries the request, it must not be taken for a
+		// duplicate of the event that was lost.
+		if !util.IsStringEmpty(idempotencyKey) {
+			err = a.S.EventService.ReleaseIdempotencyKey(r.Context(), source, idempotencyKey, eventID)
+			if err != nil {
+				log.WithError(err).Errorf("failed to release idempotency key of source %s", source.UID)
+			}
+		}
+
+		_ = render.Render(w, r, util.NewErrorResponse("failed to queue event", http.StatusInternalServerError))
+		return
+	}
+
+	metrics.IngestRequests().WithLabelValues(source.UID, "accepted").Inc()
+
+	// 5. Return 200, or the source's response
+	if source.Response != nil {
+		writeSourceResponse(w, source, event)
+		return
 	}
 
-	// 4. Return 200
 	_ = render.Render(w, r, util.NewServerResponse("Event received", nil, http.StatusOK))
 }
 
+// writeSourceResponse writes the response configured on the source for
+// an ingested event.
+func writeSourceResponse(w http.ResponseWriter, source *datastore.Source, event *datastore.Event) {
+	var body bytes.Buffer
+	tmpl, err := template.New("response").Parse(source.Response.Body)
+	if err == nil {
+		err = tmpl.Execute(&body, map[string]string{"EventID": event.UID, "SourceID": source.UID})
+	}
+
+	if err != nil {
+		log.WithError(err).Errorf("failed to render response for source %s", source.UID)
+		body.Reset()
+	}
+
+	contentType := source.Response.ContentType
+	if util.IsStringEmpty(contentType) {
+		contentType = "text/plain; charset=utf-8"
+	}
+
+	statusCode := source.Response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body.Bytes())
+}
+
+// sourceEventType derives the event type of a request from the source's
+// event type config, falling back to the provider's and then the mask ID.
+func (a *ApplicationHandler) sourceEventType(r *http.Request, source *datastore.Source, provider *verifier.Provider, payload []byte) string {
+	if source.EventType != nil {
+		eventType, err := verifier.NewEventType(source.EventType)
+		if err != nil {
+			log.WithError(err).Errorf("invalid event type config for source %s", source.UID)
+		} else if t := eventType(r, payload); !util.IsStringEmpty(t) {
+			return t
+		}
+	}
+
+	if provider != nil && provider.EventType != nil {
+		if t := provider.EventType(r, payload); !util.IsStringEmpty(t) {
+			return t
+		}
+	}
+
+	return source.MaskID
+}
+
+// sourceIdempotencyKey reads the key identifying retries of a request when
+// the source deduplicates requests, falling back to the provider's key.
+func sourceIdempotencyKey(r *http.Request, source *datastore.Source, provider *verifier.Provider, payload []byte) string {
+	if source.Deduplication == nil {
+		return ""
+	}
+
+	idempotencyKey, err := verifier.NewIdempotencyKey(source.Deduplication)
+	if err != nil {
+		log.WithError(err).Errorf("invalid deduplication config for source %s", source.UID)
+		return ""
+	}
+
+	if idempotencyKey == nil && provider != nil {
+		idempotencyKey = provider.IdempotencyKey
+	}
+
+	if idempotencyKey == nil {
+		return ""
+	}
+
+	return idempotencyKey(r, payload)
+}
+
+// credentialHeaders returns the headers the source's verifier reads its
+// secret or signature from, they are never forwarded by wildcards.
+func credentialHeaders(source *datastore.Source) []string {
+	var headers []string
+	if source.Verifier == nil {
+		return headers
+	}
+
+	if source.Verifier.HMac != nil && !util.IsStringEmpty(source.Verifier.HMac.Header) {
+		headers = append(headers, source.Verifier.HMac.Header)
+	}
+
+	if source.Verifier.ApiKey != nil && !util.IsStringEmpty(source.Verifier.ApiKey.HeaderName) {
+		headers = append(headers, source.Verifier.ApiKey.HeaderName)
+	}
+
+	return headers
+}
+
+// limitSourceRequests counts r against the source's rate limit, sources
+// created before rate limits were configurable get the default one.
+func (a *ApplicationHandler) limitSourceRequests(r *http.Request, source *datastore.Source) (*redis_rate.Result, error) {
+	rateLimit := source.RateLimit
+	if rateLimit == 0 {
+		rateLimit = convoy.RATE_LIMIT
+	}
+
+	rateLimitDuration := source.RateLimitDuration
+	if util.IsStringEmpty(rateLimitDuration) {
+		rateLimitDuration = convoy.RATE_LIMIT_DURATION
+	}
+
+	duration, err := time.ParseDuration(rateLimitDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.S.Limiter.Allow(r.Context(), fmt.Sprintf("source:%s", source.UID), rateLimit, int(duration))
+}
+
+// verifySourceIP checks the client IP of r against the source's ip allowlist,
+// trusting X-Forwarded-For only from the configured proxies.
+func (a *ApplicationHandler) verifySourceIP(r *http.Request, source *datastore.Source) error {
+	cfg, err := config.Get()
+	if err != nil {
+		return err
+	}
+
+	allowlist, err := verifier.NewIPAllowlist(source.IPAllowlist)
+	if err != nil {
+		return err
+	}
+
+	trustedProxies, err := verifier.NewIPAllowlist(cfg.Server.HTTP.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
+	return verifier.NewIPVerifier(allowlist, trustedProxies).VerifyRequest(r, nil)
+}
+
 func (a *ApplicationHandler) HandleCrcCheck(w http.ResponseWriter, r *http.Request) {
 	maskID := chi.URLParam(r, "maskID")
 
@@ -168,17 +451,19 @@ func (a *ApplicationHandler) HandleCrcCheck(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	var c crc.Crc
-
-	switch source.Provider {
-	case datastore.TwitterSourceProvider:
-		c = crc.NewTwitterCrc(source.Verifier.HMac.Secret)
-	default:
+	provider, ok := verifier.GetProvider(string(source.Provider))
+	if !ok || provider.NewCrc == nil {
 		_ = render.Render(w, r, util.NewErrorResponse("Provider type is not supported", http.StatusBadRequest))
 		return
 	}
 
-	err = c.HandleRequest(w, r, source, a.R.SourceRepo)
+	c := provider.NewCrc(source.Verifier.HMac.Secret)
+	if !c.IsChallenge(r, nil) {
+		_ = render.Render(w, r, util.NewErrorResponse("Request is not a challenge", http.StatusBadRequest))
+		return
+	}
+
+	err = c.HandleRequest(w, r, nil, source, a.R.SourceRepo)
 	if err != nil {
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 		return
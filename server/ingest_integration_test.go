@@ -4,17 +4,23 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
 
+	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	convoyMongo "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/testdb"
+	"github.com/go-redis/redis_rate/v9"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/stretchr/testify/suite"
@@ -323,6 +329,86 @@ func (i *IngestIntegrationTestSuite) Test_IngestEvent_NoopVerifier() {
 	require.Equal(i.T(), expectedStatusCode, w.Code)
 }
 
+func (i *IngestIntegrationTestSuite) Test_IngestEvent_RateLimited() {
+	maskID := "123456"
+	sourceID := "123456789"
+	expectedStatusCode := http.StatusTooManyRequests
+
+	// Just Before
+	v := &datastore.VerifierConfig{
+		Type: datastore.NoopVerifier,
+	}
+	_, _ = testdb.SeedSource(i.DB, i.DefaultGroup, sourceID, maskID, "", v)
+
+	ctrl := gomock.NewController(i.T())
+	defer ctrl.Finish()
+
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().Allow(gomock.Any(), "source:"+sourceID, convoy.RATE_LIMIT, int(time.Minute)).
+		Return(&redis_rate.Result{Allowed: 0, RetryAfter: 1500 * time.Millisecond}, nil)
+
+	limiter := i.ConvoyApp.S.Limiter
+	i.ConvoyApp.S.Limiter = rateLimiter
+	defer func() { i.ConvoyApp.S.Limiter = limiter }()
+
+	bodyStr := `{ "name": "convoy" }`
+	body := serialize(bodyStr)
+
+	// Arrange Request.
+	url := fmt.Sprintf("/ingest/%s", maskID)
+	req := createRequest(http.MethodPost, url, "", body)
+
+	w := httptest.NewRecorder()
+
+	// Act.
+	i.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(i.T(), expectedStatusCode, w.Code)
+	require.Equal(i.T(), "2", w.Header().Get("Retry-After"))
+}
+
+func (i *IngestIntegrationTestSuite) Test_IngestEvent_RejectedIPIsNotRateLimited() {
+	maskID := "123456"
+	sourceID := "123456789"
+	expectedStatusCode := http.StatusForbidden
+
+	// Just Before
+	v := &datastore.VerifierConfig{
+		Type: datastore.NoopVerifier,
+	}
+	source, _ := testdb.SeedSource(i.DB, i.DefaultGroup, sourceID, maskID, "", v)
+
+	source.IPAllowlist = []string{"10.0.0.0/8"}
+	require.NoError(i.T(), i.DB.SourceRepo().UpdateSource(context.Background(), source.GroupID, source))
+
+	ctrl := gomock.NewController(i.T())
+	defer ctrl.Finish()
+
+	rateLimiter := mocks.NewMockRateLimiter(ctrl)
+	rateLimiter.EXPECT().Allow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	limiter := i.ConvoyApp.S.Limiter
+	i.ConvoyApp.S.Limiter = rateLimiter
+	defer func() { i.ConvoyApp.S.Limiter = limiter }()
+
+	bodyStr := `{ "name": "convoy" }`
+	body := serialize(bodyStr)
+
+	// Arrange Request.
+	url := fmt.Sprintf("/ingest/%s", maskID)
+	req := createRequest(http.MethodPost, url, "", body)
+	req.RemoteAddr = "192.168.0.1:1234"
+
+	w := httptest.NewRecorder()
+
+	// Act.
+	i.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(i.T(), expectedStatusCode, w.Code)
+}
+
 func TestIngestIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(IngestIntegrationTestSuite))
 }
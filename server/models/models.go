@@ -113,6 +113,22 @@ type SourceResponse struct {
 	Provider       datastore.SourceProvider  `json:"provider"`
 	ProviderConfig *datastore.ProviderConfig `json:"provider_config"`
 
+	ForwardHeaders    []string                        `json:"forward_headers"`
+	EventType         *datastore.SourceEventType      `json:"event_type,omitempty"`
+	IPAllowlist       []string                        `json:"ip_allowlist"`
+	RejectedRequests  int64                           `json:"rejected_requests"`
+	Response          *datastore.SourceResponse       `json:"response,omitempty"`
+	MaxBodySize       int64                           `json:"max_body_size,omitempty"`
+	Deduplication     *datastore.SourceDeduplication  `json:"deduplication,omitempty"`
+	Priority          datastore.EventPriority         `json:"priority,omitempty"`
+	RateLimit         int                             `json:"rate_limit"`
+	RateLimitDuration string                          `json:"rate_limit_duration"`
+	PubSub            *datastore.PubSubConfig         `json:"pub_sub,omitempty"`
+	DBChangeStream    *datastore.DBChangeStreamConfig `json:"db_change_stream,omitempty"`
+	RestApi           *datastore.RestApiConfig        `json:"rest_api,omitempty"`
+	Status            *datastore.SourceStatus         `json:"status,omitempty"`
+	LastHandshakeAt   primitive.DateTime              `json:"last_handshake_at,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty"`
@@ -181,25 +197,91 @@ type Source struct {
 	Provider   datastore.SourceProvider `json:"provider"`
 	IsDisabled bool                     `json:"is_disabled"`
 	Verifier   datastore.VerifierConfig `json:"verifier" valid:"required~please provide a verifier"`
+
+	// EventType configures how event types are derived from requests.
+	EventType *datastore.SourceEventType `json:"event_type"`
+
+	// ForwardHeaders are the request headers, or wildcard patterns, kept
+	// on ingested events. It defaults to the provider's headers.
+	ForwardHeaders []string `json:"forward_headers"`
+
+	// IPAllowlist are the CIDRs requests to the source are accepted from.
+	IPAllowlist []string `json:"ip_allowlist"`
+
+	// ProviderConfig holds provider specific settings, e.g. Meta's verify token.
+	ProviderConfig *datastore.ProviderConfig `json:"provider_config"`
+
+	// Response and MaxBodySize customise how ingest requests are handled.
+	Response    *datastore.SourceResponse `json:"response"`
+	MaxBodySize int64                     `json:"max_body_size"`
+
+	// Deduplication drops provider retries of already ingested requests.
+	Deduplication *datastore.SourceDeduplication `json:"deduplication"`
+
+	// Priority is the priority of the events the source ingests.
+	Priority datastore.EventPriority `json:"priority" valid:"in(high|normal|low)~unsupported event priority"`
+
+	// RateLimit is the number of requests accepted per RateLimitDuration.
+	RateLimit         int    `json:"rate_limit" valid:"int~please provide a valid rate limit,optional"`
+	RateLimitDuration string `json:"rate_limit_duration" valid:"alphanum~please provide a valid rate limit duration,optional"`
+
+	// PubSub is the broker pub_sub sources consume events from.
+	PubSub *datastore.PubSubConfig `json:"pub_sub"`
+
+	// DBChangeStream is the collection db_change_stream sources watch.
+	DBChangeStream *datastore.DBChangeStreamConfig `json:"db_change_stream"`
+
+	// RestApi is the API rest_api sources poll for new items.
+	RestApi *datastore.RestApiConfig `json:"rest_api"`
 }
 
 type UpdateSource struct {
-	Name           *string                  `json:"name" valid:"required~please provide a source name"`
-	Type           datastore.SourceType     `json:"type" valid:"required~please provide a type,supported_source~unsupported source type"`
-	IsDisabled     *bool                    `json:"is_disabled"`
-	ForwardHeaders []string                 `json:"forward_headers"`
-	Verifier       datastore.VerifierConfig `json:"verifier" valid:"required~please provide a verifier"`
+	Name              *string                         `json:"name" valid:"required~please provide a source name"`
+	Type              datastore.SourceType            `json:"type" valid:"required~please provide a type,supported_source~unsupported source type"`
+	IsDisabled        *bool                           `json:"is_disabled"`
+	ForwardHeaders    []string                        `json:"forward_headers"`
+	IPAllowlist       []string                        `json:"ip_allowlist"`
+	EventType         *datastore.SourceEventType      `json:"event_type"`
+	Response          *datastore.SourceResponse       `json:"response"`
+	MaxBodySize       *int64                          `json:"max_body_size"`
+	Deduplication     *datastore.SourceDeduplication  `json:"deduplication"`
+	Priority          *datastore.EventPriority        `json:"priority"`
+	RateLimit         *int                            `json:"rate_limit"`
+	RateLimitDuration *string                         `json:"rate_limit_duration"`
+	PubSub            *datastore.PubSubConfig         `json:"pub_sub"`
+	DBChangeStream    *datastore.DBChangeStreamConfig `json:"db_change_stream"`
+	RestApi           *datastore.RestApiConfig        `json:"rest_api"`
+	Verifier          datastore.VerifierConfig        `json:"verifier" valid:"required~please provide a verifier"`
 }
 
 type Event struct {
 	AppID     string `json:"app_id" bson:"app_id" valid:"required~please provide an app id"`
 	EventType string `json:"event_type" bson:"event_type" valid:"required~please provide an event type"`
 
+	// Priority decides how soon the event is processed relative to
+	// other events, it defaults to normal.
+	Priority datastore.EventPriority `json:"priority" bson:"priority" valid:"in(high|normal|low)~unsupported event priority"`
+
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data" bson:"data" valid:"required~please provide your data"`
 }
 
+type ReplayJob struct {
+	AppID      string    `json:"app_id"`
+	SourceID   string    `json:"source_id"`
+	EventTypes []string  `json:"event_types"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+
+	// RateLimit is the maximum number of events replayed per second.
+	RateLimit int `json:"rate_limit" valid:"int~please provide a valid rate limit,optional"`
+
+	// DryRun returns the number of events the job would replay
+	// without creating it.
+	DryRun bool `json:"dry_run"`
+}
+
 type IDs struct {
 	IDs []string `json:"ids"`
 }
@@ -253,6 +335,8 @@ type Subscription struct {
 	AlertConfig  *datastore.AlertConfiguration  `json:"alert_config,omitempty" bson:"alert_config,omitempty"`
 	RetryConfig  *datastore.RetryConfiguration  `json:"retry_config,omitempty" bson:"retry_config,omitempty"`
 	FilterConfig *datastore.FilterConfiguration `json:"filter_config,omitempty" bson:"filter_config,omitempty"`
+
+	ForwardRawBody bool `json:"forward_raw_body"`
 }
 
 type UpdateSubscription struct {
@@ -264,6 +348,19 @@ type UpdateSubscription struct {
 	AlertConfig  *datastore.AlertConfiguration  `json:"alert_config,omitempty"`
 	RetryConfig  *datastore.RetryConfiguration  `json:"retry_config,omitempty"`
 	FilterConfig *datastore.FilterConfiguration `json:"filter_config,omitempty"`
+
+	ForwardRawBody *bool `json:"forward_raw_body,omitempty"`
+}
+
+type TestEvent struct {
+	// Data is the payload sent to the endpoint, a sample payload is
+	// sent when it is empty.
+	Data json.RawMessage `json:"data"`
+}
+
+type ResumeSubscription struct {
+	// Rate is the number of held deliveries released per second.
+	Rate int `json:"rate" valid:"int~please provide a valid rate,optional"`
 }
 
 type UpdateUser struct {
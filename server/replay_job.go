@@ -0,0 +1,134 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+)
+
+// CreateReplayJob
+// @Summary Create a replay job
+// @Description This endpoint creates a job that replays every event matching the filter. When dry_run is set, it only returns the number of matching events
+// @Tags Replay Jobs
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param replayJob body models.ReplayJob true "Replay Job Details"
+// @Success 201 {object} serverResponse{data=datastore.ReplayJob}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /replayjobs [post]
+func (a *ApplicationHandler) CreateReplayJob(w http.ResponseWriter, r *http.Request) {
+	var newJob models.ReplayJob
+	if err := util.ReadJSON(r, &newJob); err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	group := m.GetGroupFromContext(r.Context())
+
+	if newJob.DryRun {
+		count, err := a.S.ReplayJobService.CountReplayJobEvents(r.Context(), &newJob, group)
+		if err != nil {
+			_ = render.Render(w, r, util.NewServiceErrResponse(err))
+			return
+		}
+
+		_ = render.Render(w, r, util.NewServerResponse("replay job events count successful", map[string]interface{}{"num": count}, http.StatusOK))
+		return
+	}
+
+	job, err := a.S.ReplayJobService.CreateReplayJob(r.Context(), &newJob, group)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Replay job created successfully", job, http.StatusCreated))
+}
+
+// GetReplayJob
+// @Summary Get a replay job
+// @Description This endpoint fetches a replay job and its progress
+// @Tags Replay Jobs
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param replayJobID path string true "replay job id"
+// @Success 200 {object} serverResponse{data=datastore.ReplayJob}
+// @Failure 400,401,404,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /replayjobs/{replayJobID} [get]
+func (a *ApplicationHandler) GetReplayJob(w http.ResponseWriter, r *http.Request) {
+	group := m.GetGroupFromContext(r.Context())
+
+	job, err := a.S.ReplayJobService.FindReplayJobByID(r.Context(), group, chi.URLParam(r, "replayJobID"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Replay job fetched successfully", job, http.StatusOK))
+}
+
+// LoadReplayJobsPaged
+// @Summary Fetch multiple replay jobs
+// @Description This endpoint fetches multiple replay jobs
+// @Tags Replay Jobs
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Success 200 {object} serverResponse{data=pagedResponse{content=[]datastore.ReplayJob}}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /replayjobs [get]
+func (a *ApplicationHandler) LoadReplayJobsPaged(w http.ResponseWriter, r *http.Request) {
+	pageable := m.GetPageableFromContext(r.Context())
+	group := m.GetGroupFromContext(r.Context())
+
+	jobs, paginationData, err := a.S.ReplayJobService.LoadReplayJobsPaged(r.Context(), group, pageable)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Replay jobs fetched successfully",
+		pagedResponse{Content: &jobs, Pagination: &paginationData}, http.StatusOK))
+}
+
+// CancelReplayJob
+// @Summary Cancel a replay job
+// @Description This endpoint cancels a pending or running replay job. Events already replayed are not affected
+// @Tags Replay Jobs
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param replayJobID path string true "replay job id"
+// @Success 202 {object} serverResponse{data=datastore.ReplayJob}
+// @Failure 400,401,404,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /replayjobs/{replayJobID}/cancel [put]
+func (a *ApplicationHandler) CancelReplayJob(w http.ResponseWriter, r *http.Request) {
+	group := m.GetGroupFromContext(r.Context())
+
+	job, err := a.S.ReplayJobService.FindReplayJobByID(r.Context(), group, chi.URLParam(r, "replayJobID"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	job, err = a.S.ReplayJobService.CancelReplayJob(r.Context(), job)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Replay job cancelled successfully", job, http.StatusAccepted))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
@@ -33,18 +34,21 @@ type ApplicationHandler struct {
 }
 
 type Repos struct {
-	EventRepo         datastore.EventRepository
-	EventDeliveryRepo datastore.EventDeliveryRepository
-	AppRepo           datastore.ApplicationRepository
-	GroupRepo         datastore.GroupRepository
-	ApiKeyRepo        datastore.APIKeyRepository
-	SubRepo           datastore.SubscriptionRepository
-	SourceRepo        datastore.SourceRepository
-	OrgRepo           datastore.OrganisationRepository
-	OrgMemberRepo     datastore.OrganisationMemberRepository
-	OrgInviteRepo     datastore.OrganisationInviteRepository
-	UserRepo          datastore.UserRepository
-	ConfigRepo        datastore.ConfigurationRepository
+	EventRepo           datastore.EventRepository
+	EventDeliveryRepo   datastore.EventDeliveryRepository
+	AppRepo             datastore.ApplicationRepository
+	GroupRepo           datastore.GroupRepository
+	ApiKeyRepo          datastore.APIKeyRepository
+	SubRepo             datastore.SubscriptionRepository
+	SourceRepo          datastore.SourceRepository
+	OrgRepo             datastore.OrganisationRepository
+	OrgMemberRepo       datastore.OrganisationMemberRepository
+	OrgInviteRepo       datastore.OrganisationInviteRepository
+	UserRepo            datastore.UserRepository
+	ConfigRepo          datastore.ConfigurationRepository
+	ReplayJobRepo       datastore.ReplayJobRepository
+	TestAttemptRepo     datastore.TestAttemptRepository
+	DeliveryAttemptRepo datastore.DeliveryAttemptRepository
 }
 
 type Services struct {
@@ -54,6 +58,7 @@ type Services struct {
 	Cache    cache.Cache
 	Limiter  limiter.RateLimiter
 	Searcher searcher.Searcher
+	Payloads *claimcheck.Store
 
 	AppService                *services.AppService
 	EventService              *services.EventService
@@ -66,6 +71,7 @@ type Services struct {
 	OrganisationService       *services.OrganisationService
 	OrganisationMemberService *services.OrganisationMemberService
 	OrganisationInviteService *services.OrganisationInviteService
+	ReplayJobService          *services.ReplayJobService
 }
 
 //go:embed ui/build
@@ -91,17 +97,18 @@ func reactRootHandler(rw http.ResponseWriter, req *http.Request) {
 }
 
 func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
-	as := services.NewAppService(r.AppRepo, r.EventRepo, r.EventDeliveryRepo, s.Cache)
-	es := services.NewEventService(r.AppRepo, r.EventRepo, r.EventDeliveryRepo, s.Queue, s.Cache, s.Searcher, r.SubRepo, r.SourceRepo)
+	as := services.NewAppService(r.AppRepo, r.EventRepo, r.EventDeliveryRepo, r.TestAttemptRepo, r.SubRepo, s.Queue, s.Cache)
+	es := services.NewEventService(r.AppRepo, r.EventRepo, r.EventDeliveryRepo, r.DeliveryAttemptRepo, s.Queue, s.Cache, s.Searcher, r.SubRepo, r.SourceRepo, s.Payloads)
 	gs := services.NewGroupService(r.ApiKeyRepo, r.AppRepo, r.GroupRepo, r.EventRepo, r.EventDeliveryRepo, s.Limiter, s.Cache)
 	ss := services.NewSecurityService(r.GroupRepo, r.ApiKeyRepo)
 	os := services.NewOrganisationService(r.OrgRepo, r.OrgMemberRepo)
-	rs := services.NewSubscriptionService(r.SubRepo, r.AppRepo, r.SourceRepo)
+	rs := services.NewSubscriptionService(r.SubRepo, r.AppRepo, r.SourceRepo, s.Queue)
 	sos := services.NewSourceService(r.SourceRepo, s.Cache)
 	ois := services.NewOrganisationInviteService(r.OrgRepo, r.UserRepo, r.OrgMemberRepo, r.OrgInviteRepo, s.Queue)
 	om := services.NewOrganisationMemberService(r.OrgMemberRepo)
 	cs := services.NewConfigService(r.ConfigRepo)
 	us := services.NewUserService(r.UserRepo, s.Cache, s.Queue, cs, os)
+	rjs := services.NewReplayJobService(r.ReplayJobRepo, r.EventRepo, r.AppRepo, r.SourceRepo, s.Queue)
 
 	m := middleware.NewMiddleware(&middleware.CreateMiddleware{
 		EventRepo:         r.EventRepo,
@@ -125,23 +132,27 @@ func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
 	return &ApplicationHandler{
 		M: m,
 		R: Repos{
-			EventRepo:         r.EventRepo,
-			EventDeliveryRepo: r.EventDeliveryRepo,
-			AppRepo:           r.AppRepo,
-			GroupRepo:         r.GroupRepo,
-			ApiKeyRepo:        r.ApiKeyRepo,
-			SubRepo:           r.SubRepo,
-			SourceRepo:        r.SourceRepo,
-			OrgRepo:           r.OrgRepo,
-			OrgMemberRepo:     r.OrgMemberRepo,
-			OrgInviteRepo:     r.OrgInviteRepo,
-			UserRepo:          r.UserRepo,
-			ConfigRepo:        r.ConfigRepo,
+			EventRepo:           r.EventRepo,
+			EventDeliveryRepo:   r.EventDeliveryRepo,
+			AppRepo:             r.AppRepo,
+			GroupRepo:           r.GroupRepo,
+			ApiKeyRepo:          r.ApiKeyRepo,
+			SubRepo:             r.SubRepo,
+			SourceRepo:          r.SourceRepo,
+			OrgRepo:             r.OrgRepo,
+			OrgMemberRepo:       r.OrgMemberRepo,
+			OrgInviteRepo:       r.OrgInviteRepo,
+			UserRepo:            r.UserRepo,
+			ConfigRepo:          r.ConfigRepo,
+			ReplayJobRepo:       r.ReplayJobRepo,
+			TestAttemptRepo:     r.TestAttemptRepo,
+			DeliveryAttemptRepo: r.DeliveryAttemptRepo,
 		},
 		S: Services{
 			Queue:                     s.Queue,
 			Cache:                     s.Cache,
 			Searcher:                  s.Searcher,
+			Payloads:                  s.Payloads,
 			Logger:                    s.Logger,
 			Tracer:                    s.Tracer,
 			Limiter:                   s.Limiter,
@@ -156,6 +167,7 @@ func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
 			OrganisationService:       os,
 			OrganisationMemberService: om,
 			OrganisationInviteService: ois,
+			ReplayJobService:          rjs,
 		},
 	}
 }
@@ -175,6 +187,9 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 		ingestRouter.Post("/{maskID}", a.IngestEvent)
 	})
 
+	// Endpoint verification link.
+	router.Get("/endpoint-verification/{token}", a.VerifyAppEndpoint)
+
 	// Public API.
 	router.Route("/api", func(v1Router chi.Router) {
 
@@ -210,6 +225,9 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 							e.Get("/", a.GetAppEndpoint)
 							e.Put("/", a.UpdateAppEndpoint)
 							e.Delete("/", a.DeleteAppEndpoint)
+							e.Post("/test", a.SendTestEvent)
+							e.With(a.M.Pagination).Get("/test", a.GetTestAttempts)
+							e.With(a.M.Pagination).Get("/deliveryattempts", a.GetEndpointDeliveryAttempts)
 						})
 					})
 				})
@@ -246,10 +264,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 					eventDeliverySubRouter.Put("/resend", a.ResendEventDelivery)
 
 					eventDeliverySubRouter.Route("/deliveryattempts", func(deliveryRouter chi.Router) {
-						deliveryRouter.Use(fetchDeliveryAttempts())
-
-						deliveryRouter.Get("/", a.GetDeliveryAttempts)
-						deliveryRouter.With(a.M.RequireDeliveryAttempt()).Get("/{deliveryAttemptID}", a.GetDeliveryAttempt)
+						deliveryRouter.With(a.M.Pagination).Get("/", a.GetDeliveryAttempts)
+						deliveryRouter.Get("/{deliveryAttemptID}", a.GetDeliveryAttempt)
 					})
 				})
 			})
@@ -275,6 +291,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 				subscriptionRouter.Get("/{subscriptionID}", a.GetSubscription)
 				subscriptionRouter.Put("/{subscriptionID}", a.UpdateSubscription)
 				subscriptionRouter.Put("/{subscriptionID}/toggle_status", a.ToggleSubscriptionStatus)
+				subscriptionRouter.Put("/{subscriptionID}/pause", a.PauseSubscription)
+				subscriptionRouter.Put("/{subscriptionID}/resume", a.ResumeSubscription)
 			})
 
 			r.Route("/sources", func(sourceRouter chi.Router) {
@@ -288,6 +306,17 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 				sourceRouter.Put("/{sourceID}", a.UpdateSource)
 				sourceRouter.Delete("/{sourceID}", a.DeleteSource)
 			})
+
+			r.Route("/replayjobs", func(replayJobRouter chi.Router) {
+				replayJobRouter.Use(a.M.RequireGroup())
+				replayJobRouter.Use(a.M.RateLimitByGroupID())
+				replayJobRouter.Use(a.M.RequirePermission(auth.RoleAdmin))
+
+				replayJobRouter.Post("/", a.CreateReplayJob)
+				replayJobRouter.With(a.M.Pagination).Get("/", a.LoadReplayJobsPaged)
+				replayJobRouter.Get("/{replayJobID}", a.GetReplayJob)
+				replayJobRouter.Put("/{replayJobID}/cancel", a.CancelReplayJob)
+			})
 		})
 	})
 
@@ -411,6 +440,9 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 										e.Get("/", a.GetAppEndpoint)
 										e.Put("/", a.UpdateAppEndpoint)
 										e.Delete("/", a.DeleteAppEndpoint)
+										e.Post("/test", a.SendTestEvent)
+										e.With(a.M.Pagination).Get("/test", a.GetTestAttempts)
+										e.With(a.M.Pagination).Get("/deliveryattempts", a.GetEndpointDeliveryAttempts)
 									})
 								})
 							})
@@ -444,10 +476,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 								eventDeliverySubRouter.Put("/resend", a.ResendEventDelivery)
 
 								eventDeliverySubRouter.Route("/deliveryattempts", func(deliveryRouter chi.Router) {
-									deliveryRouter.Use(fetchDeliveryAttempts())
-
-									deliveryRouter.Get("/", a.GetDeliveryAttempts)
-									deliveryRouter.With(a.M.RequireDeliveryAttempt()).Get("/{deliveryAttemptID}", a.GetDeliveryAttempt)
+									deliveryRouter.With(a.M.Pagination).Get("/", a.GetDeliveryAttempts)
+									deliveryRouter.Get("/{deliveryAttemptID}", a.GetDeliveryAttempt)
 								})
 							})
 						})
@@ -460,6 +490,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 							subscriptionRouter.Delete("/{subscriptionID}", a.DeleteSubscription)
 							subscriptionRouter.Get("/{subscriptionID}", a.GetSubscription)
 							subscriptionRouter.Put("/{subscriptionID}", a.UpdateSubscription)
+							subscriptionRouter.Put("/{subscriptionID}/pause", a.PauseSubscription)
+							subscriptionRouter.Put("/{subscriptionID}/resume", a.ResumeSubscription)
 						})
 
 						groupSubRouter.Route("/sources", func(sourceRouter chi.Router) {
@@ -473,6 +505,15 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 							sourceRouter.Delete("/{sourceID}", a.DeleteSource)
 						})
 
+						groupSubRouter.Route("/replayjobs", func(replayJobRouter chi.Router) {
+							replayJobRouter.Use(a.M.RequireOrganisationMemberRole(auth.RoleAdmin))
+
+							replayJobRouter.Post("/", a.CreateReplayJob)
+							replayJobRouter.With(a.M.Pagination).Get("/", a.LoadReplayJobsPaged)
+							replayJobRouter.Get("/{replayJobID}", a.GetReplayJob)
+							replayJobRouter.Put("/{replayJobID}/cancel", a.CancelReplayJob)
+						})
+
 						groupSubRouter.Route("/dashboard", func(dashboardRouter chi.Router) {
 							dashboardRouter.Get("/summary", a.GetDashboardSummary)
 							dashboardRouter.Get("/config", a.GetAllConfigDetails)
@@ -516,6 +557,9 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 
 					e.Get("/", a.GetAppEndpoint)
 					e.Put("/", a.UpdateAppEndpoint)
+					e.Post("/test", a.SendTestEvent)
+					e.With(a.M.Pagination).Get("/test", a.GetTestAttempts)
+					e.With(a.M.Pagination).Get("/deliveryattempts", a.GetEndpointDeliveryAttempts)
 				})
 			})
 		})
@@ -560,10 +604,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 				eventDeliverySubRouter.Put("/resend", a.ResendEventDelivery)
 
 				eventDeliverySubRouter.Route("/deliveryattempts", func(deliveryRouter chi.Router) {
-					deliveryRouter.Use(fetchDeliveryAttempts())
-
-					deliveryRouter.Get("/", a.GetDeliveryAttempts)
-					deliveryRouter.With(a.M.RequireDeliveryAttempt()).Get("/{deliveryAttemptID}", a.GetDeliveryAttempt)
+					deliveryRouter.With(a.M.Pagination).Get("/", a.GetDeliveryAttempts)
+					deliveryRouter.Get("/{deliveryAttemptID}", a.GetDeliveryAttempt)
 				})
 			})
 		})
@@ -575,6 +617,7 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 
 	metrics.RegisterQueueMetrics(a.S.Queue)
 	metrics.RegisterDBMetrics(a.R.EventDeliveryRepo)
+	metrics.Reg().MustRegister(metrics.IngestRequests())
 	prometheus.MustRegister(metrics.RequestDuration())
 
 	return router
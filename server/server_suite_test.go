@@ -17,6 +17,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/rdb"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
@@ -79,9 +80,13 @@ func getQueueOptions(name string) (queue.QueueOptions, error) {
 		return opts, err
 	}
 	queueNames := map[string]int{
-		string(convoy.PriorityQueue):    6,
-		string(convoy.EventQueue):       2,
-		string(convoy.CreateEventQueue): 2,
+		string(convoy.PriorityQueue):                6,
+		string(convoy.HighPriorityEventQueue):       6,
+		string(convoy.HighPriorityCreateEventQueue): 6,
+		string(convoy.EventQueue):                   2,
+		string(convoy.CreateEventQueue):             2,
+		string(convoy.LowPriorityEventQueue):        1,
+		string(convoy.LowPriorityCreateEventQueue):  1,
 	}
 	opts = queue.QueueOptions{
 		Names:        queueNames,
@@ -104,6 +109,7 @@ func buildServer() *ApplicationHandler {
 	appRepo := db.AppRepo()
 	eventRepo := db.EventRepo()
 	eventDeliveryRepo := db.EventDeliveryRepo()
+	attemptRepo := db.DeliveryAttemptRepo()
 	apiKeyRepo := db.APIRepo()
 	sourceRepo := db.SourceRepo()
 	orgRepo := db.OrganisationRepo()
@@ -111,6 +117,8 @@ func buildServer() *ApplicationHandler {
 	orgInviteRepo := db.OrganisationInviteRepo()
 	userRepo := db.UserRepo()
 	configRepo := db.ConfigurationRepo()
+	replayJobRepo := db.ReplayJobRepo()
+	testAttemptRepo := db.TestAttemptRepo()
 	queue := redisqueue.NewQueue(qOpts)
 	logger := logger.NewNoopLogger()
 	cache := ncache.NewNoopCache()
@@ -121,18 +129,21 @@ func buildServer() *ApplicationHandler {
 
 	return NewApplicationHandler(
 		Repos{
-			EventRepo:         eventRepo,
-			EventDeliveryRepo: eventDeliveryRepo,
-			AppRepo:           appRepo,
-			GroupRepo:         groupRepo,
-			ApiKeyRepo:        apiKeyRepo,
-			SubRepo:           subRepo,
-			SourceRepo:        sourceRepo,
-			OrgRepo:           orgRepo,
-			OrgMemberRepo:     orgMemberRepo,
-			OrgInviteRepo:     orgInviteRepo,
-			UserRepo:          userRepo,
-			ConfigRepo:        configRepo,
+			EventRepo:           eventRepo,
+			EventDeliveryRepo:   eventDeliveryRepo,
+			DeliveryAttemptRepo: attemptRepo,
+			AppRepo:             appRepo,
+			GroupRepo:           groupRepo,
+			ApiKeyRepo:          apiKeyRepo,
+			SubRepo:             subRepo,
+			SourceRepo:          sourceRepo,
+			OrgRepo:             orgRepo,
+			OrgMemberRepo:       orgMemberRepo,
+			OrgInviteRepo:       orgInviteRepo,
+			UserRepo:            userRepo,
+			ConfigRepo:          configRepo,
+			ReplayJobRepo:       replayJobRepo,
+			TestAttemptRepo:     testAttemptRepo,
 		}, Services{
 			Queue:    queue,
 			Logger:   logger,
@@ -140,6 +151,7 @@ func buildServer() *ApplicationHandler {
 			Cache:    cache,
 			Limiter:  limiter,
 			Searcher: searcher,
+			Payloads: claimcheck.NewStore(configRepo),
 		})
 }
 
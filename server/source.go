@@ -181,18 +181,33 @@ func (a *ApplicationHandler) LoadSourcesPaged(w http.ResponseWriter, r *http.Req
 
 func sourceResponse(s *datastore.Source, baseUrl string) *models.SourceResponse {
 	return &models.SourceResponse{
-		UID:            s.UID,
-		MaskID:         s.MaskID,
-		GroupID:        s.GroupID,
-		Name:           s.Name,
-		Type:           s.Type,
-		Provider:       s.Provider,
-		ProviderConfig: s.ProviderConfig,
-		URL:            fmt.Sprintf("%s/ingest/%s", baseUrl, s.MaskID),
-		IsDisabled:     s.IsDisabled,
-		Verifier:       s.Verifier,
-		CreatedAt:      s.CreatedAt,
-		UpdatedAt:      s.UpdatedAt,
-		DeletedAt:      s.DeletedAt,
+		UID:               s.UID,
+		MaskID:            s.MaskID,
+		GroupID:           s.GroupID,
+		Name:              s.Name,
+		Type:              s.Type,
+		Provider:          s.Provider,
+		ProviderConfig:    s.ProviderConfig,
+		URL:               fmt.Sprintf("%s/ingest/%s", baseUrl, s.MaskID),
+		IsDisabled:        s.IsDisabled,
+		Verifier:          s.Verifier,
+		ForwardHeaders:    s.ForwardHeaders,
+		EventType:         s.EventType,
+		IPAllowlist:       s.IPAllowlist,
+		RejectedRequests:  s.RejectedRequests,
+		Response:          s.Response,
+		MaxBodySize:       s.MaxBodySize,
+		Deduplication:     s.Deduplication,
+		Priority:          s.Priority,
+		RateLimit:         s.RateLimit,
+		RateLimitDuration: s.RateLimitDuration,
+		PubSub:            s.PubSub,
+		DBChangeStream:    s.DBChangeStream,
+		RestApi:           s.RestApi,
+		Status:            s.Status,
+		LastHandshakeAt:   s.LastHandshakeAt,
+		CreatedAt:         s.CreatedAt,
+		UpdatedAt:         s.UpdatedAt,
+		DeletedAt:         s.DeletedAt,
 	}
 }
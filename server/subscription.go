@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/frain-dev/convoy/server/models"
@@ -188,3 +189,59 @@ func (a *ApplicationHandler) ToggleSubscriptionStatus(w http.ResponseWriter, r *
 
 	_ = render.Render(w, r, util.NewServerResponse("Subscription status updated successfully", sub, http.StatusAccepted))
 }
+
+// PauseSubscription
+// @Summary Pause a subscription
+// @Description This endpoint pauses a subscription. New deliveries are held without being attempted until the subscription is resumed
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param subscriptionID path string true "subscription id"
+// @Success 200 {object} serverResponse{data=datastore.Subscription}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /subscriptions/{subscriptionID}/pause [put]
+func (a *ApplicationHandler) PauseSubscription(w http.ResponseWriter, r *http.Request) {
+	g := m.GetGroupFromContext(r.Context())
+	subscription := chi.URLParam(r, "subscriptionID")
+
+	sub, err := a.S.SubService.PauseSubscription(r.Context(), g.UID, subscription)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Subscription paused successfully", sub, http.StatusAccepted))
+}
+
+// ResumeSubscription
+// @Summary Resume a paused subscription
+// @Description This endpoint resumes a paused subscription. Deliveries held while it was paused are sent oldest first at the given rate per second
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param subscriptionID path string true "subscription id"
+// @Param resume body models.ResumeSubscription false "Resume Details"
+// @Success 200 {object} serverResponse{data=datastore.Subscription}
+// @Failure 400,401,500 {object} serverResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /subscriptions/{subscriptionID}/resume [put]
+func (a *ApplicationHandler) ResumeSubscription(w http.ResponseWriter, r *http.Request) {
+	var resume models.ResumeSubscription
+	err := util.ReadJSON(r, &resume)
+	if err != nil && !errors.Is(err, util.ErrEmptyBody) {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	g := m.GetGroupFromContext(r.Context())
+	subscription := chi.URLParam(r, "subscriptionID")
+
+	sub, err := a.S.SubService.ResumeSubscription(r.Context(), g.UID, subscription, &resume)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Subscription resumed successfully", sub, http.StatusAccepted))
+}
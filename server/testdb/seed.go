@@ -387,6 +387,29 @@ func SeedEventDelivery(db convoyMongo.Client, app *datastore.Application, event
 	return eventDelivery, nil
 }
 
+// SeedDeliveryAttempt creates a delivery attempt of the event delivery for integration tests.
+func SeedDeliveryAttempt(db convoyMongo.Client, eventDelivery *datastore.EventDelivery, status bool) (*datastore.DeliveryAttempt, error) {
+	attempt := &datastore.DeliveryAttempt{
+		UID:            uuid.New().String(),
+		MsgID:          eventDelivery.UID,
+		GroupID:        eventDelivery.GroupID,
+		AppID:          eventDelivery.AppID,
+		EndpointID:     eventDelivery.EndpointID,
+		Status:         status,
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	// Seed Data.
+	err := db.DeliveryAttemptRepo().CreateDeliveryAttempt(context.TODO(), attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
 // SeedOrganisation is create random Organisation for integration tests.
 func SeedOrganisation(db convoyMongo.Client, uid, ownerID, name string) (*datastore.Organisation, error) {
 	if util.IsStringEmpty(uid) {
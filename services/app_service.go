@@ -1,7 +1,9 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,7 +12,10 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
@@ -18,15 +23,21 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// endpointVerificationTimeout bounds the verification challenge request.
+const endpointVerificationTimeout = 10 * time.Second
+
 type AppService struct {
 	appRepo           datastore.ApplicationRepository
 	eventRepo         datastore.EventRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	testAttemptRepo   datastore.TestAttemptRepository
+	subRepo           datastore.SubscriptionRepository
+	queue             queue.Queuer
 	cache             cache.Cache
 }
 
-func NewAppService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, cache cache.Cache) *AppService {
-	return &AppService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, cache: cache}
+func NewAppService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, testAttemptRepo datastore.TestAttemptRepository, subRepo datastore.SubscriptionRepository, queue queue.Queuer, cache cache.Cache) *AppService {
+	return &AppService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, testAttemptRepo: testAttemptRepo, subRepo: subRepo, queue: queue, cache: cache}
 }
 
 func (a *AppService) CreateApp(ctx context.Context, newApp *models.Application, g *datastore.Group) (*datastore.Application, error) {
@@ -134,7 +145,7 @@ func (a *AppService) DeleteApplication(ctx context.Context, app *datastore.Appli
 	return nil
 }
 
-func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, app *datastore.Application) (*datastore.Endpoint, error) {
+func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, app *datastore.Application, g *datastore.Group) (*datastore.Endpoint, error) {
 	// Events being nil means it wasn't passed at all, which automatically
 	// translates into a accept all scenario. This is quite different from
 	// an empty array which signifies a blacklist all events -- no events
@@ -164,6 +175,7 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		RateLimit:         e.RateLimit,
 		HttpTimeout:       e.HttpTimeout,
 		RateLimitDuration: duration.String(),
+		Status:            datastore.ActiveEndpointStatus,
 		CreatedAt:         primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:         primitive.NewDateTimeFromTime(time.Now()),
 		DocumentStatus:    datastore.ActiveDocumentStatus,
@@ -176,6 +188,13 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		}
 	}
 
+	if requiresEndpointVerification(g) {
+		err = resetEndpointVerification(endpoint)
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
 	err = a.appRepo.CreateApplicationEndpoint(ctx, app.GroupID, app.UID, endpoint)
 	if err != nil {
 		log.WithError(err).Error("failed to create application endpoint")
@@ -188,6 +207,13 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf("failed to fetch application to update cache"))
 	}
 
+	if endpoint.IsPending() {
+		endpoint, err = a.challengeAppEndpoint(ctx, app, endpoint, g)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
 	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
 	if err != nil {
@@ -197,19 +223,42 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 	return endpoint, nil
 }
 
-func (a *AppService) UpdateAppEndpoint(ctx context.Context, e models.Endpoint, endPointId string, app *datastore.Application) (*datastore.Endpoint, error) {
+func (a *AppService) UpdateAppEndpoint(ctx context.Context, e models.Endpoint, endPointId string, app *datastore.Application, g *datastore.Group) (*datastore.Endpoint, error) {
+	var previousURL string
+	for _, endpoint := range app.Endpoints {
+		if endpoint.UID == endPointId {
+			previousURL = endpoint.TargetURL
+		}
+	}
 
 	endpoints, endpoint, err := updateEndpointIfFound(&app.Endpoints, endPointId, e)
 	if err != nil {
 		return endpoint, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
+	// a new url has to be verified again before it receives events.
+	if requiresEndpointVerification(g) && endpoint.TargetURL != previousURL {
+		err = resetEndpointVerification(endpoint)
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+
+		setEndpoint(endpoints, endpoint)
+	}
+
 	app.Endpoints = *endpoints
 	err = a.appRepo.UpdateApplication(ctx, app, app.GroupID)
 	if err != nil {
 		return endpoint, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating app endpoints"))
 	}
 
+	if endpoint.IsPending() {
+		endpoint, err = a.challengeAppEndpoint(ctx, app, endpoint, g)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
 	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
 	if err != nil {
@@ -243,6 +292,191 @@ func (a *AppService) DeleteAppEndpoint(ctx context.Context, e *datastore.Endpoin
 	return nil
 }
 
+// VerifyAppEndpoint activates the pending endpoint the verification token
+// was issued for. It backs the verification link sent in the challenge.
+func (a *AppService) VerifyAppEndpoint(ctx context.Context, token string) (*datastore.Endpoint, error) {
+	app, err := a.appRepo.FindApplicationByEndpointVerificationToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEndpointNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, errors.New("invalid or expired verification token"))
+		}
+
+		log.WithError(err).Error("failed to find endpoint by verification token")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to verify endpoint"))
+	}
+
+	var endpoint *datastore.Endpoint
+	for i := range app.Endpoints {
+		if app.Endpoints[i].VerificationToken == token {
+			endpoint = &app.Endpoints[i]
+			break
+		}
+	}
+
+	if endpoint == nil || !endpoint.IsPending() {
+		return nil, util.NewServiceError(http.StatusNotFound, errors.New("invalid or expired verification token"))
+	}
+
+	activateEndpoint(endpoint)
+	err = a.appRepo.UpdateApplication(ctx, app, app.GroupID)
+	if err != nil {
+		log.WithError(err).Error("failed to verify app endpoint")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to verify endpoint"))
+	}
+
+	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
+	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
+	}
+
+	a.releaseHeldDeliveries(ctx, app, endpoint)
+
+	return endpoint, nil
+}
+
+// releaseHeldDeliveries enqueues the release of the deliveries held while
+// endpoint was waiting for verification, failures are only logged since
+// the endpoint is verified already.
+func (a *AppService) releaseHeldDeliveries(ctx context.Context, app *datastore.Application, endpoint *datastore.Endpoint) {
+	subscriptions, err := a.subRepo.FindSubscriptionsByAppID(ctx, app.GroupID, app.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to find subscriptions of verified endpoint")
+		return
+	}
+
+	for _, s := range subscriptions {
+		// deliveries of paused subscriptions are released when they are
+		// resumed, resuming subscriptions are being released already.
+		if s.EndpointID != endpoint.UID || s.Status == datastore.PausedSubscriptionStatus ||
+			s.Status == datastore.ResumingSubscriptionStatus {
+			continue
+		}
+
+		payload, err := json.Marshal(datastore.ResumeSubscriptionPayload{
+			GroupID:        app.GroupID,
+			SubscriptionID: s.UID,
+			Rate:           datastore.DefaultResumeRate,
+		})
+		if err != nil {
+			log.WithError(err).Error("failed to marshal resume subscription payload")
+			continue
+		}
+
+		err = a.queue.Write(convoy.ResumeSubscription, convoy.DefaultQueue, &queue.Job{Payload: payload})
+		if err != nil {
+			log.WithError(err).Error("failed to write resume subscription job to the queue")
+		}
+	}
+}
+
+// SendTestEvent delivers a test event to the endpoint right away, signed
+// the same way as regular deliveries, and returns the request and response
+// exchanged. The attempt is stored as a test attempt, so it never affects
+// the endpoint's delivery statistics.
+func (a *AppService) SendTestEvent(ctx context.Context, testEvent *models.TestEvent, app *datastore.Application, e *datastore.Endpoint, g *datastore.Group) (*datastore.TestAttempt, error) {
+	if e.IsPending() {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("endpoint has not been verified"))
+	}
+
+	if g.Config == nil || g.Config.Signature == nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("group signature config is not set"))
+	}
+
+	payload := testEvent.Data
+	if len(payload) == 0 {
+		payload = defaultTestEventPayload
+	}
+
+	buff := bytes.NewBuffer([]byte{})
+	err := json.Compact(buff, payload)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("test event data must be valid json"))
+	}
+	payload = buff.Bytes()
+
+	cfg, err := config.Get()
+	if err != nil {
+		log.WithError(err).Error("failed to load config")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to send test event"))
+	}
+
+	httpTimeout := convoy.HTTP_TIMEOUT
+	if !util.IsStringEmpty(e.HttpTimeout) {
+		httpTimeout = e.HttpTimeout
+	}
+
+	httpDuration, err := time.ParseDuration(httpTimeout)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to parse endpoint http timeout"))
+	}
+
+	hmac, timestamp, err := net.SignPayload(g, e.Secret, payload)
+	if err != nil {
+		log.WithError(err).Error("failed to sign test event")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to sign test event"))
+	}
+
+	start := time.Now()
+	dispatch := net.NewDispatcher(httpDuration)
+	resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), payload, g, hmac, timestamp, int64(cfg.MaxResponseSize), nil)
+	duration := time.Since(start)
+
+	attemptStatus := err == nil && resp.StatusCode >= 200 && resp.StatusCode <= 299
+
+	testAttempt := &datastore.TestAttempt{
+		UID:            uuid.New().String(),
+		GroupID:        g.UID,
+		AppID:          app.UID,
+		EndpointID:     e.UID,
+		Payload:        payload,
+		Attempt:        net.NewDeliveryAttempt(e, resp, attemptStatus),
+		Duration:       duration.Milliseconds(),
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	err = a.testAttemptRepo.CreateTestAttempt(ctx, testAttempt)
+	if err != nil {
+		log.WithError(err).Error("failed to save test attempt")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to save test attempt"))
+	}
+
+	return testAttempt, nil
+}
+
+func (a *AppService) LoadTestAttemptsPaged(ctx context.Context, app *datastore.Application, e *datastore.Endpoint, pageable datastore.Pageable) ([]datastore.TestAttempt, datastore.PaginationData, error) {
+	attempts, paginationData, err := a.testAttemptRepo.LoadTestAttemptsPaged(ctx, app.GroupID, app.UID, e.UID, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load test attempts")
+		return nil, datastore.PaginationData{}, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while fetching test attempts"))
+	}
+
+	return attempts, paginationData, nil
+}
+
+// challengeAppEndpoint sends the verification challenge to a pending
+// endpoint and activates it straight away if the receiver echoes the
+// challenge back. Otherwise the endpoint stays pending until the
+// verification link is visited.
+func (a *AppService) challengeAppEndpoint(ctx context.Context, app *datastore.Application, endpoint *datastore.Endpoint, g *datastore.Group) (*datastore.Endpoint, error) {
+	if !sendEndpointChallenge(endpoint, g) {
+		return endpoint, nil
+	}
+
+	activateEndpoint(endpoint)
+	setEndpoint(&app.Endpoints, endpoint)
+
+	err := a.appRepo.UpdateApplication(ctx, app, app.GroupID)
+	if err != nil {
+		log.WithError(err).Error("failed to activate verified app endpoint")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while verifying app endpoint"))
+	}
+
+	return endpoint, nil
+}
+
 func (a *AppService) CountGroupApplications(ctx context.Context, groupID string) (int64, error) {
 	apps, err := a.appRepo.CountGroupApplications(ctx, groupID)
 	if err != nil {
@@ -287,3 +521,90 @@ func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.
 	}
 	return endpoints, nil, datastore.ErrEndpointNotFound
 }
+
+func setEndpoint(endpoints *[]datastore.Endpoint, e *datastore.Endpoint) {
+	for i := range *endpoints {
+		if (*endpoints)[i].UID == e.UID {
+			(*endpoints)[i] = *e
+		}
+	}
+}
+
+func requiresEndpointVerification(g *datastore.Group) bool {
+	return g != nil && g.Config != nil && g.Config.VerifyEndpoints
+}
+
+func resetEndpointVerification(e *datastore.Endpoint) error {
+	token, err := util.GenerateSecret()
+	if err != nil {
+		return fmt.Errorf("could not generate verification token...%v", err)
+	}
+
+	e.Status = datastore.PendingEndpointStatus
+	e.VerificationToken = token
+	e.VerifiedAt = 0
+	return nil
+}
+
+func activateEndpoint(e *datastore.Endpoint) {
+	e.Status = datastore.ActiveEndpointStatus
+	e.VerificationToken = ""
+	e.VerifiedAt = primitive.NewDateTimeFromTime(time.Now())
+}
+
+type endpointChallenge struct {
+	Type            string `json:"type"`
+	Challenge       string `json:"challenge"`
+	VerificationURL string `json:"verification_url"`
+}
+
+// defaultTestEventPayload is sent when a test event is requested without data.
+var defaultTestEventPayload = json.RawMessage(`{"event_type":"convoy.test","data":{"message":"This is a test event from Convoy"}}`)
+
+// sendEndpointChallenge posts a signed challenge to the endpoint and
+// reports whether the receiver echoed it back, either as the raw response
+// body or as the challenge field of a JSON body.
+func sendEndpointChallenge(e *datastore.Endpoint, g *datastore.Group) bool {
+	if g.Config.Signature == nil {
+		return false
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		log.WithError(err).Error("failed to load config")
+		return false
+	}
+
+	body, err := json.Marshal(endpointChallenge{
+		Type:            "endpoint.verification",
+		Challenge:       e.VerificationToken,
+		VerificationURL: fmt.Sprintf("%s/endpoint-verification/%s", cfg.Host, e.VerificationToken),
+	})
+	if err != nil {
+		return false
+	}
+
+	hmac, timestamp, err := net.SignPayload(g, e.Secret, body)
+	if err != nil {
+		log.WithError(err).Error("failed to sign endpoint verification challenge")
+		return false
+	}
+
+	dispatch := net.NewDispatcher(endpointVerificationTimeout)
+	resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), body, g, hmac, timestamp, int64(cfg.MaxResponseSize), nil)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.WithError(err).Infof("endpoint %s did not answer the verification challenge", e.UID)
+		return false
+	}
+
+	if string(bytes.TrimSpace(resp.Body)) == e.VerificationToken {
+		return true
+	}
+
+	var echo endpointChallenge
+	if err := json.Unmarshal(resp.Body, &echo); err != nil {
+		return false
+	}
+
+	return echo.Challenge == e.VerificationToken
+}
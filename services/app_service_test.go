@@ -2,10 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
@@ -18,8 +22,11 @@ func provideAppService(ctrl *gomock.Controller) *AppService {
 	appRepo := mocks.NewMockApplicationRepository(ctrl)
 	eventRepo := mocks.NewMockEventRepository(ctrl)
 	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	testAttemptRepo := mocks.NewMockTestAttemptRepository(ctrl)
+	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+	queue := mocks.NewMockQueuer(ctrl)
 	cache := mocks.NewMockCache(ctrl)
-	return NewAppService(appRepo, eventRepo, eventDeliveryRepo, cache)
+	return NewAppService(appRepo, eventRepo, eventDeliveryRepo, testAttemptRepo, subRepo, queue, cache)
 }
 
 func boolPtr(b bool) *bool {
@@ -593,6 +600,7 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 		ctx context.Context
 		e   models.Endpoint
 		app *datastore.Application
+		g   *datastore.Group
 	}
 	tests := []struct {
 		name         string
@@ -635,6 +643,7 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 				},
 			},
 			wantEndpoint: &datastore.Endpoint{
+				Status:            datastore.ActiveEndpointStatus,
 				Secret:            "1234",
 				TargetURL:         "https://google.com",
 				Description:       "test_endpoint",
@@ -681,6 +690,7 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 				},
 			},
 			wantEndpoint: &datastore.Endpoint{
+				Status:            datastore.ActiveEndpointStatus,
 				Secret:            "1234",
 				TargetURL:         "https://google.com",
 				Description:       "test_endpoint",
@@ -740,7 +750,7 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 				tc.dbFn(as)
 			}
 
-			appEndpoint, err := as.CreateAppEndpoint(tc.args.ctx, tc.args.e, tc.args.app)
+			appEndpoint, err := as.CreateAppEndpoint(tc.args.ctx, tc.args.e, tc.args.app, tc.args.g)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
@@ -809,6 +819,7 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 		e          models.Endpoint
 		endPointId string
 		app        *datastore.Application
+		g          *datastore.Group
 	}
 	tests := []struct {
 		name         string
@@ -976,7 +987,7 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 				tc.dbFn(as)
 			}
 
-			appEndpoint, err := as.UpdateAppEndpoint(tc.args.ctx, tc.args.e, tc.args.endPointId, tc.args.app)
+			appEndpoint, err := as.UpdateAppEndpoint(tc.args.ctx, tc.args.e, tc.args.endPointId, tc.args.app, tc.args.g)
 			if tc.wantErr {
 				require.NotNil(t, err)
 				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
@@ -1117,3 +1128,268 @@ func TestAppService_DeleteAppEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestAppService_CreateAppEndpoint_Verification(t *testing.T) {
+	ctx := context.Background()
+
+	err := config.LoadConfig("")
+	require.NoError(t, err)
+
+	g := &datastore.Group{
+		UID: "1234",
+		Config: &datastore.GroupConfig{
+			Signature:       &datastore.DefaultSignatureConfig,
+			VerifyEndpoints: true,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus datastore.EndpointStatus
+	}{
+		{
+			name: "should_activate_endpoint_that_echoes_challenge",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var c endpointChallenge
+				_ = json.NewDecoder(r.Body).Decode(&c)
+				_ = json.NewEncoder(w).Encode(map[string]string{"challenge": c.Challenge})
+			},
+			wantStatus: datastore.ActiveEndpointStatus,
+		},
+		{
+			name: "should_keep_endpoint_pending_without_echo",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			wantStatus: datastore.PendingEndpointStatus,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			srv := httptest.NewServer(tc.handler)
+			defer srv.Close()
+
+			var created *datastore.Endpoint
+			a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+			a.EXPECT().CreateApplicationEndpoint(gomock.Any(), gomock.Any(), "abc", gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _ string, _ string, e *datastore.Endpoint) error {
+					created = e
+					return nil
+				})
+
+			a.EXPECT().FindApplicationByID(gomock.Any(), "abc").Times(1).
+				DoAndReturn(func(_ context.Context, _ string) (*datastore.Application, error) {
+					return &datastore.Application{UID: "abc", Endpoints: []datastore.Endpoint{*created}}, nil
+				})
+
+			if tc.wantStatus == datastore.ActiveEndpointStatus {
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			c, _ := as.cache.(*mocks.MockCache)
+			c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+			endpoint, err := as.CreateAppEndpoint(ctx, models.Endpoint{URL: srv.URL, Secret: "1234"}, &datastore.Application{UID: "abc"}, g)
+			require.Nil(t, err)
+			require.Equal(t, tc.wantStatus, endpoint.Status)
+
+			if tc.wantStatus == datastore.PendingEndpointStatus {
+				require.NotEmpty(t, endpoint.VerificationToken)
+				return
+			}
+
+			require.Empty(t, endpoint.VerificationToken)
+			require.NotZero(t, endpoint.VerifiedAt)
+		})
+	}
+}
+
+func TestAppService_VerifyAppEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		token       string
+		dbFn        func(as *AppService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:  "should_verify_pending_endpoint",
+			token: "token",
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByEndpointVerificationToken(gomock.Any(), "token").Times(1).Return(&datastore.Application{
+					UID:     "abc",
+					GroupID: "group1",
+					Endpoints: []datastore.Endpoint{
+						{UID: "endpoint1", Status: datastore.PendingEndpointStatus, VerificationToken: "token"},
+					},
+				}, nil)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				s, _ := as.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group1", "abc").Times(1).Return([]datastore.Subscription{
+					{UID: "sub1", EndpointID: "endpoint1", Status: datastore.ActiveSubscriptionStatus},
+					{UID: "sub2", EndpointID: "endpoint1", Status: datastore.PausedSubscriptionStatus},
+					{UID: "sub3", EndpointID: "endpoint2", Status: datastore.ActiveSubscriptionStatus},
+				}, nil)
+
+				// only the active subscription of the endpoint is released.
+				q, _ := as.queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.ResumeSubscription, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:  "should_error_for_unknown_token",
+			token: "token",
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByEndpointVerificationToken(gomock.Any(), "token").Times(1).Return(nil, datastore.ErrEndpointNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "invalid or expired verification token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(as)
+			}
+
+			endpoint, err := as.VerifyAppEndpoint(ctx, tc.token)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, datastore.ActiveEndpointStatus, endpoint.Status)
+			require.Empty(t, endpoint.VerificationToken)
+		})
+	}
+}
+
+func TestAppService_SendTestEvent(t *testing.T) {
+	ctx := context.Background()
+
+	err := config.LoadConfig("")
+	require.NoError(t, err)
+
+	g := &datastore.Group{
+		UID: "1234",
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.DefaultSignatureConfig,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		testEvent   *models.TestEvent
+		endpoint    *datastore.Endpoint
+		handler     http.HandlerFunc
+		wantPayload string
+		wantStatus  bool
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:      "should_send_test_event",
+			testEvent: &models.TestEvent{Data: json.RawMessage(`{"name": "test"}`)},
+			endpoint:  &datastore.Endpoint{UID: "ref", Secret: "1234"},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("ok"))
+			},
+			wantPayload: `{"name":"test"}`,
+			wantStatus:  true,
+		},
+		{
+			name:      "should_send_sample_payload_without_data",
+			testEvent: &models.TestEvent{},
+			endpoint:  &datastore.Endpoint{UID: "ref", Secret: "1234"},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			wantPayload: string(defaultTestEventPayload),
+			wantStatus:  true,
+		},
+		{
+			name:      "should_record_failed_test_event",
+			testEvent: &models.TestEvent{},
+			endpoint:  &datastore.Endpoint{UID: "ref", Secret: "1234"},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantPayload: string(defaultTestEventPayload),
+			wantStatus:  false,
+		},
+		{
+			name:        "should_error_for_pending_endpoint",
+			testEvent:   &models.TestEvent{},
+			endpoint:    &datastore.Endpoint{UID: "ref", Status: datastore.PendingEndpointStatus},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "endpoint has not been verified",
+		},
+		{
+			name:        "should_error_for_invalid_data",
+			testEvent:   &models.TestEvent{Data: json.RawMessage(`{"name"`)},
+			endpoint:    &datastore.Endpoint{UID: "ref"},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "test event data must be valid json",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			if tc.handler != nil {
+				srv := httptest.NewServer(tc.handler)
+				defer srv.Close()
+				tc.endpoint.TargetURL = srv.URL
+
+				ta, _ := as.testAttemptRepo.(*mocks.MockTestAttemptRepository)
+				ta.EXPECT().CreateTestAttempt(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			}
+
+			attempt, err := as.SendTestEvent(ctx, tc.testEvent, &datastore.Application{UID: "abc"}, tc.endpoint, g)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, "abc", attempt.AppID)
+			require.Equal(t, "ref", attempt.EndpointID)
+			require.Equal(t, tc.wantPayload, string(attempt.Payload))
+			require.Equal(t, tc.wantStatus, attempt.Attempt.Status)
+			require.NotEmpty(t, attempt.Attempt.RequestHeader[g.Config.Signature.Header.String()])
+		})
+	}
+}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/server/models"
@@ -27,15 +29,17 @@ type EventService struct {
 	sourceRepo        datastore.SourceRepository
 	eventRepo         datastore.EventRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	attemptRepo       datastore.DeliveryAttemptRepository
 	queue             queue.Queuer
 	subRepo           datastore.SubscriptionRepository
 	cache             cache.Cache
 	searcher          searcher.Searcher
+	payloads          *claimcheck.Store
 }
 
-func NewEventService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository,
-	queue queue.Queuer, cache cache.Cache, seacher searcher.Searcher, subRepo datastore.SubscriptionRepository, sourceRepo datastore.SourceRepository) *EventService {
-	return &EventService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, queue: queue, cache: cache, searcher: seacher, subRepo: subRepo, sourceRepo: sourceRepo}
+func NewEventService(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, eventDeliveryRepo datastore.EventDeliveryRepository, attemptRepo datastore.DeliveryAttemptRepository,
+	queue queue.Queuer, cache cache.Cache, seacher searcher.Searcher, subRepo datastore.SubscriptionRepository, sourceRepo datastore.SourceRepository, payloads *claimcheck.Store) *EventService {
+	return &EventService{appRepo: appRepo, eventRepo: eventRepo, eventDeliveryRepo: eventDeliveryRepo, attemptRepo: attemptRepo, queue: queue, cache: cache, searcher: seacher, subRepo: subRepo, sourceRepo: sourceRepo, payloads: payloads}
 }
 
 func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Event, g *datastore.Group) (*datastore.Event, error) {
@@ -84,6 +88,7 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 	event := &datastore.Event{
 		UID:            uuid.New().String(),
 		EventType:      datastore.EventType(newMessage.EventType),
+		Priority:       newMessage.Priority,
 		Data:           newMessage.Data,
 		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
@@ -110,7 +115,7 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 		Payload: payload,
 		Delay:   0,
 	}
-	err = e.queue.Write(taskName, convoy.CreateEventQueue, job)
+	err = e.queue.Write(taskName, event.Priority.CreateEventQueue(), job)
 	if err != nil {
 		log.Errorf("Error occurred sending new event to the queue %s", err)
 	}
@@ -131,7 +136,7 @@ func (e *EventService) ReplayAppEvent(ctx context.Context, event *datastore.Even
 		Payload: payload,
 		Delay:   0,
 	}
-	err = e.queue.Write(taskName, convoy.CreateEventQueue, job)
+	err = e.queue.Write(taskName, event.Priority.CreateEventQueue(), job)
 	if err != nil {
 		log.WithError(err).Error("replay_event: failed to write event to the queue")
 		return util.NewServiceError(http.StatusBadRequest, errors.New("failed to write event to queue"))
@@ -150,6 +155,124 @@ func (e *EventService) GetAppEvent(ctx context.Context, id string) (*datastore.E
 	return event, nil
 }
 
+// LoadEventPayload sets the data of event from the object store when it
+// was offloaded.
+func (e *EventService) LoadEventPayload(ctx context.Context, event *datastore.Event) error {
+	err := e.payloads.LoadEvent(ctx, event)
+	if err != nil {
+		log.WithError(err).Error("failed to load event payload")
+		return util.NewServiceError(http.StatusInternalServerError, errors.New("failed to load event payload"))
+	}
+
+	return nil
+}
+
+// idempotencyKeyClaim records the event that claimed an idempotency key.
+type idempotencyKeyClaim struct {
+	EventID string
+}
+
+// FindDuplicateEvent returns the event the source ingested within its
+// deduplication window with the same idempotency key and counts the new
+// request as its duplicate. It returns nil when there's no such event, and
+// claims the key for eventID so concurrent retries of the request are
+// duplicates of eventID while it is still being created.
+func (e *EventService) FindDuplicateEvent(ctx context.Context, source *datastore.Source, idempotencyKey string, eventID string) (*datastore.Event, error) {
+	window := int64(config.DefaultDeduplicationWindow)
+	if source.Deduplication != nil && source.Deduplication.Window > 0 {
+		window = source.Deduplication.Window
+	}
+
+	key := convoy.IdempotencyKeysCacheKey.Get(source.UID).Get(idempotencyKey).String()
+	claimed, err := e.cache.SetNX(ctx, key, &idempotencyKeyClaim{EventID: eventID}, time.Duration(window)*time.Second)
+	if err != nil {
+		// the events stored within the window are still deduplicated.
+		log.WithError(err).Error("failed to claim idempotency key")
+		claimed = true
+	}
+
+	if !claimed {
+		var claim idempotencyKeyClaim
+		err = e.cache.Get(ctx, key, &claim)
+		if err != nil {
+			log.WithError(err).Error("failed to find idempotency key claim")
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to find duplicate event"))
+		}
+
+		if !util.IsStringEmpty(claim.EventID) && claim.EventID != eventID {
+			return e.recordDuplicateEvent(ctx, source, claim.EventID)
+		}
+	}
+
+	since := time.Now().Add(-time.Duration(window) * time.Second)
+	event, err := e.eventRepo.FindEventByIdempotencyKey(ctx, source.UID, idempotencyKey, since)
+	if errors.Is(err, datastore.ErrEventNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		log.WithError(err).Error("failed to find event by idempotency key")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to find duplicate event"))
+	}
+
+	// the key was claimed after the event was created, retries are
+	// duplicates of the stored event.
+	err = e.cache.Set(ctx, key, &idempotencyKeyClaim{EventID: event.UID}, time.Until(event.CreatedAt.Time().Add(time.Duration(window)*time.Second)))
+	if err != nil {
+		log.WithError(err).Error("failed to claim idempotency key")
+	}
+
+	err = e.eventRepo.IncrementDuplicateCount(ctx, event.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to increment event duplicate count")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to record duplicate event"))
+	}
+
+	return event, nil
+}
+
+// ReleaseIdempotencyKey releases the claim eventID holds on an idempotency
+// key, so retries of a request whose event failed to be queued aren't
+// taken for duplicates of it.
+func (e *EventService) ReleaseIdempotencyKey(ctx context.Context, source *datastore.Source, idempotencyKey string, eventID string) error {
+	key := convoy.IdempotencyKeysCacheKey.Get(source.UID).Get(idempotencyKey).String()
+
+	var claim idempotencyKeyClaim
+	err := e.cache.Get(ctx, key, &claim)
+	if err != nil {
+		return err
+	}
+
+	// the key is claimed by another event.
+	if claim.EventID != eventID {
+		return nil
+	}
+
+	return e.cache.Delete(ctx, key)
+}
+
+// recordDuplicateEvent counts a request as a duplicate of the event that
+// claimed its idempotency key, the event may still be in the queue.
+func (e *EventService) recordDuplicateEvent(ctx context.Context, source *datastore.Source, eventID string) (*datastore.Event, error) {
+	event, err := e.eventRepo.FindEventByID(ctx, eventID)
+	if errors.Is(err, datastore.ErrEventNotFound) {
+		return &datastore.Event{UID: eventID, SourceID: source.UID, GroupID: source.GroupID}, nil
+	}
+
+	if err != nil {
+		log.WithError(err).Error("failed to find duplicate event")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to find duplicate event"))
+	}
+
+	err = e.eventRepo.IncrementDuplicateCount(ctx, event.UID)
+	if err != nil {
+		log.WithError(err).Error("failed to increment event duplicate count")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to record duplicate event"))
+	}
+
+	return event, nil
+}
+
 func (e *EventService) Search(ctx context.Context, filter *datastore.Filter) ([]datastore.Event, datastore.PaginationData, error) {
 	var events []datastore.Event
 	ids, paginationData, err := e.searcher.Search(filter.Group.UID, &datastore.SearchFilter{
@@ -186,6 +309,42 @@ func (e *EventService) GetEventDelivery(ctx context.Context, id string) (*datast
 	return eventDelivery, nil
 }
 
+// LoadEventDeliveryPayload sets the data of eventDelivery from the object
+// store when it was offloaded.
+func (e *EventService) LoadEventDeliveryPayload(ctx context.Context, eventDelivery *datastore.EventDelivery) error {
+	err := e.payloads.LoadEventDelivery(ctx, eventDelivery)
+	if err != nil {
+		log.WithError(err).Error("failed to load event delivery payload")
+		return util.NewServiceError(http.StatusInternalServerError, errors.New("failed to load event delivery payload"))
+	}
+
+	return nil
+}
+
+func (e *EventService) GetDeliveryAttempt(ctx context.Context, eventDelivery *datastore.EventDelivery, id string) (*datastore.DeliveryAttempt, error) {
+	attempt, err := e.attemptRepo.FindDeliveryAttemptByID(ctx, eventDelivery.UID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrEventDeliveryAttemptNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to find delivery attempt")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to find delivery attempt"))
+	}
+
+	return attempt, nil
+}
+
+func (e *EventService) LoadDeliveryAttemptsPaged(ctx context.Context, filter *datastore.DeliveryAttemptFilter, pageable datastore.Pageable) ([]datastore.DeliveryAttempt, datastore.PaginationData, error) {
+	attempts, paginationData, err := e.attemptRepo.LoadDeliveryAttemptsPaged(ctx, filter, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load delivery attempts")
+		return nil, datastore.PaginationData{}, util.NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching delivery attempts"))
+	}
+
+	return attempts, paginationData, nil
+}
+
 func (e *EventService) BatchRetryEventDelivery(ctx context.Context, filter *datastore.Filter) (int, int, error) {
 	deliveries, _, err := e.eventDeliveryRepo.LoadEventDeliveriesPaged(ctx, filter.Group.UID, filter.AppID, filter.EventID, filter.Status, filter.SearchParams, filter.Pageable)
 	if err != nil {
@@ -418,7 +577,7 @@ func (e *EventService) requeueEventDelivery(ctx context.Context, eventDelivery *
 		Payload: json.RawMessage(eventDelivery.UID),
 		Delay:   1 * time.Second,
 	}
-	err = e.queue.Write(taskName, convoy.EventQueue, job)
+	err = e.queue.Write(taskName, eventDelivery.Priority.EventQueue(), job)
 	if err != nil {
 		return fmt.Errorf("error occurred re-enqueing old event - %s: %v", eventDelivery.UID, err)
 	}
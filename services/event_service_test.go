@@ -6,26 +6,31 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func provideEventService(ctrl *gomock.Controller) *EventService {
 	appRepo := mocks.NewMockApplicationRepository(ctrl)
 	eventRepo := mocks.NewMockEventRepository(ctrl)
 	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+	attemptRepo := mocks.NewMockDeliveryAttemptRepository(ctrl)
 	queue := mocks.NewMockQueuer(ctrl)
 	cache := mocks.NewMockCache(ctrl)
 	searcher := mocks.NewMockSearcher(ctrl)
 	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
 	sourceRepo := mocks.NewMockSourceRepository(ctrl)
-	return NewEventService(appRepo, eventRepo, eventDeliveryRepo, queue, cache, searcher, subRepo, sourceRepo)
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+	return NewEventService(appRepo, eventRepo, eventDeliveryRepo, attemptRepo, queue, cache, searcher, subRepo, sourceRepo, claimcheck.NewStore(configRepo))
 }
 
 func TestEventService_CreateAppEvent(t *testing.T) {
@@ -261,6 +266,72 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "retry strategy not defined in configuration",
 		},
+		{
+			name: "should_create_high_priority_event",
+			dbFn: func(es *EventService) {
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any())
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+
+				a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "123").
+					Times(1).Return(&datastore.Application{
+					Title:     "test_app",
+					UID:       "123",
+					GroupID:   "abc",
+					Endpoints: []datastore.Endpoint{{UID: "ref"}},
+				}, nil)
+
+				eq, _ := es.queue.(*mocks.MockQueuer)
+				eq.EXPECT().Write(convoy.CreateEventProcessor, convoy.HighPriorityCreateEventQueue, gomock.Any()).
+					Times(1).Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:     "123",
+					EventType: "payment.created",
+					Priority:  datastore.HighEventPriority,
+					Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				},
+				g: &datastore.Group{
+					UID:  "abc",
+					Name: "test_group",
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   1000,
+							RetryCount: 10,
+						},
+						Signature: &datastore.SignatureConfiguration{},
+					},
+				},
+			},
+			wantEvent: &datastore.Event{
+				EventType:      datastore.EventType("payment.created"),
+				Priority:       datastore.HighEventPriority,
+				Data:           bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				AppID:          "123",
+				GroupID:        "abc",
+				DocumentStatus: datastore.ActiveDocumentStatus,
+			},
+		},
+		{
+			name: "should_error_for_unsupported_priority",
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:     "123",
+					EventType: "payment.created",
+					Priority:  "urgent",
+					Data:      bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				},
+				g: &datastore.Group{},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "priority:unsupported event priority",
+		},
 		{
 			name: "should_error_for_empty_app_id",
 			args: args{
@@ -452,6 +523,173 @@ func TestEventService_GetAppEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_FindDuplicateEvent(t *testing.T) {
+	ctx := context.Background()
+	source := &datastore.Source{UID: "abc", GroupID: "group-1", Deduplication: &datastore.SourceDeduplication{Window: 60}}
+	createdAt := primitive.NewDateTimeFromTime(time.Now())
+
+	claim := func(es *EventService, claimedBy string) {
+		c, _ := es.cache.(*mocks.MockCache)
+		c.EXPECT().SetNX(gomock.Any(), "idempotency_keys:abc:key", &idempotencyKeyClaim{EventID: "456"}, time.Minute).
+			Times(1).Return(claimedBy == "", nil)
+
+		if claimedBy != "" {
+			c.EXPECT().Get(gomock.Any(), "idempotency_keys:abc:key", gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+					data.(*idempotencyKeyClaim).EventID = claimedBy
+					return nil
+				})
+		}
+	}
+
+	tests := []struct {
+		name          string
+		dbFn          func(es *EventService)
+		wantDuplicate *datastore.Event
+		wantErr       bool
+		wantErrCode   int
+		wantErrMsg    string
+	}{
+		{
+			name: "should_find_duplicate_event",
+			dbFn: func(es *EventService) {
+				claim(es, "")
+
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByIdempotencyKey(gomock.Any(), "abc", "key", gomock.Any()).
+					Times(1).Return(&datastore.Event{UID: "123", CreatedAt: createdAt}, nil)
+				e.EXPECT().IncrementDuplicateCount(gomock.Any(), "123").Times(1).Return(nil)
+
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), "idempotency_keys:abc:key", &idempotencyKeyClaim{EventID: "123"}, gomock.Any()).Times(1).Return(nil)
+			},
+			wantDuplicate: &datastore.Event{UID: "123", CreatedAt: createdAt},
+		},
+		{
+			name: "should_find_claimed_duplicate_event",
+			dbFn: func(es *EventService) {
+				claim(es, "123")
+
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByID(gomock.Any(), "123").Times(1).Return(&datastore.Event{UID: "123"}, nil)
+				e.EXPECT().IncrementDuplicateCount(gomock.Any(), "123").Times(1).Return(nil)
+			},
+			wantDuplicate: &datastore.Event{UID: "123"},
+		},
+		{
+			name: "should_find_queued_duplicate_event",
+			dbFn: func(es *EventService) {
+				claim(es, "123")
+
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByID(gomock.Any(), "123").Times(1).Return(nil, datastore.ErrEventNotFound)
+			},
+			wantDuplicate: &datastore.Event{UID: "123", SourceID: "abc", GroupID: "group-1"},
+		},
+		{
+			name: "should_not_find_duplicate_event",
+			dbFn: func(es *EventService) {
+				claim(es, "")
+
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByIdempotencyKey(gomock.Any(), "abc", "key", gomock.Any()).
+					Times(1).Return(nil, datastore.ErrEventNotFound)
+			},
+		},
+		{
+			name: "should_fail_to_find_duplicate_event",
+			dbFn: func(es *EventService) {
+				claim(es, "")
+
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByIdempotencyKey(gomock.Any(), "abc", "key", gomock.Any()).
+					Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to find duplicate event",
+		},
+		{
+			name: "should_fail_to_record_duplicate_event",
+			dbFn: func(es *EventService) {
+				claim(es, "123")
+
+				e, _ := es.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().FindEventByID(gomock.Any(), "123").Times(1).Return(&datastore.Event{UID: "123"}, nil)
+				e.EXPECT().IncrementDuplicateCount(gomock.Any(), "123").Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to record duplicate event",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			duplicate, err := es.FindDuplicateEvent(ctx, source, "key", "456")
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantDuplicate, duplicate)
+		})
+	}
+}
+
+func TestEventService_ReleaseIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	source := &datastore.Source{UID: "abc", GroupID: "group-1"}
+
+	tests := []struct {
+		name    string
+		claimBy string
+		dbFn    func(c *mocks.MockCache)
+	}{
+		{
+			name:    "should_release_claimed_key",
+			claimBy: "456",
+			dbFn: func(c *mocks.MockCache) {
+				c.EXPECT().Delete(gomock.Any(), "idempotency_keys:abc:key").Times(1).Return(nil)
+			},
+		},
+		{
+			name:    "should_keep_key_claimed_by_another_event",
+			claimBy: "123",
+			dbFn: func(c *mocks.MockCache) {
+				c.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			c, _ := es.cache.(*mocks.MockCache)
+			c.EXPECT().Get(gomock.Any(), "idempotency_keys:abc:key", gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+					data.(*idempotencyKeyClaim).EventID = tc.claimBy
+					return nil
+				})
+			tc.dbFn(c)
+
+			require.NoError(t, es.ReleaseIdempotencyKey(ctx, source, "key", "456"))
+		})
+	}
+}
+
 func TestEventService_ReplayAppEvent(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -589,6 +827,94 @@ func TestEventService_GetEventDelivery(t *testing.T) {
 	}
 }
 
+func TestEventService_GetDeliveryAttempt(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx           context.Context
+		eventDelivery *datastore.EventDelivery
+		id            string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		dbFn        func(es *EventService)
+		wantAttempt *datastore.DeliveryAttempt
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_get_delivery_attempt",
+			args: args{
+				ctx:           ctx,
+				eventDelivery: &datastore.EventDelivery{UID: "123"},
+				id:            "abc",
+			},
+			dbFn: func(es *EventService) {
+				a, _ := es.attemptRepo.(*mocks.MockDeliveryAttemptRepository)
+				a.EXPECT().FindDeliveryAttemptByID(gomock.Any(), "123", "abc").
+					Times(1).Return(&datastore.DeliveryAttempt{UID: "abc", MsgID: "123"}, nil)
+			},
+			wantAttempt: &datastore.DeliveryAttempt{UID: "abc", MsgID: "123"},
+		},
+		{
+			name: "should_fail_to_find_delivery_attempt",
+			args: args{
+				ctx:           ctx,
+				eventDelivery: &datastore.EventDelivery{UID: "123"},
+				id:            "abc",
+			},
+			dbFn: func(es *EventService) {
+				a, _ := es.attemptRepo.(*mocks.MockDeliveryAttemptRepository)
+				a.EXPECT().FindDeliveryAttemptByID(gomock.Any(), "123", "abc").
+					Times(1).Return(nil, datastore.ErrEventDeliveryAttemptNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "event delivery attempt not found",
+		},
+		{
+			name: "should_fail_to_get_delivery_attempt",
+			args: args{
+				ctx:           ctx,
+				eventDelivery: &datastore.EventDelivery{UID: "123"},
+				id:            "abc",
+			},
+			dbFn: func(es *EventService) {
+				a, _ := es.attemptRepo.(*mocks.MockDeliveryAttemptRepository)
+				a.EXPECT().FindDeliveryAttemptByID(gomock.Any(), "123", "abc").
+					Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to find delivery attempt",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			attempt, err := es.GetDeliveryAttempt(tc.args.ctx, tc.args.eventDelivery, tc.args.id)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantAttempt, attempt)
+		})
+	}
+}
+
 func TestEventService_BatchRetryEventDelivery(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxReplayJobRateLimit caps the number of events a single replay job
+// can process per second.
+const maxReplayJobRateLimit = 1000
+
+type ReplayJobService struct {
+	replayJobRepo datastore.ReplayJobRepository
+	eventRepo     datastore.EventRepository
+	appRepo       datastore.ApplicationRepository
+	sourceRepo    datastore.SourceRepository
+	queue         queue.Queuer
+}
+
+func NewReplayJobService(replayJobRepo datastore.ReplayJobRepository, eventRepo datastore.EventRepository, appRepo datastore.ApplicationRepository, sourceRepo datastore.SourceRepository, queue queue.Queuer) *ReplayJobService {
+	return &ReplayJobService{replayJobRepo: replayJobRepo, eventRepo: eventRepo, appRepo: appRepo, sourceRepo: sourceRepo, queue: queue}
+}
+
+// CountReplayJobEvents returns the number of events a replay job created
+// from newJob would replay. It is used for dry runs.
+func (r *ReplayJobService) CountReplayJobEvents(ctx context.Context, newJob *models.ReplayJob, g *datastore.Group) (int64, error) {
+	filter, err := r.buildEventFilter(ctx, newJob, g)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := r.eventRepo.CountEvents(ctx, filter)
+	if err != nil {
+		log.WithError(err).Error("failed to count replay job events")
+		return 0, util.NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while counting events"))
+	}
+
+	return count, nil
+}
+
+func (r *ReplayJobService) CreateReplayJob(ctx context.Context, newJob *models.ReplayJob, g *datastore.Group) (*datastore.ReplayJob, error) {
+	filter, err := r.buildEventFilter(ctx, newJob, g)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := r.eventRepo.CountEvents(ctx, filter)
+	if err != nil {
+		log.WithError(err).Error("failed to count replay job events")
+		return nil, util.NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while counting events"))
+	}
+
+	rateLimit := newJob.RateLimit
+	if rateLimit == 0 {
+		rateLimit = datastore.DefaultReplayJobRateLimit
+	}
+
+	job := &datastore.ReplayJob{
+		UID:            uuid.New().String(),
+		GroupID:        g.UID,
+		Filter:         filter,
+		Status:         datastore.PendingReplayJobStatus,
+		RateLimit:      rateLimit,
+		TotalEvents:    count,
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	err = r.replayJobRepo.CreateReplayJob(ctx, job)
+	if err != nil {
+		log.WithError(err).Error("failed to create replay job")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to create replay job"))
+	}
+
+	jobByte, err := json.Marshal(job)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	err = r.queue.Write(convoy.ReplayJobProcessor, convoy.DefaultQueue, &queue.Job{
+		ID:      job.UID,
+		Payload: json.RawMessage(jobByte),
+		Delay:   0,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to write replay job to the queue")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to write replay job to queue"))
+	}
+
+	return job, nil
+}
+
+func (r *ReplayJobService) FindReplayJobByID(ctx context.Context, g *datastore.Group, id string) (*datastore.ReplayJob, error) {
+	job, err := r.replayJobRepo.FindReplayJobByID(ctx, g.UID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrReplayJobNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("error retrieving replay job"))
+	}
+
+	return job, nil
+}
+
+func (r *ReplayJobService) LoadReplayJobsPaged(ctx context.Context, g *datastore.Group, pageable datastore.Pageable) ([]datastore.ReplayJob, datastore.PaginationData, error) {
+	jobs, paginationData, err := r.replayJobRepo.LoadReplayJobsPaged(ctx, g.UID, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load replay jobs")
+		return nil, datastore.PaginationData{}, util.NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching replay jobs"))
+	}
+
+	return jobs, paginationData, nil
+}
+
+// CancelReplayJob marks a pending or running job as cancelled. The worker
+// checks the job status between batches and stops once it sees this.
+func (r *ReplayJobService) CancelReplayJob(ctx context.Context, job *datastore.ReplayJob) (*datastore.ReplayJob, error) {
+	if job.IsDone() {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("only pending or running replay jobs can be cancelled"))
+	}
+
+	job.Status = datastore.CancelledReplayJobStatus
+	job.CompletedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	err := r.replayJobRepo.UpdateReplayJob(ctx, job)
+	if err != nil {
+		log.WithError(err).Error("failed to cancel replay job")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while cancelling replay job"))
+	}
+
+	return job, nil
+}
+
+func (r *ReplayJobService) buildEventFilter(ctx context.Context, newJob *models.ReplayJob, g *datastore.Group) (*datastore.EventFilter, error) {
+	if err := util.Validate(newJob); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if newJob.StartDate.IsZero() || newJob.EndDate.IsZero() {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("please provide a start date and an end date"))
+	}
+
+	if newJob.StartDate.After(newJob.EndDate) {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("start date cannot be after end date"))
+	}
+
+	if newJob.RateLimit < 0 || newJob.RateLimit > maxReplayJobRateLimit {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("rate limit must be between 0 (default) and 1000 events per second"))
+	}
+
+	if !util.IsStringEmpty(newJob.AppID) {
+		app, err := r.appRepo.FindApplicationByID(ctx, newJob.AppID)
+		if err != nil {
+			log.WithError(err).Error("failed to find application by id")
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to find application by id"))
+		}
+
+		if app.GroupID != g.UID {
+			return nil, util.NewServiceError(http.StatusUnauthorized, errors.New("app does not belong to group"))
+		}
+	}
+
+	if !util.IsStringEmpty(newJob.SourceID) {
+		_, err := r.sourceRepo.FindSourceByID(ctx, g.UID, newJob.SourceID)
+		if err != nil {
+			log.WithError(err).Error("failed to find source by id")
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to find source by id"))
+		}
+	}
+
+	return &datastore.EventFilter{
+		GroupID:        g.UID,
+		AppID:          newJob.AppID,
+		SourceID:       newJob.SourceID,
+		EventTypes:     newJob.EventTypes,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+		CreatedAtStart: newJob.StartDate.Unix(),
+		CreatedAtEnd:   newJob.EndDate.Unix(),
+	}, nil
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideReplayJobService(ctrl *gomock.Controller) *ReplayJobService {
+	replayJobRepo := mocks.NewMockReplayJobRepository(ctrl)
+	eventRepo := mocks.NewMockEventRepository(ctrl)
+	appRepo := mocks.NewMockApplicationRepository(ctrl)
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	queue := mocks.NewMockQueuer(ctrl)
+	return NewReplayJobService(replayJobRepo, eventRepo, appRepo, sourceRepo, queue)
+}
+
+func TestReplayJobService_CreateReplayJob(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+	end := time.Now()
+
+	type args struct {
+		ctx    context.Context
+		newJob *models.ReplayJob
+		group  *datastore.Group
+	}
+
+	tests := []struct {
+		name        string
+		args        args
+		dbFn        func(rs *ReplayJobService)
+		wantJob     *datastore.ReplayJob
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_create_replay_job",
+			args: args{
+				ctx: ctx,
+				newJob: &models.ReplayJob{
+					AppID:      "app-1",
+					EventTypes: []string{"payment.created"},
+					StartDate:  start,
+					EndDate:    end,
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			dbFn: func(rs *ReplayJobService) {
+				a, _ := rs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).Return(&datastore.Application{UID: "app-1", GroupID: "12345"}, nil)
+
+				e, _ := rs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountEvents(gomock.Any(), gomock.Any()).Times(1).Return(int64(20), nil)
+
+				r, _ := rs.replayJobRepo.(*mocks.MockReplayJobRepository)
+				r.EXPECT().CreateReplayJob(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				q, _ := rs.queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.ReplayJobProcessor, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantJob: &datastore.ReplayJob{
+				GroupID:     "12345",
+				Status:      datastore.PendingReplayJobStatus,
+				RateLimit:   datastore.DefaultReplayJobRateLimit,
+				TotalEvents: 20,
+			},
+		},
+		{
+			name: "should_error_for_start_date_after_end_date",
+			args: args{
+				ctx: ctx,
+				newJob: &models.ReplayJob{
+					StartDate: end,
+					EndDate:   start,
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "start date cannot be after end date",
+		},
+		{
+			name: "should_error_for_rate_limit_above_maximum",
+			args: args{
+				ctx: ctx,
+				newJob: &models.ReplayJob{
+					StartDate: start,
+					EndDate:   end,
+					RateLimit: 5000,
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "rate limit must be between 0 (default) and 1000 events per second",
+		},
+		{
+			name: "should_error_for_app_in_another_group",
+			args: args{
+				ctx: ctx,
+				newJob: &models.ReplayJob{
+					AppID:     "app-1",
+					StartDate: start,
+					EndDate:   end,
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			dbFn: func(rs *ReplayJobService) {
+				a, _ := rs.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-1").Times(1).Return(&datastore.Application{UID: "app-1", GroupID: "abc"}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusUnauthorized,
+			wantErrMsg:  "app does not belong to group",
+		},
+		{
+			name: "should_fail_to_write_job_to_queue",
+			args: args{
+				ctx: ctx,
+				newJob: &models.ReplayJob{
+					StartDate: start,
+					EndDate:   end,
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			dbFn: func(rs *ReplayJobService) {
+				e, _ := rs.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CountEvents(gomock.Any(), gomock.Any()).Times(1).Return(int64(20), nil)
+
+				r, _ := rs.replayJobRepo.(*mocks.MockReplayJobRepository)
+				r.EXPECT().CreateReplayJob(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				q, _ := rs.queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.ReplayJobProcessor, convoy.DefaultQueue, gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to write replay job to queue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rs := provideReplayJobService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(rs)
+			}
+
+			job, err := rs.CreateReplayJob(tc.args.ctx, tc.args.newJob, tc.args.group)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.NotEmpty(t, job.UID)
+			require.Equal(t, tc.wantJob.GroupID, job.GroupID)
+			require.Equal(t, tc.wantJob.Status, job.Status)
+			require.Equal(t, tc.wantJob.RateLimit, job.RateLimit)
+			require.Equal(t, tc.wantJob.TotalEvents, job.TotalEvents)
+			require.Equal(t, tc.args.newJob.EventTypes, job.Filter.EventTypes)
+		})
+	}
+}
+
+func TestReplayJobService_CancelReplayJob(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		job         *datastore.ReplayJob
+		dbFn        func(rs *ReplayJobService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_cancel_running_job",
+			job:  &datastore.ReplayJob{UID: "1234", Status: datastore.RunningReplayJobStatus},
+			dbFn: func(rs *ReplayJobService) {
+				r, _ := rs.replayJobRepo.(*mocks.MockReplayJobRepository)
+				r.EXPECT().UpdateReplayJob(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:        "should_not_cancel_completed_job",
+			job:         &datastore.ReplayJob{UID: "1234", Status: datastore.CompletedReplayJobStatus},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "only pending or running replay jobs can be cancelled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rs := provideReplayJobService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(rs)
+			}
+
+			job, err := rs.CancelReplayJob(ctx, tc.job)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, datastore.CancelledReplayJobStatus, job.Status)
+			require.NotZero(t, job.CompletedAt)
+		})
+	}
+}
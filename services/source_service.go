@@ -3,12 +3,20 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"text/template"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/changestream"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/internal/pkg/restapi"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/verifier"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
@@ -35,6 +43,77 @@ func (s *SourceService) CreateSource(ctx context.Context, newSource *models.Sour
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for hmac"))
 	}
 
+	if err := validateHmacConfig(newSource.Verifier.HMac); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	var provider *verifier.Provider
+	if !util.IsStringEmpty(string(newSource.Provider)) {
+		var ok bool
+		provider, ok = verifier.GetProvider(string(newSource.Provider))
+		if !ok {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("unsupported source provider"))
+		}
+
+		if newSource.Verifier.HMac == nil || util.IsStringEmpty(newSource.Verifier.HMac.Secret) {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("provider sources require a secret"))
+		}
+	}
+
+	if _, err := verifier.NewIPAllowlist(newSource.IPAllowlist); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := httpheader.ValidatePatterns(newSource.ForwardHeaders); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if err := validateSourceResponse(newSource.Response, newSource.MaxBodySize); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if newSource.EventType != nil {
+		if _, err := verifier.NewEventType(newSource.EventType); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if err := validateSourceDeduplication(newSource.Deduplication, provider); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if newSource.Type == datastore.PubSubSource {
+		if err := pubsub.Validate(newSource.PubSub); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		setPubSubDefaults(newSource.PubSub)
+	}
+
+	if newSource.Type == datastore.DBChangeStream {
+		if err := changestream.Validate(newSource.DBChangeStream); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if newSource.Type == datastore.RestApiSource {
+		if err := restapi.Validate(newSource.RestApi); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		setRestApiDefaults(newSource.RestApi)
+	}
+
+	if newSource.RateLimit == 0 {
+		newSource.RateLimit = convoy.RATE_LIMIT
+	}
+
+	if util.IsStringEmpty(newSource.RateLimitDuration) {
+		newSource.RateLimitDuration = convoy.RATE_LIMIT_DURATION
+	}
+
+	if err := validateSourceRateLimit(newSource.RateLimit, newSource.RateLimitDuration); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	if newSource.Verifier.Type == datastore.APIKeyVerifier && newSource.Verifier.ApiKey == nil {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for api key"))
 	}
@@ -44,22 +123,48 @@ func (s *SourceService) CreateSource(ctx context.Context, newSource *models.Sour
 	}
 
 	source := &datastore.Source{
-		UID:            uuid.New().String(),
-		GroupID:        g.UID,
-		MaskID:         uniuri.NewLen(16),
-		Name:           newSource.Name,
-		Type:           newSource.Type,
-		Provider:       datastore.SourceProvider(newSource.Provider),
-		Verifier:       &newSource.Verifier,
-		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
-		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
-		DocumentStatus: datastore.ActiveDocumentStatus,
+		UID:               uuid.New().String(),
+		GroupID:           g.UID,
+		MaskID:            uniuri.NewLen(16),
+		Name:              newSource.Name,
+		Type:              newSource.Type,
+		Provider:          datastore.SourceProvider(newSource.Provider),
+		Verifier:          &newSource.Verifier,
+		EventType:         newSource.EventType,
+		Deduplication:     newSource.Deduplication,
+		Priority:          newSource.Priority,
+		RateLimit:         newSource.RateLimit,
+		RateLimitDuration: newSource.RateLimitDuration,
+		PubSub:            newSource.PubSub,
+		DBChangeStream:    newSource.DBChangeStream,
+		RestApi:           newSource.RestApi,
+		Response:          newSource.Response,
+		MaxBodySize:       newSource.MaxBodySize,
+		IPAllowlist:       newSource.IPAllowlist,
+		CreatedAt:         primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:         primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus:    datastore.ActiveDocumentStatus,
+	}
+
+	if newSource.ForwardHeaders != nil {
+		source.ForwardHeaders = newSource.ForwardHeaders
+	} else if provider != nil {
+		source.ForwardHeaders = provider.ForwardHeaders
+	}
+
+	if newSource.ProviderConfig != nil {
+		source.ProviderConfig = newSource.ProviderConfig
 	}
 
 	if source.Provider == datastore.TwitterSourceProvider {
 		source.ProviderConfig = &datastore.ProviderConfig{Twitter: &datastore.TwitterProviderConfig{}}
 	}
 
+	if source.Provider == datastore.MetaSourceProvider &&
+		(source.ProviderConfig == nil || source.ProviderConfig.Meta == nil || util.IsStringEmpty(source.ProviderConfig.Meta.VerifyToken)) {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("meta sources require a verify token"))
+	}
+
 	err := s.sourceRepo.CreateSource(ctx, source)
 	if err != nil {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to create source"))
@@ -85,6 +190,10 @@ func (s *SourceService) UpdateSource(ctx context.Context, g *datastore.Group, so
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for hmac"))
 	}
 
+	if err := validateHmacConfig(sourceUpdate.Verifier.HMac); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	if sourceUpdate.Verifier.Type == datastore.APIKeyVerifier && sourceUpdate.Verifier.ApiKey == nil {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for api key"))
 	}
@@ -94,14 +203,122 @@ func (s *SourceService) UpdateSource(ctx context.Context, g *datastore.Group, so
 	}
 
 	if sourceUpdate.ForwardHeaders != nil {
+		if err := httpheader.ValidatePatterns(sourceUpdate.ForwardHeaders); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
 		source.ForwardHeaders = sourceUpdate.ForwardHeaders
 	}
 
+	if sourceUpdate.IPAllowlist != nil {
+		if _, err := verifier.NewIPAllowlist(sourceUpdate.IPAllowlist); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		source.IPAllowlist = sourceUpdate.IPAllowlist
+	}
+
+	if sourceUpdate.Response != nil {
+		source.Response = sourceUpdate.Response
+	}
+
+	if sourceUpdate.MaxBodySize != nil {
+		source.MaxBodySize = *sourceUpdate.MaxBodySize
+	}
+
+	if sourceUpdate.Priority != nil {
+		if !sourceUpdate.Priority.IsValid() {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("unsupported event priority"))
+		}
+		source.Priority = *sourceUpdate.Priority
+	}
+
+	if err := validateSourceResponse(source.Response, source.MaxBodySize); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if sourceUpdate.EventType != nil {
+		if _, err := verifier.NewEventType(sourceUpdate.EventType); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		source.EventType = sourceUpdate.EventType
+	}
+
+	if sourceUpdate.Deduplication != nil {
+		provider, _ := verifier.GetProvider(string(source.Provider))
+		if err := validateSourceDeduplication(sourceUpdate.Deduplication, provider); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		source.Deduplication = sourceUpdate.Deduplication
+	}
+
+	if sourceUpdate.PubSub != nil {
+		source.PubSub = sourceUpdate.PubSub
+	}
+
+	if source.Type == datastore.PubSubSource {
+		if err := pubsub.Validate(source.PubSub); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		setPubSubDefaults(source.PubSub)
+	}
+
+	watchedCollectionChanged := false
+	if sourceUpdate.DBChangeStream != nil {
+		watchedCollectionChanged = source.DBChangeStream == nil ||
+			source.DBChangeStream.URI != sourceUpdate.DBChangeStream.URI ||
+			source.DBChangeStream.Database != sourceUpdate.DBChangeStream.Database ||
+			source.DBChangeStream.Collection != sourceUpdate.DBChangeStream.Collection
+		source.DBChangeStream = sourceUpdate.DBChangeStream
+	}
+
+	if source.Type == datastore.DBChangeStream {
+		if err := changestream.Validate(source.DBChangeStream); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if sourceUpdate.RestApi != nil {
+		source.RestApi = sourceUpdate.RestApi
+	}
+
+	if source.Type == datastore.RestApiSource {
+		if err := restapi.Validate(source.RestApi); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+		setRestApiDefaults(source.RestApi)
+	}
+
+	if sourceUpdate.RateLimit != nil {
+		source.RateLimit = *sourceUpdate.RateLimit
+	}
+
+	if sourceUpdate.RateLimitDuration != nil {
+		source.RateLimitDuration = *sourceUpdate.RateLimitDuration
+	}
+
+	if sourceUpdate.RateLimit != nil || sourceUpdate.RateLimitDuration != nil {
+		if util.IsStringEmpty(source.RateLimitDuration) {
+			source.RateLimitDuration = convoy.RATE_LIMIT_DURATION
+		}
+
+		if err := validateSourceRateLimit(source.RateLimit, source.RateLimitDuration); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
 	err := s.sourceRepo.UpdateSource(ctx, g.UID, source)
 	if err != nil {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating source"))
 	}
 
+	// resume tokens of one collection can't resume the changes of another.
+	if watchedCollectionChanged && source.Status != nil && !util.IsStringEmpty(source.Status.ResumeToken) {
+		source.Status.ResumeToken = ""
+		err = s.sourceRepo.UpdateSourceStatus(ctx, g.UID, source.UID, source.Status)
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating source"))
+		}
+	}
+
 	if source.Provider == datastore.TwitterSourceProvider {
 		sourceCacheKey := convoy.SourceCacheKey.Get(source.MaskID).String()
 		err = s.cache.Set(ctx, sourceCacheKey, &source, time.Hour*24)
@@ -141,6 +358,16 @@ func (s *SourceService) FindSourceByMaskID(ctx context.Context, maskID string) (
 	return source, nil
 }
 
+// RecordRejectedRequest counts a request the source's ip allowlist rejected.
+func (s *SourceService) RecordRejectedRequest(ctx context.Context, source *datastore.Source) error {
+	err := s.sourceRepo.IncrementRejectedRequests(ctx, source.GroupID, source.UID)
+	if err != nil {
+		return util.NewServiceError(http.StatusBadRequest, errors.New("failed to record rejected request"))
+	}
+
+	return nil
+}
+
 func (s *SourceService) LoadSourcesPaged(ctx context.Context, g *datastore.Group, filter *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
 	sources, paginationData, err := s.sourceRepo.LoadSourcesPaged(ctx, g.UID, filter, pageable)
 	if err != nil {
@@ -168,3 +395,102 @@ func (s *SourceService) DeleteSource(ctx context.Context, g *datastore.Group, so
 
 	return nil
 }
+
+func validateHmacConfig(hmac *datastore.HMac) error {
+	if hmac == nil {
+		return nil
+	}
+
+	if !util.IsStringEmpty(hmac.TimestampKey) && util.IsStringEmpty(hmac.SignatureKey) {
+		return errors.New("signature key is required for timestamped hmac signatures")
+	}
+
+	if hmac.Tolerance < 0 {
+		return errors.New("hmac tolerance cannot be negative")
+	}
+
+	return nil
+}
+
+func validateSourceResponse(response *datastore.SourceResponse, maxBodySize int64) error {
+	if maxBodySize < 0 {
+		return errors.New("max body size cannot be negative")
+	}
+
+	if maxBodySize > config.MaxSourceRequestSize {
+		return fmt.Errorf("max body size cannot exceed %d bytes", config.MaxSourceRequestSize)
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	if response.StatusCode != 0 && (response.StatusCode < 200 || response.StatusCode > 299) {
+		return errors.New("response status code must be a 2xx status code")
+	}
+
+	if _, err := template.New("response").Parse(response.Body); err != nil {
+		return fmt.Errorf("invalid response body template: %v", err)
+	}
+
+	return nil
+}
+
+func validateSourceDeduplication(dedup *datastore.SourceDeduplication, provider *verifier.Provider) error {
+	if dedup == nil {
+		return nil
+	}
+
+	if dedup.Window < 0 {
+		return errors.New("deduplication window cannot be negative")
+	}
+
+	if dedup.Window > config.MaxDeduplicationWindow {
+		return fmt.Errorf("deduplication window cannot exceed %d seconds", config.MaxDeduplicationWindow)
+	}
+
+	idempotencyKey, err := verifier.NewIdempotencyKey(dedup)
+	if err != nil {
+		return err
+	}
+
+	if idempotencyKey == nil && (provider == nil || provider.IdempotencyKey == nil) {
+		return errors.New("deduplication requires a header or json path")
+	}
+
+	return nil
+}
+
+// validateSourceRateLimit accepts the durations the rate limiter supports.
+func validateSourceRateLimit(rateLimit int, rateLimitDuration string) error {
+	if rateLimit <= 0 {
+		return errors.New("rate limit must be greater than zero")
+	}
+
+	duration, err := time.ParseDuration(rateLimitDuration)
+	if err != nil {
+		return fmt.Errorf("invalid rate limit duration: %s", rateLimitDuration)
+	}
+
+	if duration != time.Second && duration != time.Minute && duration != time.Hour {
+		return errors.New("rate limit duration must be one of 1s, 1m or 1h")
+	}
+
+	return nil
+}
+
+func setPubSubDefaults(cfg *datastore.PubSubConfig) {
+	if cfg.Workers == 0 {
+		cfg.Workers = 1
+	}
+
+	if cfg.Redis != nil && util.IsStringEmpty(cfg.Redis.ConsumerGroup) {
+		cfg.Redis.ConsumerGroup = pubsub.DefaultConsumerGroup
+	}
+}
+
+func setRestApiDefaults(cfg *datastore.RestApiConfig) {
+	if cfg.Interval == 0 {
+		cfg.Interval = restapi.DefaultInterval
+	}
+}
@@ -167,6 +167,348 @@ func TestSourceService_CreateSource(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "Invalid verifier config for hmac",
 		},
+		{
+			name: "should_fail_for_invalid_ip_allowlist",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					IPAllowlist: []string{"192.30.252.0/22", "192.30.252.0/40"},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid ip address or cidr: 192.30.252.0/40",
+		},
+		{
+			name: "should_fail_for_ambiguous_event_type",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					EventType: &datastore.SourceEventType{
+						Header:   "X-GitHub-Event",
+						JSONPath: "action",
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "event type requires exactly one of header, json path or template",
+		},
+		{
+			name: "should_fail_for_deduplication_without_key",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					Deduplication: &datastore.SourceDeduplication{Window: 3600},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "deduplication requires a header or json path",
+		},
+		{
+			name: "should_fail_for_too_long_deduplication_window",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					Deduplication: &datastore.SourceDeduplication{Header: "X-Request-Id", Window: 30 * 24 * 60 * 60},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "deduplication window cannot exceed 604800 seconds",
+		},
+		{
+			name: "should_fail_for_unsupported_rate_limit_duration",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					RateLimit:         100,
+					RateLimitDuration: "5m",
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "rate limit duration must be one of 1s, 1m or 1h",
+		},
+		{
+			name: "should_fail_for_negative_rate_limit",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					RateLimit: -1,
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "rate limit must be greater than zero",
+		},
+		{
+			name: "should_fail_for_pub_sub_source_without_config",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.PubSubSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "pub sub config is required for pub sub sources",
+		},
+		{
+			name: "should_fail_for_db_change_stream_source_without_collection",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.DBChangeStream,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					DBChangeStream: &datastore.DBChangeStreamConfig{
+						URI:      "mongodb://localhost:27017",
+						Database: "shop",
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "collection is required",
+		},
+		{
+			name: "should_fail_for_rest_api_source_with_short_interval",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.RestApiSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					RestApi: &datastore.RestApiConfig{
+						URL:         "https://api.example.com/items",
+						Interval:    10,
+						IDPath:      "id",
+						CursorParam: "since",
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "rest api interval cannot be less than 60 seconds",
+		},
+		{
+			name: "should_fail_for_invalid_forward_header_pattern",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					ForwardHeaders: []string{"X-GitHub-["},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid header pattern: X-GitHub-[",
+		},
+		{
+			name: "should_fail_for_non_2xx_response_status",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					Response: &datastore.SourceResponse{
+						StatusCode: http.StatusNotFound,
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "response status code must be a 2xx status code",
+		},
+		{
+			name: "should_fail_for_invalid_response_template",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					Response: &datastore.SourceResponse{
+						Body: `{"id": "{{ .EventID }"}`,
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid response body template: template: response:1: unexpected \"}\" in operand",
+		},
+		{
+			name: "should_fail_for_too_large_max_body_size",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.NoopVerifier,
+					},
+					MaxBodySize: 20 * 1024 * 1024,
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "max body size cannot exceed 10485760 bytes",
+		},
+		{
+			name: "should_fail_for_unsupported_provider",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name:     "Convoy-Prod",
+					Type:     datastore.HTTPSource,
+					Provider: "unknown",
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.HMacVerifier,
+						HMac: &datastore.HMac{
+							Encoding: datastore.HexEncoding,
+							Header:   "X-Convoy-Signature",
+							Hash:     "SHA256",
+							Secret:   "Convoy-Secret",
+						},
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "unsupported source provider",
+		},
+		{
+			name: "should_fail_timestamped_hmac_without_signature_key",
+			args: args{
+				ctx: ctx,
+				newSource: &models.Source{
+					Name: "Convoy-Prod",
+					Type: datastore.HTTPSource,
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.HMacVerifier,
+						HMac: &datastore.HMac{
+							Encoding:     datastore.HexEncoding,
+							Header:       "X-Convoy-Signature",
+							Hash:         "SHA256",
+							Secret:       "Convoy-Secret",
+							TimestampKey: "t",
+						},
+					},
+				},
+				group: &datastore.Group{
+					UID: "12345",
+				},
+			},
+			dbFn:        func(so *SourceService) {},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "signature key is required for timestamped hmac signatures",
+		},
 	}
 
 	for _, tc := range tests {
@@ -257,6 +599,60 @@ func TestSourceService_UpdateSource(t *testing.T) {
 			},
 		},
 
+		{
+			name: "should_reset_resume_token_when_watched_collection_changes",
+			args: args{
+				ctx: ctx,
+				source: &datastore.Source{
+					UID:  "12345",
+					Type: datastore.DBChangeStream,
+					DBChangeStream: &datastore.DBChangeStreamConfig{
+						URI:        "mongodb://localhost:27017",
+						Database:   "shop",
+						Collection: "users",
+					},
+					Status: &datastore.SourceStatus{ResumeToken: `{"_data":"8263"}`},
+				},
+				update: &models.UpdateSource{
+					Name: stringPtr("Convoy-Prod"),
+					Type: datastore.DBChangeStream,
+					DBChangeStream: &datastore.DBChangeStreamConfig{
+						URI:        "mongodb://localhost:27017",
+						Database:   "shop",
+						Collection: "orders",
+					},
+					Verifier: datastore.VerifierConfig{
+						Type: datastore.HMacVerifier,
+						HMac: &datastore.HMac{
+							Encoding: datastore.Base64Encoding,
+							Header:   "X-Convoy-Header",
+							Hash:     "SHA512",
+							Secret:   "Convoy-Secret",
+						},
+					},
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			wantSource: &datastore.Source{
+				Name: "Convoy-Prod",
+				Type: datastore.DBChangeStream,
+				Verifier: &datastore.VerifierConfig{
+					Type: datastore.HMacVerifier,
+					HMac: &datastore.HMac{
+						Encoding: datastore.Base64Encoding,
+						Header:   "X-Convoy-Header",
+						Hash:     "SHA512",
+						Secret:   "Convoy-Secret",
+					},
+				},
+			},
+			dbFn: func(so *SourceService) {
+				s, _ := so.sourceRepo.(*mocks.MockSourceRepository)
+				s.EXPECT().UpdateSource(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				s.EXPECT().UpdateSourceStatus(gomock.Any(), "12345", "12345", &datastore.SourceStatus{}).Times(1).Return(nil)
+			},
+		},
+
 		{
 			name: "should_fail_to_update_source",
 			args: args{
@@ -477,6 +873,57 @@ func TestSourceService_DeleteSource(t *testing.T) {
 
 }
 
+func TestSourceService_RecordRejectedRequest(t *testing.T) {
+	ctx := context.Background()
+	source := &datastore.Source{UID: "12345", GroupID: "abc"}
+
+	tests := []struct {
+		name        string
+		dbFn        func(so *SourceService)
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_record_rejected_request",
+			dbFn: func(so *SourceService) {
+				s, _ := so.sourceRepo.(*mocks.MockSourceRepository)
+				s.EXPECT().IncrementRejectedRequests(gomock.Any(), "abc", "12345").Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_fail_to_record_rejected_request",
+			dbFn: func(so *SourceService) {
+				s, _ := so.sourceRepo.(*mocks.MockSourceRepository)
+				s.EXPECT().IncrementRejectedRequests(gomock.Any(), "abc", "12345").Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "failed to record rejected request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			so := provideSourceService(ctrl)
+			tc.dbFn(so)
+
+			err := so.RecordRejectedRequest(ctx, source)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
+
 func TestSourceService_LoadSourcesPaged(t *testing.T) {
 	ctx := context.Background()
 
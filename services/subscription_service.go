@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
@@ -28,10 +31,11 @@ type SubcriptionService struct {
 	subRepo    datastore.SubscriptionRepository
 	appRepo    datastore.ApplicationRepository
 	sourceRepo datastore.SourceRepository
+	queue      queue.Queuer
 }
 
-func NewSubscriptionService(subRepo datastore.SubscriptionRepository, appRepo datastore.ApplicationRepository, sourceRepo datastore.SourceRepository) *SubcriptionService {
-	return &SubcriptionService{subRepo: subRepo, sourceRepo: sourceRepo, appRepo: appRepo}
+func NewSubscriptionService(subRepo datastore.SubscriptionRepository, appRepo datastore.ApplicationRepository, sourceRepo datastore.SourceRepository, queue queue.Queuer) *SubcriptionService {
+	return &SubcriptionService{subRepo: subRepo, sourceRepo: sourceRepo, appRepo: appRepo, queue: queue}
 }
 
 func (s *SubcriptionService) CreateSubscription(ctx context.Context, group *datastore.Group, newSubscription *models.Subscription) (*datastore.Subscription, error) {
@@ -77,6 +81,8 @@ func (s *SubcriptionService) CreateSubscription(ctx context.Context, group *data
 		AlertConfig:  newSubscription.AlertConfig,
 		FilterConfig: newSubscription.FilterConfig,
 
+		ForwardRawBody: newSubscription.ForwardRawBody,
+
 		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
 
@@ -164,6 +170,10 @@ func (s *SubcriptionService) UpdateSubscription(ctx context.Context, groupId str
 		subscription.FilterConfig.EventTypes = update.FilterConfig.EventTypes
 	}
 
+	if update.ForwardRawBody != nil {
+		subscription.ForwardRawBody = *update.ForwardRawBody
+	}
+
 	err = s.subRepo.UpdateSubscription(ctx, groupId, subscription)
 	if err != nil {
 		log.WithError(err).Error(ErrUpateSubscriptionError.Error())
@@ -187,6 +197,10 @@ func (s *SubcriptionService) ToggleSubscriptionStatus(ctx context.Context, group
 		subscription.Status = datastore.ActiveSubscriptionStatus
 	case datastore.PendingSubscriptionStatus:
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("subscription is in pending status"))
+	case datastore.PausedSubscriptionStatus:
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("subscription is paused, resume it instead"))
+	case datastore.ResumingSubscriptionStatus:
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("subscription is resuming"))
 	default:
 		return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf("unknown subscription status: %s", subscription.Status))
 	}
@@ -200,6 +214,84 @@ func (s *SubcriptionService) ToggleSubscriptionStatus(ctx context.Context, group
 	return subscription, nil
 }
 
+// PauseSubscription holds new deliveries to the subscription's endpoint
+// until it is resumed. Held deliveries do not consume retries.
+func (s *SubcriptionService) PauseSubscription(ctx context.Context, groupId string, subscriptionId string) (*datastore.Subscription, error) {
+	subscription, err := s.subRepo.FindSubscriptionByID(ctx, groupId, subscriptionId)
+	if err != nil {
+		log.WithError(err).Error(ErrSubscriptionNotFound.Error())
+		return nil, util.NewServiceError(http.StatusBadRequest, ErrSubscriptionNotFound)
+	}
+
+	if subscription.Status != datastore.ActiveSubscriptionStatus && subscription.Status != datastore.ResumingSubscriptionStatus {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("only active subscriptions can be paused"))
+	}
+
+	subscription.Status = datastore.PausedSubscriptionStatus
+	err = s.subRepo.UpdateSubscriptionStatus(ctx, groupId, subscription.UID, subscription.Status)
+	if err != nil {
+		log.WithError(err).Error("failed to update subscription status")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to update subscription status"))
+	}
+
+	return subscription, nil
+}
+
+// ResumeSubscription releases the deliveries held while a subscription was
+// paused, oldest first, at the given rate. The subscription is resuming
+// until they are all released, then it is active again.
+func (s *SubcriptionService) ResumeSubscription(ctx context.Context, groupId string, subscriptionId string, resume *models.ResumeSubscription) (*datastore.Subscription, error) {
+	if err := util.Validate(resume); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	if resume.Rate < 0 {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("please provide a valid rate"))
+	}
+
+	subscription, err := s.subRepo.FindSubscriptionByID(ctx, groupId, subscriptionId)
+	if err != nil {
+		log.WithError(err).Error(ErrSubscriptionNotFound.Error())
+		return nil, util.NewServiceError(http.StatusBadRequest, ErrSubscriptionNotFound)
+	}
+
+	if subscription.Status != datastore.PausedSubscriptionStatus {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("only paused subscriptions can be resumed"))
+	}
+
+	subscription.Status = datastore.ResumingSubscriptionStatus
+	err = s.subRepo.UpdateSubscriptionStatus(ctx, groupId, subscription.UID, subscription.Status)
+	if err != nil {
+		log.WithError(err).Error("failed to update subscription status")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to update subscription status"))
+	}
+
+	rate := resume.Rate
+	if rate == 0 {
+		rate = datastore.DefaultResumeRate
+	}
+
+	payload, err := json.Marshal(datastore.ResumeSubscriptionPayload{
+		GroupID:        groupId,
+		SubscriptionID: subscription.UID,
+		Rate:           rate,
+	})
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	err = s.queue.Write(convoy.ResumeSubscription, convoy.DefaultQueue, &queue.Job{
+		Payload: payload,
+		Delay:   0,
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to write resume subscription job to the queue")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to release held event deliveries"))
+	}
+
+	return subscription, nil
+}
+
 func (s *SubcriptionService) DeleteSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
 	err := s.subRepo.DeleteSubscription(ctx, groupId, subscription)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
@@ -18,7 +19,8 @@ func provideSubsctiptionService(ctrl *gomock.Controller) *SubcriptionService {
 	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
 	appRepo := mocks.NewMockApplicationRepository(ctrl)
 	sourceRepo := mocks.NewMockSourceRepository(ctrl)
-	return NewSubscriptionService(subRepo, appRepo, sourceRepo)
+	queue := mocks.NewMockQueuer(ctrl)
+	return NewSubscriptionService(subRepo, appRepo, sourceRepo, queue)
 }
 
 func TestSubscription_CreateSubscription(t *testing.T) {
@@ -948,3 +950,145 @@ func TestSubcriptionService_ToggleSubscriptionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSubcriptionService_PauseSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		dbFn        func(ss *SubcriptionService)
+		want        *datastore.Subscription
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_pause_active_subscription",
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc", Status: datastore.ActiveSubscriptionStatus}, nil)
+
+				s.EXPECT().UpdateSubscriptionStatus(gomock.Any(), "1234", "abc", datastore.PausedSubscriptionStatus).Times(1).Return(nil)
+			},
+			want: &datastore.Subscription{UID: "abc", Status: datastore.PausedSubscriptionStatus},
+		},
+		{
+			name: "should_pause_resuming_subscription",
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc", Status: datastore.ResumingSubscriptionStatus}, nil)
+
+				s.EXPECT().UpdateSubscriptionStatus(gomock.Any(), "1234", "abc", datastore.PausedSubscriptionStatus).Times(1).Return(nil)
+			},
+			want: &datastore.Subscription{UID: "abc", Status: datastore.PausedSubscriptionStatus},
+		},
+		{
+			name: "should_error_for_inactive_subscription",
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc", Status: datastore.InactiveSubscriptionStatus}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "only active subscriptions can be paused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ss := provideSubsctiptionService(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(ss)
+			}
+
+			got, err := ss.PauseSubscription(ctx, "1234", "abc")
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSubcriptionService_ResumeSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		resume      *models.ResumeSubscription
+		dbFn        func(ss *SubcriptionService)
+		want        *datastore.Subscription
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name:   "should_resume_paused_subscription",
+			resume: &models.ResumeSubscription{Rate: 20},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc", Status: datastore.PausedSubscriptionStatus}, nil)
+
+				s.EXPECT().UpdateSubscriptionStatus(gomock.Any(), "1234", "abc", datastore.ResumingSubscriptionStatus).Times(1).Return(nil)
+
+				q, _ := ss.queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.ResumeSubscription, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			want: &datastore.Subscription{UID: "abc", Status: datastore.ResumingSubscriptionStatus},
+		},
+		{
+			name:   "should_error_for_active_subscription",
+			resume: &models.ResumeSubscription{},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc", Status: datastore.ActiveSubscriptionStatus}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "only paused subscriptions can be resumed",
+		},
+		{
+			name:        "should_error_for_negative_rate",
+			resume:      &models.ResumeSubscription{Rate: -1},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide a valid rate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ss := provideSubsctiptionService(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(ss)
+			}
+
+			got, err := ss.ResumeSubscription(ctx, "1234", "abc", tt.resume)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
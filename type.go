@@ -67,19 +67,24 @@ func GetVersion() string {
 }
 
 const (
-	EventProcessor        TaskName = "EventProcessor"
-	DeadLetterProcessor   TaskName = "DeadLetterProcessor"
-	CreateEventProcessor  TaskName = "CreateEventProcessor"
-	NotificationProcessor TaskName = "NotificationProcessor"
-	IndexDocument         TaskName = "index document"
-	DailyAnalytics        TaskName = "daily analytics"
-	MonitorTwitterSources TaskName = "monitor twitter sources"
-	RetentionPolicies     TaskName = "retention_policies"
-	EmailProcessor        TaskName = "EmailProcessor"
-	ApplicationsCacheKey  CacheKey = "applications"
-	GroupsCacheKey        CacheKey = "groups"
-	TokenCacheKey         CacheKey = "tokens"
-	SourceCacheKey        CacheKey = "sources"
+	EventProcessor          TaskName = "EventProcessor"
+	DeadLetterProcessor     TaskName = "DeadLetterProcessor"
+	CreateEventProcessor    TaskName = "CreateEventProcessor"
+	NotificationProcessor   TaskName = "NotificationProcessor"
+	IndexDocument           TaskName = "index document"
+	DailyAnalytics          TaskName = "daily analytics"
+	MonitorSourceHandshakes TaskName = "monitor source handshakes"
+	RetentionPolicies       TaskName = "retention_policies"
+	EmailProcessor          TaskName = "EmailProcessor"
+	ReplayJobProcessor      TaskName = "ReplayJobProcessor"
+	ResumeSubscription      TaskName = "ResumeSubscription"
+	PollRestApiSources      TaskName = "poll rest api sources"
+	ApplicationsCacheKey    CacheKey = "applications"
+	GroupsCacheKey          CacheKey = "groups"
+	TokenCacheKey           CacheKey = "tokens"
+	SourceCacheKey          CacheKey = "sources"
+	IdempotencyKeysCacheKey CacheKey = "idempotency_keys"
+	SourceLeasesCacheKey    CacheKey = "source_leases"
 )
 
 // queues
@@ -89,6 +94,12 @@ const (
 	PriorityQueue    QueueName = "PriorityQueue"
 	ScheduleQueue    QueueName = "ScheduleQueue"
 	DefaultQueue     QueueName = "DefaultQueue"
+
+	// priority lanes, normal priority events use EventQueue and CreateEventQueue.
+	HighPriorityEventQueue       QueueName = "HighPriorityEventQueue"
+	HighPriorityCreateEventQueue QueueName = "HighPriorityCreateEventQueue"
+	LowPriorityEventQueue        QueueName = "LowPriorityEventQueue"
+	LowPriorityCreateEventQueue  QueueName = "LowPriorityCreateEventQueue"
 )
 
 // Exports dir
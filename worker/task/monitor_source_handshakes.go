@@ -0,0 +1,157 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/email"
+	"github.com/frain-dev/convoy/pkg/verifier"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+)
+
+// MonitorSourceHandshakes notifies the apps subscribed to sources whose
+// provider hasn't completed a challenge handshake, or hasn't repeated it
+// within the provider's handshake interval. The apps are notified once
+// until the provider completes the next handshake.
+func MonitorSourceHandshakes(sourceRepo datastore.SourceRepository, subRepo datastore.SubscriptionRepository, appRepo datastore.ApplicationRepository, queue queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		for _, name := range verifier.Providers() {
+			provider, _ := verifier.GetProvider(name)
+			if provider.NewCrc == nil {
+				continue
+			}
+
+			f := &datastore.SourceFilter{Provider: name}
+			for p := (datastore.Pageable{Page: 1, PerPage: 100}); ; p.Page++ {
+				sources, pagination, err := sourceRepo.LoadSourcesPaged(ctx, "", f, p)
+				if err != nil {
+					log.Error("Failed to load sources paged")
+					return err
+				}
+
+				for _, source := range sources {
+					if !isHandshakeOverdue(provider, source, time.Now()) {
+						continue
+					}
+
+					err = notifySourceApps(ctx, source, subRepo, appRepo, queue)
+					if err != nil {
+						return err
+					}
+
+					err = sourceRepo.UpdateHandshakeNotifiedAt(ctx, source.GroupID, source.UID, time.Now())
+					if err != nil {
+						log.WithError(err).Error("Failed to update source handshake notification time")
+						return err
+					}
+				}
+
+				if int64(p.Page) >= pagination.TotalPage {
+					break
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// isHandshakeOverdue reports whether a source created at least an hour ago
+// never completed a handshake or last completed it over the provider's
+// handshake interval ago, and its apps weren't notified since.
+func isHandshakeOverdue(provider *verifier.Provider, source datastore.Source, now time.Time) bool {
+	if now.Before(source.CreatedAt.Time().Add(time.Hour)) {
+		return false
+	}
+
+	lastHandshakeAt := lastHandshake(source)
+	if source.HandshakeNotifiedAt > lastHandshakeAt {
+		return false
+	}
+
+	if lastHandshakeAt == 0 {
+		return true
+	}
+
+	return provider.HandshakeInterval > 0 && now.Sub(lastHandshakeAt.Time()) > provider.HandshakeInterval
+}
+
+// lastHandshake falls back to the crc time twitter sources recorded before
+// handshakes were tracked for every provider.
+func lastHandshake(source datastore.Source) primitive.DateTime {
+	if source.LastHandshakeAt == 0 && source.ProviderConfig != nil && source.ProviderConfig.Twitter != nil {
+		return source.ProviderConfig.Twitter.CrcVerifiedAt
+	}
+
+	return source.LastHandshakeAt
+}
+
+func notifySourceApps(ctx context.Context, source datastore.Source, subRepo datastore.SubscriptionRepository, appRepo datastore.ApplicationRepository, q queue.Queuer) error {
+	subscriptions, err := subRepo.FindSubscriptionsBySourceIDs(ctx, source.GroupID, source.UID)
+	if err != nil {
+		log.Error("Failed to load source subscriptions")
+		return err
+	}
+
+	for _, s := range subscriptions {
+		app, err := appRepo.FindApplicationByID(ctx, s.AppID)
+		if err != nil {
+			log.Error("Failed to load subscription app")
+			return err
+		}
+
+		if !util.IsStringEmpty(app.SupportEmail) {
+			err = sendNotificationEmail(source, app, q)
+			if err != nil {
+				log.Error("failed to send notification")
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func sendNotificationEmail(source datastore.Source, app *datastore.Application, q queue.Queuer) error {
+	lastHandshakeAt := "never"
+	if t := lastHandshake(source); t != 0 {
+		lastHandshakeAt = t.Time().String()
+	}
+
+	em := email.Message{
+		Email:        app.SupportEmail,
+		Subject:      "Source Challenge Handshake",
+		TemplateName: email.TemplateSourceHandshake,
+		Params: map[string]string{
+			"last_handshake_at": lastHandshakeAt,
+			"provider":          string(source.Provider),
+			"source_name":       source.Name,
+		},
+	}
+
+	buf, err := json.Marshal(em)
+	if err != nil {
+		log.WithError(err).Error("failed to marshal notification payload")
+		return err
+	}
+
+	job := &queue.Job{
+		Payload: json.RawMessage(buf),
+		Delay:   0,
+	}
+
+	err = q.Write(convoy.NotificationProcessor, convoy.DefaultQueue, job)
+	if err != nil {
+		log.WithError(err).Error("failed to write new notification to the queue")
+		return err
+	}
+	return nil
+}
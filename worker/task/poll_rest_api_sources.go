@@ -0,0 +1,49 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/restapi"
+	"github.com/frain-dev/convoy/queue"
+)
+
+// PollRestApiSources polls the enabled rest_api sources whose interval
+// has passed since their last poll.
+func PollRestApiSources(sourceRepo datastore.SourceRepository, eventRepo datastore.EventRepository, queue queue.Queuer) func(context.Context, *asynq.Task) error {
+	poller := restapi.NewPoller(sourceRepo, eventRepo, queue)
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		f := &datastore.SourceFilter{Type: string(datastore.RestApiSource)}
+		for p := (datastore.Pageable{Page: 1, PerPage: 100}); ; p.Page++ {
+			sources, pagination, err := sourceRepo.LoadSourcesPaged(ctx, "", f, p)
+			if err != nil {
+				log.Error("Failed to load sources paged")
+				return err
+			}
+
+			for i := range sources {
+				source := &sources[i]
+				if source.IsDisabled || !restapi.IsDue(source, time.Now()) {
+					continue
+				}
+
+				// a failing source is recorded in its status and doesn't
+				// stop the others from being polled.
+				if err = poller.Poll(ctx, source); err != nil {
+					log.WithError(err).Errorf("failed to poll source %s", source.UID)
+				}
+			}
+
+			if int64(p.Page) >= pagination.TotalPage {
+				break
+			}
+		}
+
+		return nil
+	}
+}
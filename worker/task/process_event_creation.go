@@ -9,6 +9,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/google/uuid"
@@ -17,7 +18,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, groupRepo datastore.GroupRepository, eventDeliveryRepo datastore.EventDeliveryRepository, cache cache.Cache, eventQueue queue.Queuer, subRepo datastore.SubscriptionRepository, search searcher.Searcher) func(context.Context, *asynq.Task) error {
+func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo datastore.EventRepository, groupRepo datastore.GroupRepository, eventDeliveryRepo datastore.EventDeliveryRepository, cache cache.Cache, eventQueue queue.Queuer, subRepo datastore.SubscriptionRepository, search searcher.Searcher, payloads *claimcheck.Store) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 
 		var event datastore.Event
@@ -77,11 +78,19 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 
 			subscriptions = matchSubscriptions(string(event.EventType), subs)
 		} else if group.Type == datastore.IncomingGroup {
-			subscriptions, err = subRepo.FindSubscriptionsBySourceIDs(ctx, group.UID, event.SourceID)
+			subs, err := subRepo.FindSubscriptionsBySourceIDs(ctx, group.UID, event.SourceID)
 			if err != nil {
 				log.Errorf("error fetching subscriptions for this source %s", err)
 				return &EndpointError{Err: errors.New("error fetching subscriptions for this source"), delay: 10 * time.Second}
 			}
+
+			subscriptions = matchSourceSubscriptions(string(event.EventType), subs)
+		}
+
+		// the payload is kept in the database when it can't be offloaded.
+		err = payloads.Offload(ctx, &event)
+		if err != nil {
+			log.WithError(err).Error("failed to offload event payload")
 		}
 
 		event.MatchedEndpoints = len(subscriptions)
@@ -90,84 +99,105 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		intervalSeconds := group.Config.Strategy.Duration
-		retryLimit := group.Config.Strategy.RetryCount
+		err = createEventDeliveries(ctx, &event, group, subscriptions, "", appRepo, eventDeliveryRepo, eventQueue)
+		if err != nil {
+			return err
+		}
 
-		for _, s := range subscriptions {
-			app, err := appRepo.FindApplicationByID(ctx, s.AppID)
-			if err != nil {
-				log.Errorf("Error fetching applcation %s", err)
-				return &EndpointError{Err: err, delay: 10 * time.Second}
-			}
+		job := &queue.Job{
+			ID:      event.UID,
+			Payload: t.Payload(), // t.Payload() is the original event bytes
+			Delay:   5 * time.Second,
+		}
 
-			endpoint, err := appRepo.FindApplicationEndpointByID(ctx, app.UID, s.EndpointID)
-			if err != nil {
-				log.Errorf("Error fetching endpoint %s", err)
-				return &EndpointError{Err: err, delay: 10 * time.Second}
-			}
+		err = eventQueue.Write(convoy.IndexDocument, convoy.PriorityQueue, job)
+		if err != nil {
+			log.Errorf("[asynq]: an error occurred sending event to be indexed %s", err)
+		}
 
-			s.Endpoint = endpoint
+		return nil
+	}
+}
 
-			metadata := &datastore.Metadata{
-				NumTrials:       0,
-				RetryLimit:      retryLimit,
-				Data:            event.Data,
-				IntervalSeconds: intervalSeconds,
-				Strategy:        group.Config.Strategy.Type,
-				NextSendTime:    primitive.NewDateTimeFromTime(time.Now()),
-			}
+// createEventDeliveries creates an event delivery for every subscription
+// and enqueues the deliveries that were not discarded for dispatch. The
+// deliveries of a replay are tagged with the id of the replay job.
+func createEventDeliveries(ctx context.Context, event *datastore.Event, group *datastore.Group, subscriptions []datastore.Subscription, replayJobID string,
+	appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, eventQueue queue.Queuer) error {
+	intervalSeconds := group.Config.Strategy.Duration
+	retryLimit := group.Config.Strategy.RetryCount
 
-			eventDelivery := &datastore.EventDelivery{UID: uuid.New().String(),
-				SubscriptionID: s.UID,
-				AppID:          app.UID,
-				Metadata:       metadata,
-				GroupID:        group.UID,
-				EventID:        event.UID,
-				EndpointID:     s.EndpointID,
-				Headers:        event.Headers,
-
-				Status:           getEventDeliveryStatus(s, app),
-				DeliveryAttempts: []datastore.DeliveryAttempt{},
-				DocumentStatus:   datastore.ActiveDocumentStatus,
-				CreatedAt:        primitive.NewDateTimeFromTime(time.Now()),
-				UpdatedAt:        primitive.NewDateTimeFromTime(time.Now()),
-			}
+	for _, s := range subscriptions {
+		app, err := appRepo.FindApplicationByID(ctx, s.AppID)
+		if err != nil {
+			log.Errorf("Error fetching applcation %s", err)
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
 
-			err = eventDeliveryRepo.CreateEventDelivery(ctx, eventDelivery)
-			if err != nil {
-				log.WithError(err).Error("error occurred creating event delivery")
-				return &EndpointError{Err: err, delay: 10 * time.Second}
-			}
+		endpoint, err := appRepo.FindApplicationEndpointByID(ctx, app.UID, s.EndpointID)
+		if err != nil {
+			log.Errorf("Error fetching endpoint %s", err)
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
 
-			taskName := convoy.EventProcessor
-			if eventDelivery.Status != datastore.DiscardedEventStatus {
-				payload := json.RawMessage(eventDelivery.UID)
+		s.Endpoint = endpoint
 
-				job := &queue.Job{
-					ID:      eventDelivery.UID,
-					Payload: payload,
-					Delay:   1 * time.Second,
-				}
-				err = eventQueue.Write(taskName, convoy.EventQueue, job)
-				if err != nil {
-					log.Errorf("[asynq]: an error occurred sending event delivery to be dispatched %s", err)
-				}
-			}
+		metadata := &datastore.Metadata{
+			NumTrials:       0,
+			RetryLimit:      retryLimit,
+			Data:            event.Data,
+			DataRef:         event.DataRef,
+			IntervalSeconds: intervalSeconds,
+			Strategy:        group.Config.Strategy.Type,
+			NextSendTime:    primitive.NewDateTimeFromTime(time.Now()),
 		}
 
-		job := &queue.Job{
-			ID:      event.UID,
-			Payload: t.Payload(), // t.Payload() is the original event bytes
-			Delay:   5 * time.Second,
+		if s.ForwardRawBody && (len(event.Raw) > 0 || event.RawRef != "") {
+			metadata.Raw = event.Raw
+			metadata.RawRef = event.RawRef
+			metadata.ContentType = event.ContentType
 		}
 
-		err = eventQueue.Write(convoy.IndexDocument, convoy.PriorityQueue, job)
+		eventDelivery := &datastore.EventDelivery{UID: uuid.New().String(),
+			SubscriptionID: s.UID,
+			ReplayJobID:    replayJobID,
+			AppID:          app.UID,
+			Metadata:       metadata,
+			GroupID:        group.UID,
+			EventID:        event.UID,
+			EndpointID:     s.EndpointID,
+			Priority:       event.Priority,
+			Headers:        event.Headers,
+
+			Status:         getEventDeliveryStatus(s, app),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+			CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+			UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		}
+
+		err = eventDeliveryRepo.CreateEventDelivery(ctx, eventDelivery)
 		if err != nil {
-			log.Errorf("[asynq]: an error occurred sending event to be indexed %s", err)
+			log.WithError(err).Error("error occurred creating event delivery")
+			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		return nil
+		taskName := convoy.EventProcessor
+		if eventDelivery.Status == datastore.ScheduledEventStatus {
+			payload := json.RawMessage(eventDelivery.UID)
+
+			job := &queue.Job{
+				ID:      eventDelivery.UID,
+				Payload: payload,
+				Delay:   1 * time.Second,
+			}
+			err = eventQueue.Write(taskName, eventDelivery.Priority.EventQueue(), job)
+			if err != nil {
+				log.Errorf("[asynq]: an error occurred sending event delivery to be dispatched %s", err)
+			}
+		}
 	}
+
+	return nil
 }
 
 func matchSubscriptions(eventType string, subscriptions []datastore.Subscription) []datastore.Subscription {
@@ -183,8 +213,39 @@ func matchSubscriptions(eventType string, subscriptions []datastore.Subscription
 	return matched
 }
 
+// matchSourceSubscriptions matches the subscriptions of a source against the
+// event type, subscriptions without a filter receive every event.
+func matchSourceSubscriptions(eventType string, subscriptions []datastore.Subscription) []datastore.Subscription {
+	var matched []datastore.Subscription
+	for _, sub := range subscriptions {
+		if sub.FilterConfig == nil || len(sub.FilterConfig.EventTypes) == 0 {
+			matched = append(matched, sub)
+			continue
+		}
+
+		matched = append(matched, matchSubscriptions(eventType, []datastore.Subscription{sub})...)
+	}
+
+	return matched
+}
+
 func getEventDeliveryStatus(subscription datastore.Subscription, app *datastore.Application) datastore.EventDeliveryStatus {
-	if app.IsDisabled || subscription.Status != datastore.ActiveSubscriptionStatus {
+	if app.IsDisabled {
+		return datastore.DiscardedEventStatus
+	}
+
+	// deliveries to an endpoint waiting for verification are held until
+	// it is verified.
+	if subscription.Endpoint != nil && subscription.Endpoint.IsPending() {
+		return datastore.PausedEventStatus
+	}
+
+	// deliveries to a resuming subscription are held behind its backlog.
+	if subscription.Status == datastore.PausedSubscriptionStatus || subscription.Status == datastore.ResumingSubscriptionStatus {
+		return datastore.PausedEventStatus
+	}
+
+	if subscription.Status != datastore.ActiveSubscriptionStatus {
 		return datastore.DiscardedEventStatus
 	}
 
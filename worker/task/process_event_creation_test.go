@@ -11,6 +11,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/queue"
@@ -29,6 +30,7 @@ type args struct {
 	eventQueue        queue.Queuer
 	subRepo           datastore.SubscriptionRepository
 	search            searcher.Searcher
+	configRepo        datastore.ConfigurationRepository
 }
 
 func provideArgs(ctrl *gomock.Controller) *args {
@@ -40,6 +42,10 @@ func provideArgs(ctrl *gomock.Controller) *args {
 	subRepo := mocks.NewMockSubscriptionRepository(ctrl)
 	eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
 	search := mocks.NewMockSearcher(ctrl)
+	configRepo := mocks.NewMockConfigurationRepository(ctrl)
+
+	// no storage policy is configured, so payloads aren't offloaded.
+	configRepo.EXPECT().LoadConfiguration(gomock.Any()).Return(nil, datastore.ErrConfigNotFound).AnyTimes()
 
 	return &args{
 		appRepo:           appRepo,
@@ -50,6 +56,7 @@ func provideArgs(ctrl *gomock.Controller) *args {
 		eventQueue:        queue,
 		subRepo:           subRepo,
 		search:            search,
+		configRepo:        configRepo,
 	}
 }
 
@@ -213,6 +220,145 @@ func TestProcessEventCreated(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_forward_raw_body_for_incoming_group",
+			event: &datastore.Event{
+				UID:         uuid.NewString(),
+				EventType:   "*",
+				ProviderID:  uuid.NewString(),
+				SourceID:    "source-id-1",
+				GroupID:     "group-id-1",
+				AppID:       "app-id-1",
+				Data:        []byte(`{"Body":"Hello"}`),
+				Raw:         []byte(`Body=Hello`),
+				ContentType: "application/x-www-form-urlencoded",
+				CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+				UpdatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+			},
+			dbFn: func(args *args) {
+				mockCache, _ := args.cache.(*mocks.MockCache)
+				var gr *datastore.Group
+				mockCache.EXPECT().Get(gomock.Any(), "groups:group-id-1", &gr).Times(1).Return(nil)
+
+				group := &datastore.Group{
+					UID:  "group-id-1",
+					Type: datastore.IncomingGroup,
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       datastore.LinearStrategyProvider,
+							Duration:   10,
+							RetryCount: 3,
+						},
+					},
+				}
+
+				g, _ := args.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(
+					group,
+					nil,
+				)
+				mockCache.EXPECT().Set(gomock.Any(), "groups:group-id-1", group, 10*time.Minute).Times(1).Return(nil)
+
+				a, _ := args.appRepo.(*mocks.MockApplicationRepository)
+				app := &datastore.Application{UID: "app-id-1"}
+
+				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
+				subscriptions := []datastore.Subscription{
+					{
+						UID:        "456",
+						AppID:      "app-id-1",
+						EndpointID: "098",
+						Status:     datastore.ActiveSubscriptionStatus,
+						FilterConfig: &datastore.FilterConfiguration{
+							EventTypes: []string{"*"},
+						},
+						ForwardRawBody: true,
+					},
+				}
+				s.EXPECT().FindSubscriptionsBySourceIDs(gomock.Any(), "group-id-1", "source-id-1").Times(1).Return(subscriptions, nil)
+
+				e, _ := args.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").Times(1).Return(app, nil)
+
+				endpoint := &datastore.Endpoint{UID: "098", TargetURL: "https://google.com"}
+				a.EXPECT().FindApplicationEndpointByID(gomock.Any(), "app-id-1", "098").
+					Times(1).Return(endpoint, nil)
+
+				ed, _ := args.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, d *datastore.EventDelivery) error {
+						require.Equal(t, []byte(`Body=Hello`), d.Metadata.Raw)
+						require.Equal(t, "application/x-www-form-urlencoded", d.Metadata.ContentType)
+						return nil
+					})
+
+				q, _ := args.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+
+				q.EXPECT().Write(convoy.IndexDocument, convoy.PriorityQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "should_skip_incoming_subscriptions_for_other_event_types",
+			event: &datastore.Event{
+				UID:        uuid.NewString(),
+				EventType:  "push",
+				ProviderID: uuid.NewString(),
+				SourceID:   "source-id-1",
+				GroupID:    "group-id-1",
+				Data:       []byte(`{}`),
+				CreatedAt:  primitive.NewDateTimeFromTime(time.Now()),
+				UpdatedAt:  primitive.NewDateTimeFromTime(time.Now()),
+			},
+			dbFn: func(args *args) {
+				mockCache, _ := args.cache.(*mocks.MockCache)
+				var gr *datastore.Group
+				mockCache.EXPECT().Get(gomock.Any(), "groups:group-id-1", &gr).Times(1).Return(nil)
+
+				group := &datastore.Group{
+					UID:  "group-id-1",
+					Type: datastore.IncomingGroup,
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       datastore.LinearStrategyProvider,
+							Duration:   10,
+							RetryCount: 3,
+						},
+					},
+				}
+
+				g, _ := args.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(
+					group,
+					nil,
+				)
+				mockCache.EXPECT().Set(gomock.Any(), "groups:group-id-1", group, 10*time.Minute).Times(1).Return(nil)
+
+				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
+				subscriptions := []datastore.Subscription{
+					{
+						UID:        "456",
+						AppID:      "app-id-1",
+						EndpointID: "098",
+						Status:     datastore.ActiveSubscriptionStatus,
+						FilterConfig: &datastore.FilterConfiguration{
+							EventTypes: []string{"pull_request"},
+						},
+					},
+				}
+				s.EXPECT().FindSubscriptionsBySourceIDs(gomock.Any(), "group-id-1", "source-id-1").Times(1).Return(subscriptions, nil)
+
+				e, _ := args.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				q, _ := args.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.IndexDocument, convoy.PriorityQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -234,7 +380,7 @@ func TestProcessEventCreated(t *testing.T) {
 
 			task := asynq.NewTask(string(convoy.EventProcessor), job.Payload, asynq.Queue(string(convoy.EventQueue)), asynq.ProcessIn(job.Delay))
 
-			fn := ProcessEventCreation(args.appRepo, args.eventRepo, args.groupRepo, args.eventDeliveryRepo, args.cache, args.eventQueue, args.subRepo, args.search)
+			fn := ProcessEventCreation(args.appRepo, args.eventRepo, args.groupRepo, args.eventDeliveryRepo, args.cache, args.eventQueue, args.subRepo, args.search, claimcheck.NewStore(args.configRepo))
 			err = fn(context.Background(), task)
 			if tt.wantErr {
 				require.NotNil(t, err)
@@ -247,3 +393,96 @@ func TestProcessEventCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessEventCreated_HoldsDeliveriesToPendingEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	args := provideArgs(ctrl)
+
+	group := &datastore.Group{
+		UID:  "group-id-1",
+		Type: datastore.OutgoingGroup,
+		Config: &datastore.GroupConfig{
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       datastore.LinearStrategyProvider,
+				Duration:   10,
+				RetryCount: 3,
+			},
+		},
+	}
+
+	mockCache, _ := args.cache.(*mocks.MockCache)
+	mockCache.EXPECT().Get(gomock.Any(), "groups:group-id-1", gomock.Any()).Times(1).Return(nil)
+	mockCache.EXPECT().Set(gomock.Any(), "groups:group-id-1", group, 10*time.Minute).Times(1).Return(nil)
+	mockCache.EXPECT().Get(gomock.Any(), "applications:app-id-1", gomock.Any()).Times(1).Return(nil)
+
+	g, _ := args.groupRepo.(*mocks.MockGroupRepository)
+	g.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(group, nil)
+
+	app := &datastore.Application{UID: "app-id-1", GroupID: "group-id-1"}
+	a, _ := args.appRepo.(*mocks.MockApplicationRepository)
+	a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").Times(2).Return(app, nil)
+	mockCache.EXPECT().Set(gomock.Any(), "applications:app-id-1", app, 10*time.Minute).Times(1).Return(nil)
+
+	subscription := datastore.Subscription{
+		UID:          "456",
+		AppID:        "app-id-1",
+		EndpointID:   "098",
+		Status:       datastore.ActiveSubscriptionStatus,
+		FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"*"}},
+	}
+	s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
+	s.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group-id-1", "app-id-1").Times(1).Return([]datastore.Subscription{subscription}, nil)
+
+	e, _ := args.eventRepo.(*mocks.MockEventRepository)
+	e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	endpoint := &datastore.Endpoint{UID: "098", TargetURL: "https://google.com", Status: datastore.PendingEndpointStatus}
+	a.EXPECT().FindApplicationEndpointByID(gomock.Any(), "app-id-1", "098").Times(1).Return(endpoint, nil)
+
+	var held *datastore.EventDelivery
+	ed, _ := args.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+	ed.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, d *datastore.EventDelivery) error {
+			held = d
+			return nil
+		})
+
+	// the held delivery isn't dispatched.
+	q, _ := args.eventQueue.(*mocks.MockQueuer)
+	q.EXPECT().Write(convoy.IndexDocument, convoy.PriorityQueue, gomock.Any()).Times(1).Return(nil)
+
+	event := &datastore.Event{
+		UID:       uuid.NewString(),
+		EventType: "*",
+		GroupID:   "group-id-1",
+		AppID:     "app-id-1",
+		Data:      []byte(`{}`),
+	}
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	fn := ProcessEventCreation(args.appRepo, args.eventRepo, args.groupRepo, args.eventDeliveryRepo, args.cache, args.eventQueue, args.subRepo, args.search, claimcheck.NewStore(args.configRepo))
+	require.Nil(t, fn(context.Background(), asynq.NewTask(string(convoy.CreateEventProcessor), payload)))
+
+	require.NotNil(t, held)
+	require.Equal(t, datastore.PausedEventStatus, held.Status)
+
+	// verifying the endpoint releases the held delivery.
+	s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-id-1", "456").Times(1).Return(&subscription, nil)
+	ed.EXPECT().FindEventDeliveriesBySubscriptionID(gomock.Any(), "group-id-1", "456", datastore.PausedEventStatus, datastore.DefaultResumeRate).
+		Times(1).Return([]datastore.EventDelivery{*held}, nil)
+	ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), *held, datastore.ScheduledEventStatus).Times(1).Return(nil)
+	q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).
+		DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+			require.Equal(t, held.UID, job.ID)
+			return nil
+		})
+
+	payload, err = json.Marshal(datastore.ResumeSubscriptionPayload{GroupID: "group-id-1", SubscriptionID: "456", Rate: datastore.DefaultResumeRate})
+	require.NoError(t, err)
+
+	resume := ProcessSubscriptionResume(args.eventDeliveryRepo, args.subRepo, args.eventQueue)
+	require.Nil(t, resume(context.Background(), asynq.NewTask(string(convoy.ResumeSubscription), payload)))
+}
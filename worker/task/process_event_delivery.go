@@ -13,12 +13,12 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/notifications"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/retrystrategies"
 	"github.com/frain-dev/convoy/util"
-	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -33,7 +33,7 @@ type SignatureValues struct {
 	Timestamp string
 }
 
-func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, subRepo datastore.SubscriptionRepository, notificationQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, attemptRepo datastore.DeliveryAttemptRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, subRepo datastore.SubscriptionRepository, notificationQueue queue.Queuer, payloads *claimcheck.Store) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 		Id := string(t.Payload())
 
@@ -67,6 +67,47 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			return nil
 		}
 
+		// hold the delivery without consuming a retry, it is released
+		// when the subscription is resumed.
+		if subscription.Status == datastore.PausedSubscriptionStatus {
+			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(context.Background(), *ed, datastore.PausedEventStatus)
+			if err != nil {
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+
+			return nil
+		}
+
+		// deliveries to endpoints waiting for verification are held the
+		// same way, they are released when the endpoint is verified.
+		if endpoint.IsPending() {
+			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(context.Background(), *ed, datastore.PausedEventStatus)
+			if err != nil {
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+
+			return nil
+		}
+
+		// offloaded payloads are loaded for the request only, the
+		// delivery keeps the key.
+		data, raw := ed.Metadata.Data, ed.Metadata.Raw
+		if len(raw) == 0 && !util.IsStringEmpty(ed.Metadata.RawRef) {
+			raw, err = payloads.Load(ctx, ed.Metadata.RawRef)
+			if err != nil {
+				log.WithError(err).Errorf("failed to load offloaded raw body of event delivery %s", ed.UID)
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+		}
+
+		if len(raw) == 0 && !util.IsStringEmpty(ed.Metadata.DataRef) {
+			data, err = payloads.Load(ctx, ed.Metadata.DataRef)
+			if err != nil {
+				log.WithError(err).Errorf("failed to load offloaded payload of event delivery %s", ed.UID)
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+		}
+
 		var rateLimitDuration time.Duration
 		if util.IsStringEmpty(endpoint.RateLimitDuration) {
 			rateLimitDuration, err = time.ParseDuration(convoy.RATE_LIMIT_DURATION)
@@ -151,31 +192,28 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			return nil
 		}
 
-		buff := bytes.NewBuffer([]byte{})
-		encoder := json.NewEncoder(buff)
-		encoder.SetEscapeHTML(false)
-		if err := encoder.Encode(ed.Metadata.Data); err != nil {
-			log.WithError(err).Error("Failed to encode data")
-			return &EndpointError{Err: err, delay: delayDuration}
-		}
+		var bStr string
+		contentType := "application/json"
+		if len(raw) > 0 {
+			bStr, contentType = string(raw), ed.Metadata.ContentType
+		} else {
+			buff := bytes.NewBuffer([]byte{})
+			encoder := json.NewEncoder(buff)
+			encoder.SetEscapeHTML(false)
+			if err := encoder.Encode(data); err != nil {
+				log.WithError(err).Error("Failed to encode data")
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
 
-		bStr := strings.TrimSuffix(buff.String(), "\n")
+			bStr = strings.TrimSuffix(buff.String(), "\n")
+		}
 
 		g, err := groupRepo.FetchGroupByID(context.Background(), app.GroupID)
 		if err != nil {
 			log.WithError(err).Error("could not find error")
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
-		var signedPayload strings.Builder
-		var timestamp string
-		if g.Config.ReplayAttacks {
-			timestamp = fmt.Sprint(time.Now().Unix())
-			signedPayload.WriteString(timestamp)
-			signedPayload.WriteString(",")
-		}
-		signedPayload.WriteString(bStr)
-
-		hmac, err := util.ComputeJSONHmac(g.Config.Signature.Hash, signedPayload.String(), secret, false)
+		hmac, timestamp, err := net.SignPayload(g, secret, []byte(bStr))
 		if err != nil {
 			log.Errorf("error occurred while generating hmac - %+v\n", err)
 			return &EndpointError{Err: err, delay: delayDuration}
@@ -184,7 +222,7 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 		attemptStatus := false
 		start := time.Now()
 
-		resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), []byte(bStr), g, hmac, timestamp, int64(cfg.MaxResponseSize), ed.Headers)
+		resp, err := dispatch.SendRawRequest(e.TargetURL, string(convoy.HttpPost), []byte(bStr), contentType, g, hmac, timestamp, int64(cfg.MaxResponseSize), ed.Headers)
 		status := "-"
 		statusCode := 0
 		if resp != nil {
@@ -250,7 +288,11 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			}
 		}
 
-		attempt = parseAttemptFromResponse(ed, endpoint, resp, attemptStatus)
+		attempt = net.NewDeliveryAttempt(endpoint, resp, attemptStatus)
+		attempt.GroupID = ed.GroupID
+		attempt.AppID = ed.AppID
+		attempt.MsgID = ed.UID
+		attempt.DocumentStatus = datastore.ActiveDocumentStatus
 
 		ed.Metadata.NumTrials++
 
@@ -283,7 +325,12 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			}
 		}
 
-		err = eventDeliveryRepo.UpdateEventDeliveryWithAttempt(context.Background(), *ed, attempt)
+		err = attemptRepo.CreateDeliveryAttempt(context.Background(), &attempt)
+		if err != nil {
+			log.WithError(err).Error("failed to save delivery attempt ", ed.UID)
+		}
+
+		err = eventDeliveryRepo.UpdateEventDelivery(context.Background(), *ed)
 		if err != nil {
 			log.WithError(err).Error("failed to update message ", ed.UID)
 		}
@@ -295,29 +342,3 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 		return nil
 	}
 }
-func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.Endpoint, resp *net.Response, attemptStatus bool) datastore.DeliveryAttempt {
-
-	responseHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.ResponseHeader)
-	requestHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.RequestHeader)
-
-	return datastore.DeliveryAttempt{
-		ID:         primitive.NewObjectID(),
-		UID:        uuid.New().String(),
-		URL:        resp.URL.String(),
-		Method:     resp.Method,
-		MsgID:      m.UID,
-		EndpointID: e.UID,
-		APIVersion: "2021-08-27",
-
-		IPAddress:        resp.IP,
-		ResponseHeader:   *responseHeader,
-		RequestHeader:    *requestHeader,
-		HttpResponseCode: resp.Status,
-		ResponseData:     string(resp.Body),
-		Error:            resp.Error,
-		Status:           attemptStatus,
-
-		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
-		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
-	}
-}
@@ -0,0 +1,175 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProcessReplayJob replays one batch of a replay job's events and enqueues
+// the next batch. A batch holds job.RateLimit events and the next batch is
+// delayed so the job replays at most job.RateLimit events per second.
+// Progress is persisted after every batch, so a retried task resumes from
+// where the previous attempt stopped, and cancellation is observed between
+// batches. The deliveries of a replay are tagged with the job, so the
+// events of a batch that is retried aren't delivered twice.
+func ProcessReplayJob(replayJobRepo datastore.ReplayJobRepository, eventRepo datastore.EventRepository, groupRepo datastore.GroupRepository,
+	appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, subRepo datastore.SubscriptionRepository, eventQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload datastore.ReplayJob
+		err := json.Unmarshal(t.Payload(), &payload)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		job, err := replayJobRepo.FindReplayJobByID(ctx, payload.GroupID, payload.UID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrReplayJobNotFound) {
+				log.WithError(err).Errorf("replay job %s no longer exists", payload.UID)
+				return nil
+			}
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		if job.IsDone() {
+			return nil
+		}
+
+		group, err := groupRepo.FetchGroupByID(ctx, job.GroupID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrGroupNotFound) {
+				job.Status = datastore.FailedReplayJobStatus
+				job.Error = err.Error()
+				job.CompletedAt = primitive.NewDateTimeFromTime(time.Now())
+				return replayJobRepo.UpdateReplayJob(ctx, job)
+			}
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		if job.Status == datastore.PendingReplayJobStatus {
+			job.Status = datastore.RunningReplayJobStatus
+			job.StartedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+
+		batchSize := job.RateLimit
+		if batchSize <= 0 {
+			batchSize = datastore.DefaultReplayJobRateLimit
+		}
+
+		pageable := datastore.Pageable{
+			Page:    int(job.ProcessedEvents/int64(batchSize)) + 1,
+			PerPage: batchSize,
+			Sort:    1,
+		}
+
+		start := time.Now()
+		events, err := eventRepo.LoadEventsByFilter(ctx, job.Filter, pageable)
+		if err != nil {
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		for i := range events {
+			err = replayEvent(ctx, job, &events[i], group, appRepo, eventDeliveryRepo, subRepo, eventQueue)
+			if err != nil {
+				job.FailedEvents++
+				log.WithError(err).Errorf("replay job %s: failed to replay event %s", job.UID, events[i].UID)
+			}
+		}
+
+		job.ProcessedEvents += int64(len(events))
+		if len(events) < batchSize {
+			job.Status = datastore.CompletedReplayJobStatus
+			job.CompletedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+
+		// the job may have been cancelled while this batch was running.
+		latest, err := replayJobRepo.FindReplayJobByID(ctx, job.GroupID, job.UID)
+		if err == nil && latest.Status == datastore.CancelledReplayJobStatus {
+			job.Status = datastore.CancelledReplayJobStatus
+			job.CompletedAt = latest.CompletedAt
+		}
+
+		err = replayJobRepo.UpdateReplayJob(ctx, job)
+		if err != nil {
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		if job.IsDone() {
+			return nil
+		}
+
+		delay := time.Second - time.Since(start)
+		if delay < 0 {
+			delay = 0
+		}
+
+		next := &queue.Job{
+			ID:      fmt.Sprintf("%s:%d", job.UID, pageable.Page+1),
+			Payload: t.Payload(),
+			Delay:   delay,
+		}
+
+		err = eventQueue.Write(convoy.ReplayJobProcessor, convoy.DefaultQueue, next)
+		if err != nil {
+			log.WithError(err).Errorf("replay job %s: failed to enqueue next batch", job.UID)
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		return nil
+	}
+}
+
+// replayEvent creates new deliveries for an existing event using the
+// subscriptions that currently match it, skipping the subscriptions the
+// job already replayed the event to.
+func replayEvent(ctx context.Context, job *datastore.ReplayJob, event *datastore.Event, group *datastore.Group, appRepo datastore.ApplicationRepository,
+	eventDeliveryRepo datastore.EventDeliveryRepository, subRepo datastore.SubscriptionRepository, eventQueue queue.Queuer) error {
+	var subscriptions []datastore.Subscription
+
+	switch group.Type {
+	case datastore.OutgoingGroup:
+		subs, err := subRepo.FindSubscriptionsByAppID(ctx, group.UID, event.AppID)
+		if err != nil {
+			return err
+		}
+
+		subscriptions = matchSubscriptions(string(event.EventType), subs)
+	case datastore.IncomingGroup:
+		subs, err := subRepo.FindSubscriptionsBySourceIDs(ctx, group.UID, event.SourceID)
+		if err != nil {
+			return err
+		}
+
+		subscriptions = subs
+	}
+
+	deliveries, err := eventDeliveryRepo.FindEventDeliveriesByEventID(ctx, event.UID)
+	if err != nil {
+		return err
+	}
+
+	replayed := map[string]bool{}
+	for _, delivery := range deliveries {
+		if delivery.ReplayJobID == job.UID {
+			replayed[delivery.SubscriptionID] = true
+		}
+	}
+
+	pending := make([]datastore.Subscription, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		if !replayed[s.UID] {
+			pending = append(pending, s)
+		}
+	}
+
+	return createEventDeliveries(ctx, event, group, pending, job.UID, appRepo, eventDeliveryRepo, eventQueue)
+}
@@ -0,0 +1,249 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+type replayJobArgs struct {
+	replayJobRepo *mocks.MockReplayJobRepository
+	eventRepo     *mocks.MockEventRepository
+	groupRepo     *mocks.MockGroupRepository
+	appRepo       *mocks.MockApplicationRepository
+	eventDelivery *mocks.MockEventDeliveryRepository
+	subRepo       *mocks.MockSubscriptionRepository
+	queue         *mocks.MockQueuer
+}
+
+func provideReplayJobArgs(ctrl *gomock.Controller) *replayJobArgs {
+	return &replayJobArgs{
+		replayJobRepo: mocks.NewMockReplayJobRepository(ctrl),
+		eventRepo:     mocks.NewMockEventRepository(ctrl),
+		groupRepo:     mocks.NewMockGroupRepository(ctrl),
+		appRepo:       mocks.NewMockApplicationRepository(ctrl),
+		eventDelivery: mocks.NewMockEventDeliveryRepository(ctrl),
+		subRepo:       mocks.NewMockSubscriptionRepository(ctrl),
+		queue:         mocks.NewMockQueuer(ctrl),
+	}
+}
+
+func TestProcessReplayJob(t *testing.T) {
+	group := &datastore.Group{
+		UID:  "group-id-1",
+		Type: datastore.OutgoingGroup,
+		Config: &datastore.GroupConfig{
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       datastore.LinearStrategyProvider,
+				Duration:   10,
+				RetryCount: 3,
+			},
+		},
+	}
+
+	events := []datastore.Event{
+		{UID: "event-id-1", EventType: "payment.created", AppID: "app-id-1", GroupID: "group-id-1", Data: []byte(`{}`)},
+	}
+
+	expectReplay := func(args *replayJobArgs) {
+		subscriptions := []datastore.Subscription{
+			{
+				UID:        "456",
+				AppID:      "app-id-1",
+				EndpointID: "098",
+				Status:     datastore.ActiveSubscriptionStatus,
+				FilterConfig: &datastore.FilterConfiguration{
+					EventTypes: []string{"*"},
+				},
+			},
+		}
+		args.subRepo.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group-id-1", "app-id-1").Times(1).Return(subscriptions, nil)
+
+		app := &datastore.Application{UID: "app-id-1"}
+		args.appRepo.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").Times(1).Return(app, nil)
+		args.appRepo.EXPECT().FindApplicationEndpointByID(gomock.Any(), "app-id-1", "098").
+			Times(1).Return(&datastore.Endpoint{UID: "098", TargetURL: "https://google.com"}, nil)
+
+		args.eventDelivery.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "event-id-1").Times(1).Return(nil, nil)
+		args.eventDelivery.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, d *datastore.EventDelivery) error {
+				require.Equal(t, "job-id-1", d.ReplayJobID)
+				return nil
+			})
+		args.queue.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+	}
+
+	tests := []struct {
+		name       string
+		job        *datastore.ReplayJob
+		dbFn       func(args *replayJobArgs, job *datastore.ReplayJob)
+		wantStatus datastore.ReplayJobStatus
+		wantErr    bool
+		wantDelay  time.Duration
+	}{
+		{
+			name: "should_complete_job_on_last_batch",
+			job: &datastore.ReplayJob{
+				UID:       "job-id-1",
+				GroupID:   "group-id-1",
+				Filter:    &datastore.EventFilter{GroupID: "group-id-1"},
+				Status:    datastore.PendingReplayJobStatus,
+				RateLimit: 100,
+			},
+			dbFn: func(args *replayJobArgs, job *datastore.ReplayJob) {
+				args.replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), "group-id-1", "job-id-1").Times(2).Return(job, nil)
+				args.groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(group, nil)
+				args.eventRepo.EXPECT().LoadEventsByFilter(gomock.Any(), job.Filter, datastore.Pageable{Page: 1, PerPage: 100, Sort: 1}).
+					Times(1).Return(events, nil)
+
+				expectReplay(args)
+
+				args.replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, j *datastore.ReplayJob) error {
+						require.Equal(t, int64(1), j.ProcessedEvents)
+						require.Equal(t, datastore.CompletedReplayJobStatus, j.Status)
+						require.NotZero(t, j.StartedAt)
+						require.NotZero(t, j.CompletedAt)
+						return nil
+					})
+			},
+		},
+		{
+			name: "should_enqueue_next_batch",
+			job: &datastore.ReplayJob{
+				UID:             "job-id-1",
+				GroupID:         "group-id-1",
+				Filter:          &datastore.EventFilter{GroupID: "group-id-1"},
+				Status:          datastore.RunningReplayJobStatus,
+				RateLimit:       1,
+				ProcessedEvents: 2,
+			},
+			dbFn: func(args *replayJobArgs, job *datastore.ReplayJob) {
+				args.replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), "group-id-1", "job-id-1").Times(2).Return(job, nil)
+				args.groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(group, nil)
+				args.eventRepo.EXPECT().LoadEventsByFilter(gomock.Any(), job.Filter, datastore.Pageable{Page: 3, PerPage: 1, Sort: 1}).
+					Times(1).Return(events, nil)
+
+				expectReplay(args)
+
+				args.replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, j *datastore.ReplayJob) error {
+						require.Equal(t, int64(3), j.ProcessedEvents)
+						require.Equal(t, datastore.RunningReplayJobStatus, j.Status)
+						return nil
+					})
+
+				args.queue.EXPECT().Write(convoy.ReplayJobProcessor, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name: "should_skip_events_already_replayed_by_the_job",
+			job: &datastore.ReplayJob{
+				UID:       "job-id-1",
+				GroupID:   "group-id-1",
+				Filter:    &datastore.EventFilter{GroupID: "group-id-1"},
+				Status:    datastore.RunningReplayJobStatus,
+				RateLimit: 100,
+			},
+			dbFn: func(args *replayJobArgs, job *datastore.ReplayJob) {
+				args.replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), "group-id-1", "job-id-1").Times(2).Return(job, nil)
+				args.groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(group, nil)
+				args.eventRepo.EXPECT().LoadEventsByFilter(gomock.Any(), job.Filter, datastore.Pageable{Page: 1, PerPage: 100, Sort: 1}).
+					Times(1).Return(events, nil)
+
+				subscriptions := []datastore.Subscription{
+					{
+						UID:          "456",
+						AppID:        "app-id-1",
+						EndpointID:   "098",
+						Status:       datastore.ActiveSubscriptionStatus,
+						FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"*"}},
+					},
+				}
+				args.subRepo.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group-id-1", "app-id-1").Times(1).Return(subscriptions, nil)
+
+				// the previous attempt replayed the event before it failed
+				// to save its progress.
+				deliveries := []datastore.EventDelivery{
+					{UID: "delivery-id-1", EventID: "event-id-1", SubscriptionID: "456"},
+					{UID: "delivery-id-2", EventID: "event-id-1", SubscriptionID: "456", ReplayJobID: "job-id-1"},
+				}
+				args.eventDelivery.EXPECT().FindEventDeliveriesByEventID(gomock.Any(), "event-id-1").Times(1).Return(deliveries, nil)
+				args.eventDelivery.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(0)
+
+				args.replayJobRepo.EXPECT().UpdateReplayJob(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, j *datastore.ReplayJob) error {
+						require.Equal(t, int64(1), j.ProcessedEvents)
+						require.Equal(t, int64(0), j.FailedEvents)
+						return nil
+					})
+			},
+		},
+		{
+			name: "should_stop_cancelled_job",
+			job: &datastore.ReplayJob{
+				UID:     "job-id-1",
+				GroupID: "group-id-1",
+				Status:  datastore.CancelledReplayJobStatus,
+			},
+			dbFn: func(args *replayJobArgs, job *datastore.ReplayJob) {
+				args.replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), "group-id-1", "job-id-1").Times(1).Return(job, nil)
+			},
+		},
+		{
+			name: "should_retry_when_events_fail_to_load",
+			job: &datastore.ReplayJob{
+				UID:       "job-id-1",
+				GroupID:   "group-id-1",
+				Filter:    &datastore.EventFilter{GroupID: "group-id-1"},
+				Status:    datastore.RunningReplayJobStatus,
+				RateLimit: 100,
+			},
+			dbFn: func(args *replayJobArgs, job *datastore.ReplayJob) {
+				args.replayJobRepo.EXPECT().FindReplayJobByID(gomock.Any(), "group-id-1", "job-id-1").Times(1).Return(job, nil)
+				args.groupRepo.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(group, nil)
+				args.eventRepo.EXPECT().LoadEventsByFilter(gomock.Any(), job.Filter, gomock.Any()).
+					Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:   true,
+			wantDelay: 10 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			args := provideReplayJobArgs(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(args, tt.job)
+			}
+
+			payload, err := json.Marshal(tt.job)
+			require.NoError(t, err)
+
+			task := asynq.NewTask(string(convoy.ReplayJobProcessor), payload, asynq.Queue(string(convoy.DefaultQueue)))
+
+			fn := ProcessReplayJob(args.replayJobRepo, args.eventRepo, args.groupRepo, args.appRepo, args.eventDelivery, args.subRepo, args.queue)
+			err = fn(context.Background(), task)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantDelay, err.(*EndpointError).Delay())
+				return
+			}
+
+			require.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+)
+
+// ProcessSubscriptionResume releases deliveries held while a subscription
+// was paused. Deliveries are released oldest first, Rate per second; each
+// run releases one batch and enqueues itself for the next one. A resuming
+// subscription is set to active once its backlog is released, and one more
+// run releases the deliveries held while it was being set.
+func ProcessSubscriptionResume(eventDeliveryRepo datastore.EventDeliveryRepository, subRepo datastore.SubscriptionRepository, eventQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var payload datastore.ResumeSubscriptionPayload
+		err := json.Unmarshal(t.Payload(), &payload)
+		if err != nil {
+			return &EndpointError{Err: err, delay: defaultDelay}
+		}
+
+		subscription, err := subRepo.FindSubscriptionByID(ctx, payload.GroupID, payload.SubscriptionID)
+		if err != nil {
+			if errors.Is(err, datastore.ErrSubscriptionNotFound) {
+				return nil
+			}
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		// the subscription was paused again, the next resume picks up from here.
+		if subscription.Status == datastore.PausedSubscriptionStatus {
+			return nil
+		}
+
+		rate := payload.Rate
+		if rate <= 0 {
+			rate = datastore.DefaultResumeRate
+		}
+
+		deliveries, err := eventDeliveryRepo.FindEventDeliveriesBySubscriptionID(ctx, payload.GroupID, payload.SubscriptionID, datastore.PausedEventStatus, rate)
+		if err != nil {
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		for i, delivery := range deliveries {
+			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(ctx, delivery, datastore.ScheduledEventStatus)
+			if err != nil {
+				log.WithError(err).Error("failed to update status of held event delivery")
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+
+			// spread the batch across the second so deliveries go out in order.
+			job := &queue.Job{
+				ID:      delivery.UID,
+				Payload: json.RawMessage(delivery.UID),
+				Delay:   time.Duration(i) * time.Second / time.Duration(rate),
+			}
+
+			err = eventQueue.Write(convoy.EventProcessor, delivery.Priority.EventQueue(), job)
+			if err != nil {
+				log.Errorf("[asynq]: an error occurred sending event delivery to be dispatched %s", err)
+			}
+		}
+
+		if len(deliveries) < rate {
+			if subscription.Status != datastore.ResumingSubscriptionStatus {
+				return nil
+			}
+
+			err = subRepo.UpdateSubscriptionStatus(ctx, payload.GroupID, subscription.UID, datastore.ActiveSubscriptionStatus)
+			if err != nil {
+				log.WithError(err).Error("failed to update subscription status")
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+		}
+
+		err = eventQueue.Write(convoy.ResumeSubscription, convoy.DefaultQueue, &queue.Job{
+			Payload: t.Payload(),
+			Delay:   time.Second,
+		})
+		if err != nil {
+			log.WithError(err).Error("failed to enqueue next batch of held event deliveries")
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		return nil
+	}
+}
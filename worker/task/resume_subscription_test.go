@@ -0,0 +1,116 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessSubscriptionResume(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload datastore.ResumeSubscriptionPayload
+		dbFn    func(ed *mocks.MockEventDeliveryRepository, s *mocks.MockSubscriptionRepository, q *mocks.MockQueuer)
+	}{
+		{
+			name:    "should_release_last_batch",
+			payload: datastore.ResumeSubscriptionPayload{GroupID: "group-id-1", SubscriptionID: "sub-id-1", Rate: 10},
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, s *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-id-1", "sub-id-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-id-1", Status: datastore.ActiveSubscriptionStatus}, nil)
+
+				deliveries := []datastore.EventDelivery{{UID: "ed-1"}, {UID: "ed-2"}}
+				ed.EXPECT().FindEventDeliveriesBySubscriptionID(gomock.Any(), "group-id-1", "sub-id-1", datastore.PausedEventStatus, 10).
+					Times(1).Return(deliveries, nil)
+
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus).Times(2).Return(nil)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(2).Return(nil)
+			},
+		},
+		{
+			name:    "should_enqueue_next_batch",
+			payload: datastore.ResumeSubscriptionPayload{GroupID: "group-id-1", SubscriptionID: "sub-id-1", Rate: 1},
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, s *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-id-1", "sub-id-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-id-1", Status: datastore.ActiveSubscriptionStatus}, nil)
+
+				ed.EXPECT().FindEventDeliveriesBySubscriptionID(gomock.Any(), "group-id-1", "sub-id-1", datastore.PausedEventStatus, 1).
+					Times(1).Return([]datastore.EventDelivery{{UID: "ed-1"}}, nil)
+
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.ResumeSubscription, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:    "should_activate_resuming_subscription_once_drained",
+			payload: datastore.ResumeSubscriptionPayload{GroupID: "group-id-1", SubscriptionID: "sub-id-1", Rate: 10},
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, s *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-id-1", "sub-id-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-id-1", Status: datastore.ResumingSubscriptionStatus}, nil)
+
+				ed.EXPECT().FindEventDeliveriesBySubscriptionID(gomock.Any(), "group-id-1", "sub-id-1", datastore.PausedEventStatus, 10).
+					Times(1).Return([]datastore.EventDelivery{{UID: "ed-1"}}, nil)
+
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+
+				s.EXPECT().UpdateSubscriptionStatus(gomock.Any(), "group-id-1", "sub-id-1", datastore.ActiveSubscriptionStatus).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.ResumeSubscription, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:    "should_keep_resuming_subscription_while_draining",
+			payload: datastore.ResumeSubscriptionPayload{GroupID: "group-id-1", SubscriptionID: "sub-id-1", Rate: 1},
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, s *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-id-1", "sub-id-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-id-1", Status: datastore.ResumingSubscriptionStatus}, nil)
+
+				ed.EXPECT().FindEventDeliveriesBySubscriptionID(gomock.Any(), "group-id-1", "sub-id-1", datastore.PausedEventStatus, 1).
+					Times(1).Return([]datastore.EventDelivery{{UID: "ed-1"}}, nil)
+
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus).Times(1).Return(nil)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+
+				s.EXPECT().UpdateSubscriptionStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				q.EXPECT().Write(convoy.ResumeSubscription, convoy.DefaultQueue, gomock.Any()).Times(1).Return(nil)
+			},
+		},
+		{
+			name:    "should_stop_when_paused_again",
+			payload: datastore.ResumeSubscriptionPayload{GroupID: "group-id-1", SubscriptionID: "sub-id-1", Rate: 10},
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, s *mocks.MockSubscriptionRepository, q *mocks.MockQueuer) {
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-id-1", "sub-id-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-id-1", Status: datastore.PausedSubscriptionStatus}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			subRepo := mocks.NewMockSubscriptionRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+
+			tt.dbFn(eventDeliveryRepo, subRepo, q)
+
+			payload, err := json.Marshal(tt.payload)
+			require.NoError(t, err)
+
+			task := asynq.NewTask(string(convoy.ResumeSubscription), payload, asynq.Queue(string(convoy.DefaultQueue)))
+
+			fn := ProcessSubscriptionResume(eventDeliveryRepo, subRepo, q)
+			require.Nil(t, fn(context.Background(), task))
+		})
+	}
+}
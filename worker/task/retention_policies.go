@@ -10,14 +10,21 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	objectstore "github.com/frain-dev/convoy/datastore/object-store"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	"github.com/frain-dev/convoy/util"
 	"github.com/hibiken/asynq"
 	log "github.com/sirupsen/logrus"
 )
 
-func RententionPolicies(instanceConfig config.Configuration, configRepo datastore.ConfigurationRepository, groupRepo datastore.GroupRepository, eventRepo datastore.EventRepository, eventDeliveriesRepo datastore.EventDeliveryRepository, searcher searcher.Searcher) func(context.Context, *asynq.Task) error {
+func RententionPolicies(instanceConfig config.Configuration, configRepo datastore.ConfigurationRepository, groupRepo datastore.GroupRepository, eventRepo datastore.EventRepository, eventDeliveriesRepo datastore.EventDeliveryRepository, attemptRepo datastore.DeliveryAttemptRepository, searcher searcher.Searcher, payloads *claimcheck.Store) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
+		// documents are exported with mongoexport before they are deleted.
+		if instanceConfig.Database.Type != config.MongodbDatabaseProvider {
+			log.Warn("retention policies are only supported on mongodb, skipping")
+			return nil
+		}
+
 		config, err := configRepo.LoadConfiguration(ctx)
 		if err != nil {
 			if errors.Is(err, datastore.ErrConfigNotFound) {
@@ -25,7 +32,7 @@ func RententionPolicies(instanceConfig config.Configuration, configRepo datastor
 			}
 			return err
 		}
-		collections := []string{"events", "eventdeliveries"}
+		collections := []string{"events", "eventdeliveries", "deliveryattempts"}
 
 		objectStoreClient, exportDir, err := NewObjectStoreClient(config)
 		if err != nil {
@@ -49,20 +56,123 @@ func RententionPolicies(instanceConfig config.Configuration, configRepo datastor
 					return err
 				}
 				expDate := time.Now().UTC().Add(-policy)
+
+				// the offloaded payloads are written back into the documents
+				// before they are exported, and deleted once the documents
+				// referencing them are.
+				refs, err := restorePayloads(ctx, eventRepo, eventDeliveriesRepo, payloads, g, expDate)
+				if err != nil {
+					log.WithError(err).Error("failed to restore offloaded payloads")
+					return err
+				}
+
 				uri := instanceConfig.Database.Dsn
 				for _, collection := range collections {
-					err = ExportCollection(ctx, collection, uri, exportDir, expDate, objectStoreClient, g, eventRepo, eventDeliveriesRepo, groupRepo, searcher)
+					err = ExportCollection(ctx, collection, uri, exportDir, expDate, objectStoreClient, g, eventRepo, eventDeliveriesRepo, attemptRepo, groupRepo, searcher)
 					if err != nil {
 						log.WithError(err).Errorf("Error exporting collection %v", collection)
 						return err
 					}
 				}
+
+				err = payloads.Delete(ctx, refs...)
+				if err != nil {
+					log.WithError(err).Error("failed to delete offloaded payloads")
+					return err
+				}
 			}
 		}
 		return nil
 	}
 }
 
+// restorePayloads writes the payloads offloaded by the events of group
+// created before expDate, and by their deliveries, back into them so the
+// exported documents carry their payloads. It returns the references of
+// the restored payloads.
+func restorePayloads(ctx context.Context, eventRepo datastore.EventRepository, eventDeliveriesRepo datastore.EventDeliveryRepository, payloads *claimcheck.Store, group *datastore.Group, expDate time.Time) ([]string, error) {
+	filter := &datastore.EventFilter{
+		GroupID:        group.UID,
+		CreatedAtStart: 0,
+		CreatedAtEnd:   expDate.Unix(),
+	}
+
+	// deliveries share the payloads of their events.
+	var refs []string
+	seen := map[string]bool{}
+	addRefs := func(newRefs ...string) {
+		for _, ref := range newRefs {
+			if !util.IsStringEmpty(ref) && !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	for p := (datastore.Pageable{Page: 1, PerPage: 1000, Sort: 1}); ; p.Page++ {
+		events, err := eventRepo.LoadEventsByFilter(ctx, filter, p)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range events {
+			e := &events[i]
+			if util.IsStringEmpty(e.DataRef) && util.IsStringEmpty(e.RawRef) {
+				continue
+			}
+
+			err = payloads.LoadEvent(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+
+			addRefs(e.DataRef, e.RawRef)
+			e.DataRef, e.RawRef = "", ""
+
+			err = eventRepo.UpdateEventPayload(ctx, e)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(events) < p.PerPage {
+			break
+		}
+	}
+
+	searchParams := datastore.SearchParams{CreatedAtStart: 0, CreatedAtEnd: expDate.Unix()}
+	for p := (datastore.Pageable{Page: 1, PerPage: 1000, Sort: 1, SkipTotal: true}); ; p.Page++ {
+		deliveries, _, err := eventDeliveriesRepo.LoadEventDeliveriesPaged(ctx, group.UID, "", "", nil, searchParams, p)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range deliveries {
+			d := &deliveries[i]
+			if d.Metadata == nil || (util.IsStringEmpty(d.Metadata.DataRef) && util.IsStringEmpty(d.Metadata.RawRef)) {
+				continue
+			}
+
+			err = payloads.LoadEventDelivery(ctx, d)
+			if err != nil {
+				return nil, err
+			}
+
+			addRefs(d.Metadata.DataRef, d.Metadata.RawRef)
+			d.Metadata.DataRef, d.Metadata.RawRef = "", ""
+
+			err = eventDeliveriesRepo.UpdateEventDelivery(ctx, *d)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if len(deliveries) < p.PerPage {
+			return refs, nil
+		}
+	}
+}
+
 func NewObjectStoreClient(config *datastore.Configuration) (objectstore.ObjectStore, string, error) {
 	switch config.StoragePolicy.Type {
 	case datastore.S3:
@@ -108,12 +218,18 @@ func GetArgsByCollection(collection string, uri string, exportDir string, expDat
 		out := fmt.Sprint(exportDir, group.OrganisationID, "/", group.UID, "/", "eventdeliveries/", time.Now().UTC().Format(time.RFC3339), "/", "event_deliveries.json") //<org-id>/<project-id>/events/<today-as-ISODateTime>
 		args := util.MongoExportArgsBuilder(uri, collection, query, out)
 		return args, out, nil
+
+	case "deliveryattempts":
+		query := fmt.Sprintf(`{ "group_id": "%s", "document_status": "Active", "created_at": { "$lt": { "$date": "%s" }}}`, group.UID, fmt.Sprint(expDate.Format(time.RFC3339)))
+		out := fmt.Sprint(exportDir, group.OrganisationID, "/", group.UID, "/", "deliveryattempts/", time.Now().UTC().Format(time.RFC3339), "/", "delivery_attempts.json") //<org-id>/<project-id>/deliveryattempts/<today-as-ISODateTime>
+		args := util.MongoExportArgsBuilder(uri, collection, query, out)
+		return args, out, nil
 	default:
 		return nil, "", errors.New("invalid collection")
 	}
 }
 
-func ExportCollection(ctx context.Context, collection string, uri string, exportDir string, expDate time.Time, objectStoreClient objectstore.ObjectStore, group *datastore.Group, eventRepo datastore.EventRepository, eventDeliveriesRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, searcher searcher.Searcher) error {
+func ExportCollection(ctx context.Context, collection string, uri string, exportDir string, expDate time.Time, objectStoreClient objectstore.ObjectStore, group *datastore.Group, eventRepo datastore.EventRepository, eventDeliveriesRepo datastore.EventDeliveryRepository, attemptRepo datastore.DeliveryAttemptRepository, groupRepo datastore.GroupRepository, searcher searcher.Searcher) error {
 	args, out, err := GetArgsByCollection(collection, uri, exportDir, expDate, group)
 	if err != nil {
 		return err
@@ -146,9 +262,12 @@ func ExportCollection(ctx context.Context, collection string, uri string, export
 			SearchParams: f.SearchParams,
 		}}
 
-		err = searcher.Remove(collection, sf)
-		if err != nil {
-			return err
+		// delivery attempts aren't indexed by the searcher.
+		if collection != "deliveryattempts" {
+			err = searcher.Remove(collection, sf)
+			if err != nil {
+				return err
+			}
 		}
 
 		switch collection {
@@ -187,6 +306,17 @@ func ExportCollection(ctx context.Context, collection string, uri string, export
 			if err != nil {
 				return err
 			}
+
+		case "deliveryattempts":
+			attemptFilter := &datastore.DeliveryAttemptFilter{
+				GroupID:        group.UID,
+				CreatedAtStart: 0,
+				CreatedAtEnd:   expDate.Unix(),
+			}
+			err = attemptRepo.DeleteGroupDeliveryAttempts(ctx, attemptFilter, true)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -5,8 +5,10 @@ package task
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	convoyMongo "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/internal/pkg/claimcheck"
 
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
 	noopsearcher "github.com/frain-dev/convoy/internal/pkg/searcher/noop"
@@ -93,7 +96,7 @@ func (r *RetentionPoliciesIntegrationTestSuite) Test_Should_Export_Two_Documents
 	//call handler
 	task := asynq.NewTask(string(convoy.TaskName("retention-policies")), nil, asynq.Queue(string(convoy.ScheduleQueue)))
 
-	fn := RententionPolicies(getConfig(), r.ConvoyApp.configRepo, r.ConvoyApp.groupRepo, r.ConvoyApp.eventRepo, r.ConvoyApp.eventDeliveryRepo, r.ConvoyApp.searcher)
+	fn := RententionPolicies(getConfig(), r.ConvoyApp.configRepo, r.ConvoyApp.groupRepo, r.ConvoyApp.eventRepo, r.ConvoyApp.eventDeliveryRepo, r.ConvoyApp.attemptRepo, r.ConvoyApp.searcher, claimcheck.NewStore(r.ConvoyApp.configRepo))
 	err = fn(context.Background(), task)
 	require.NoError(r.T(), err)
 
@@ -155,7 +158,7 @@ func (r *RetentionPoliciesIntegrationTestSuite) Test_Should_Export_Zero_Document
 	//call handler
 	task := asynq.NewTask(string(convoy.TaskName("retention-policies")), nil, asynq.Queue(string(convoy.ScheduleQueue)))
 
-	fn := RententionPolicies(getConfig(), r.ConvoyApp.configRepo, r.ConvoyApp.groupRepo, r.ConvoyApp.eventRepo, r.ConvoyApp.eventDeliveryRepo, r.ConvoyApp.searcher)
+	fn := RententionPolicies(getConfig(), r.ConvoyApp.configRepo, r.ConvoyApp.groupRepo, r.ConvoyApp.eventRepo, r.ConvoyApp.eventDeliveryRepo, r.ConvoyApp.attemptRepo, r.ConvoyApp.searcher, claimcheck.NewStore(r.ConvoyApp.configRepo))
 	err = fn(context.Background(), task)
 	require.NoError(r.T(), err)
 
@@ -169,6 +172,90 @@ func (r *RetentionPoliciesIntegrationTestSuite) Test_Should_Export_Zero_Document
 	require.Equal(r.T(), ed.UID, eventDelivery.UID)
 }
 
+func (r *RetentionPoliciesIntegrationTestSuite) Test_Should_Export_Offloaded_Payloads() {
+	ctx := context.Background()
+
+	//seed instance configuration offloading every payload
+	cfg, err := seedConfiguration(r.DB)
+	require.NoError(r.T(), err)
+
+	cfg.StoragePolicy.PayloadOffloadThreshold = 1
+	err = r.DB.ConfigurationRepo().UpdateConfiguration(ctx, cfg)
+	require.NoError(r.T(), err)
+
+	//seed group
+	groupConfig := &datastore.GroupConfig{
+		Strategy: &datastore.StrategyConfiguration{
+			Type:       "linear",
+			Duration:   20,
+			RetryCount: 4,
+		},
+		RetentionPolicy: &datastore.RetentionPolicyConfiguration{
+			Policy: "72h",
+		},
+		RateLimit:                &datastore.DefaultRateLimitConfig,
+		IsRetentionPolicyEnabled: true,
+	}
+	group, err := testdb.SeedGroup(r.DB, uuid.NewString(), uuid.NewString(), "test", datastore.OutgoingGroup, groupConfig)
+	require.NoError(r.T(), err)
+
+	//seed an offloaded event and its delivery
+	createdAt := time.Now().UTC().Add(-80 * time.Hour)
+	data := []byte(`{"name":"offloaded"}`)
+	event := &datastore.Event{
+		UID:            uuid.NewString(),
+		EventType:      "*",
+		Data:           data,
+		AppID:          uuid.NewString(),
+		GroupID:        group.UID,
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Unix(createdAt.Unix(), 0)),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	payloads := claimcheck.NewStore(r.ConvoyApp.configRepo)
+	err = payloads.Offload(ctx, event)
+	require.NoError(r.T(), err)
+	require.NotEmpty(r.T(), event.DataRef)
+
+	err = r.DB.EventRepo().CreateEvent(ctx, event)
+	require.NoError(r.T(), err)
+
+	eventDelivery, err := seedEventDelivery(r.DB, event.AppID, event.UID, uuid.NewString(), group.UID, "", datastore.SuccessEventStatus, uuid.NewString(), SeedFilter{
+		CreatedAt:      createdAt,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	})
+	require.NoError(r.T(), err)
+
+	eventDelivery.Metadata = &datastore.Metadata{DataRef: event.DataRef}
+	err = r.DB.EventDeliveryRepo().UpdateEventDelivery(ctx, *eventDelivery)
+	require.NoError(r.T(), err)
+
+	//call handler
+	task := asynq.NewTask(string(convoy.TaskName("retention-policies")), nil, asynq.Queue(string(convoy.ScheduleQueue)))
+
+	fn := RententionPolicies(getConfig(), r.ConvoyApp.configRepo, r.ConvoyApp.groupRepo, r.ConvoyApp.eventRepo, r.ConvoyApp.eventDeliveryRepo, r.ConvoyApp.attemptRepo, r.ConvoyApp.searcher, payloads)
+	err = fn(ctx, task)
+	require.NoError(r.T(), err)
+
+	//check that the exported documents carry the payload
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for _, pattern := range []string{"events/*/events.json", "eventdeliveries/*/event_deliveries.json"} {
+		files, err := filepath.Glob(fmt.Sprint(cfg.StoragePolicy.OnPrem.Path, group.OrganisationID, "/", group.UID, "/", pattern))
+		require.NoError(r.T(), err)
+		require.Len(r.T(), files, 1)
+
+		exported, err := os.ReadFile(files[0])
+		require.NoError(r.T(), err)
+		require.Contains(r.T(), string(exported), encoded)
+		require.NotContains(r.T(), string(exported), event.DataRef)
+	}
+
+	//check that the offloaded payload is deleted
+	_, err = payloads.Load(ctx, event.DataRef)
+	require.Error(r.T(), err)
+}
+
 func TestRetentionPoliciesIntegrationSuiteTest(t *testing.T) {
 	suite.Run(t, new(RetentionPoliciesIntegrationTestSuite))
 }
@@ -207,6 +294,7 @@ func buildApplication() *applicationHandler {
 		eventRepo:         db.EventRepo(),
 		configRepo:        db.ConfigurationRepo(),
 		eventDeliveryRepo: db.EventDeliveryRepo(),
+		attemptRepo:       db.DeliveryAttemptRepo(),
 		searcher:          searcher,
 	}
 
@@ -218,6 +306,7 @@ type applicationHandler struct {
 	eventRepo         datastore.EventRepository
 	configRepo        datastore.ConfigurationRepository
 	eventDeliveryRepo datastore.EventDeliveryRepository
+	attemptRepo       datastore.DeliveryAttemptRepository
 	searcher          searcher.Searcher
 }
 
@@ -106,8 +106,12 @@ func processEventDeliveryBatch(ctx context.Context, status datastore.EventDelive
 		}
 
 		batchIDs := make([]string, len(batch))
+		queueIDs := map[convoy.QueueName][]string{}
 		for i := range batch {
 			batchIDs[i] = batch[i].UID
+
+			queueName := batch[i].Priority.EventQueue()
+			queueIDs[queueName] = append(queueIDs[queueName], batch[i].UID)
 		}
 
 		if status == datastore.ProcessingEventStatus {
@@ -117,10 +121,12 @@ func processEventDeliveryBatch(ctx context.Context, status datastore.EventDelive
 			}
 		}
 
-		// remove these event deliveries queue
-		err := q.DeleteEventDeliveriesfromQueue(convoy.EventQueue, batchIDs)
-		if err != nil {
-			log.WithError(err).WithField("ids", batchIDs).Errorf("batch %d: failed to delete event deliveries from zset", batchCount)
+		// remove these event deliveries from their queues
+		for queueName, ids := range queueIDs {
+			err := q.DeleteEventDeliveriesfromQueue(queueName, ids)
+			if err != nil {
+				log.WithError(err).WithField("ids", ids).Errorf("batch %d: failed to delete event deliveries from zset", batchCount)
+			}
 		}
 
 		for i := range batch {
@@ -132,7 +138,7 @@ func processEventDeliveryBatch(ctx context.Context, status datastore.EventDelive
 				Payload: json.RawMessage(delivery.UID),
 				Delay:   1 * time.Second,
 			}
-			err := q.Write(taskName, convoy.EventQueue, job)
+			err := q.Write(taskName, delivery.Priority.EventQueue(), job)
 			if err != nil {
 				log.WithError(err).Errorf("batch %d: failed to send event delivery %s to the queue", batchCount, delivery.ID)
 			}